@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -43,15 +46,67 @@ func main() {
 	if err != nil {
 		ssotLogger.Errorf(mainCtx, "inventoryLogger: %s", err)
 	}
+
+	// Stop syncing on SIGINT or SIGTERM. Signal handling is restored after the first
+	// signal, so another signal kills the process, if sources don't stop in time.
+	daemonCtx, stop := signal.NotifyContext(mainCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-daemonCtx.Done()
+		stop()
+	}()
+
+	netboxInventory, incrementalSources, err := runFullSync(daemonCtx, ssotLogger, inventoryLogger, config)
+	if err != nil {
+		ssotLogger.Error(mainCtx, err)
+		return
+	}
+
+	// Keep syncing changes of incremental sources, until the process is stopped.
+	// Full resync runs all sources again, so orphans can be safely removed.
+	for len(incrementalSources) > 0 && daemonCtx.Err() == nil {
+		failed := runIncrementalSync(daemonCtx, ssotLogger, incrementalSources, netboxInventory)
+		if daemonCtx.Err() != nil {
+			return
+		}
+		// Full resync, retried until at least one incremental source succeeds
+		for {
+			if failed && !waitRetry(daemonCtx) {
+				return
+			}
+			ssotLogger.Info(mainCtx, "Starting full resync of all sources")
+			netboxInventory, incrementalSources, err = runFullSync(daemonCtx, ssotLogger, inventoryLogger, config)
+			if daemonCtx.Err() != nil {
+				return
+			}
+			if err == nil && len(incrementalSources) > 0 {
+				break
+			}
+			if err != nil {
+				ssotLogger.Errorf(mainCtx, "full resync: %s", err)
+			} else {
+				ssotLogger.Errorf(mainCtx, "full resync: syncing of all incremental sources failed")
+			}
+			failed = true
+		}
+	}
+}
+
+// runFullSync initializes new netbox inventory and syncs all sources to it in parallel.
+// Orphans are removed only if all sources were synced successfully and mainCtx is not done. It returns the
+// inventory and incremental sources, that were synced successfully. Error is only
+// returned if the run couldn't start (e.g. netbox is not reachable).
+func runFullSync(mainCtx context.Context, ssotLogger *logger.Logger, inventoryLogger *logger.Logger, config *parser.Config) (*inventory.NetboxInventory, []incrementalSource, error) {
+	startTime := time.Now()
+
 	inventoryCtx := context.WithValue(context.Background(), constants.CtxSourceKey, "inventory")
 	netboxInventory := inventory.NewNetboxInventory(inventoryCtx, inventoryLogger, config.Netbox)
 	ssotLogger.Debug(mainCtx, "Netbox inventory: ", netboxInventory)
 
 	ssotLogger.Info(mainCtx, "Starting initializing netbox inventory")
-	err = netboxInventory.Init()
+	err := netboxInventory.Init()
 	if err != nil {
-		ssotLogger.Error(mainCtx, err)
-		return
+		return nil, nil, err
 	}
 	ssotLogger.Debug(mainCtx, "Netbox inventory initialized: ", netboxInventory)
 
@@ -59,6 +114,10 @@ func main() {
 	successfullRun := true
 	// Variable to store failed sources
 	encounteredErrors := map[string]bool{}
+	var resultLock sync.Mutex
+
+	// Sources, that keep syncing changes after the full sync
+	incrementalSources := []incrementalSource{}
 
 	// Go through all sources and sync data
 	var wg sync.WaitGroup
@@ -68,11 +127,15 @@ func main() {
 		sourceCtx := context.WithValue(mainCtx, constants.CtxSourceKey, sourceConfig.Name)
 		source, err := source.NewSource(sourceCtx, sourceConfig, ssotLogger, netboxInventory)
 		if err != nil {
-			ssotLogger.Error(sourceCtx, err)
-			return
+			return nil, nil, fmt.Errorf("source %s: %s", sourceConfig.Name, err)
 		}
 		ssotLogger.Infof(sourceCtx, "Successfully created source %s", constants.CheckMark)
 		ssotLogger.Debugf(sourceCtx, "Source content: %s", source)
+		if sourceConfig.IncrementalSync {
+			if s, ok := source.(common.IncrementalSource); ok {
+				incrementalSources = append(incrementalSources, incrementalSource{ctx: sourceCtx, config: sourceConfig, source: s})
+			}
+		}
 		wg.Add(1)
 		// Run each source in parallel
 		go func(sourceCtx context.Context, source common.Source) {
@@ -82,13 +145,18 @@ func main() {
 				ssotLogger.Errorf(sourceCtx, "source ctx value is not set")
 				return
 			}
+			markFailed := func() {
+				resultLock.Lock()
+				defer resultLock.Unlock()
+				successfullRun = false
+				encounteredErrors[sourceName] = true
+			}
 			// Source initialization
 			ssotLogger.Info(sourceCtx, "Initializing source")
-			err = source.Init()
+			err := source.Init()
 			if err != nil {
 				ssotLogger.Error(sourceCtx, err)
-				successfullRun = false
-				encounteredErrors[sourceName] = true
+				markFailed()
 				return
 			}
 			ssotLogger.Infof(sourceCtx, "Successfully initialized source %s", constants.CheckMark)
@@ -97,9 +165,8 @@ func main() {
 			ssotLogger.Info(sourceCtx, "Syncing source...")
 			err = source.Sync(netboxInventory)
 			if err != nil {
-				successfullRun = false
 				ssotLogger.Error(sourceCtx, err)
-				encounteredErrors[sourceName] = true
+				markFailed()
 				return
 			}
 			ssotLogger.Infof(sourceCtx, "Source synced successfully %s", constants.CheckMark)
@@ -108,14 +175,14 @@ func main() {
 	wg.Wait()
 
 	// Orphan manager cleanup on successful run and if enabled
-	if config.Netbox.RemoveOrphans && successfullRun {
+	if config.Netbox.RemoveOrphans && successfullRun && mainCtx.Err() == nil {
 		ssotLogger.Info(mainCtx, "Cleaning up orphaned objects...")
 		err = netboxInventory.DeleteOrphans(mainCtx)
 		if err != nil {
 			ssotLogger.Error(mainCtx, err)
-			return
+		} else {
+			ssotLogger.Infof(mainCtx, "%s Successfully removed orphans", constants.CheckMark)
 		}
-		ssotLogger.Infof(mainCtx, "%s Successfully removed orphans", constants.CheckMark)
 	} else {
 		ssotLogger.Info(mainCtx, "Skipping removing orphaned objects...")
 	}
@@ -130,4 +197,64 @@ func main() {
 			ssotLogger.Infof(mainCtx, "%s syncing of source %s failed", constants.WarningSign, source)
 		}
 	}
+
+	syncedIncrementalSources := make([]incrementalSource, 0, len(incrementalSources))
+	for _, s := range incrementalSources {
+		if encounteredErrors[s.config.Name] {
+			ssotLogger.Warningf(s.ctx, "Skipping incremental sync, because full sync of source failed")
+			continue
+		}
+		syncedIncrementalSources = append(syncedIncrementalSources, s)
+	}
+	return netboxInventory, syncedIncrementalSources, nil
+}
+
+type incrementalSource struct {
+	ctx    context.Context //nolint:containedctx
+	config *parser.SourceConfig
+	source common.IncrementalSource
+}
+
+// runIncrementalSync syncs changes of the sources in parallel, until ctx is done,
+// the shortest config.FullResyncInterval of the sources passes, or syncing changes
+// of any source fails. It returns true, if syncing changes failed.
+func runIncrementalSync(ctx context.Context, ssotLogger *logger.Logger, sources []incrementalSource, nbi *inventory.NetboxInventory) bool {
+	fullResyncInterval := sources[0].config.FullResyncInterval
+	for _, s := range sources {
+		fullResyncInterval = min(fullResyncInterval, s.config.FullResyncInterval)
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, time.Duration(fullResyncInterval)*time.Hour)
+	defer cancel()
+
+	failed := false
+	var failedLock sync.Mutex
+	var wg sync.WaitGroup
+	for _, s := range sources {
+		wg.Add(1)
+		go func(s incrementalSource) {
+			defer wg.Done()
+			err := s.source.SyncUpdates(syncCtx, nbi)
+			if err != nil && syncCtx.Err() == nil {
+				ssotLogger.Errorf(s.ctx, "incremental sync: %s", err)
+				failedLock.Lock()
+				failed = true
+				failedLock.Unlock()
+				// Other sources are stopped too, because full resync runs all sources
+				cancel()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return failed
+}
+
+// waitRetry waits for constants.IncrementalSyncRetryInterval.
+// It returns false, if ctx is done before that.
+func waitRetry(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(constants.IncrementalSyncRetryInterval):
+		return true
+	}
 }
@@ -17,6 +17,7 @@ require (
 	github.com/diskfs/go-diskfs v1.4.0 // indirect
 	github.com/go-resty/resty/v2 v2.12.0 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/websocket v1.5.1 // indirect
 	github.com/jinzhu/copier v0.4.0 // indirect
 	github.com/magefile/mage v1.15.0 // indirect
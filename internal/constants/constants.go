@@ -1,15 +1,19 @@
 package constants
 
+import "time"
+
 type SourceType string
 
 const (
-	Ovirt     SourceType = "ovirt"
-	Vmware    SourceType = "vmware"
-	Dnac      SourceType = "dnac"
-	Proxmox   SourceType = "proxmox"
-	PaloAlto  SourceType = "paloalto"
-	Fortigate SourceType = "fortigate"
-	FMC       SourceType = "fmc"
+	Ovirt        SourceType = "ovirt"
+	Vmware       SourceType = "vmware"
+	Dnac         SourceType = "dnac"
+	Proxmox      SourceType = "proxmox"
+	PaloAlto     SourceType = "paloalto"
+	Fortigate    SourceType = "fortigate"
+	FMC          SourceType = "fmc"
+	Panorama     SourceType = "panorama"
+	FortiManager SourceType = "fortimanager"
 )
 
 const DefaultNetboxTagColor = "00add8"
@@ -19,8 +23,31 @@ const DefaultArpTagName = "arp-entry"
 const DefaultArpTagColor = ColorRed
 const ArpLastSeenFormat = "2006-01-02 15:04:05"
 
+const DefaultVMTagColor = ColorGrey
+
+// Items of vsphere content libraries are synced as tags with this color. Slugs
+// of the tags start with the prefix, so they can be removed as orphans.
+const DefaultContentLibraryTagColor = ColorBlue
+const ContentLibraryTagSlugPrefix = "content-library-"
+
+// Netbox has no dhcp status for ip ranges, so dhcp pools are
+// synced as active ip ranges marked with this tag.
+const DefaultDhcpPoolTagName = "dhcp-pool"
+const DefaultDhcpPoolTagColor = ColorGreen
+
+// Netbox requires a RIR for each ASN. Since RIR of collected ASNs is unknown,
+// private ASNs (RFC 6996) and public ASNs are assigned to these default RIRs.
+const DefaultPrivateASNRIRName = "RFC 6996"
+const DefaultPublicASNRIRName = "Public ASNs"
+
 const DefaultArpDataLifeSpan = 60 * 60 * 24 * 2 // 2 days in seconds
 
+// Interval in hours, after which incremental sync falls back to full resync.
+const DefaultFullResyncInterval = 24
+
+// Time to wait before retrying failed incremental sync.
+const IncrementalSyncRetryInterval = time.Minute
+
 const (
 	DefaultOSName       string = "Generic OS"
 	DefaultOSVersion    string = "Generic Version"
@@ -65,22 +92,26 @@ const (
 // E.g. we name a source "prodvmware", tag "Source: prodvmware" is created
 // with our color.
 var SourceTagColorMap = map[SourceType]string{
-	Ovirt:     ColorDarkRed,
-	Vmware:    ColorLightGreen,
-	Dnac:      ColorLightBlue,
-	PaloAlto:  ColorDarkOrange,
-	Fortigate: ColorDarkGreen,
-	FMC:       ColorLightBlue,
+	Ovirt:        ColorDarkRed,
+	Vmware:       ColorLightGreen,
+	Dnac:         ColorLightBlue,
+	PaloAlto:     ColorDarkOrange,
+	Fortigate:    ColorDarkGreen,
+	FMC:          ColorLightBlue,
+	Panorama:     ColorDarkOrange,
+	FortiManager: ColorDarkGreen,
 }
 
 // Each source Mapping for source type tag. E.g. tag "paloalto" -> color orange.
 var SourceTypeTagColorMap = map[SourceType]string{
-	Ovirt:     ColorRed,
-	Vmware:    ColorGreen,
-	Dnac:      ColorBlue,
-	PaloAlto:  ColorOrange,
-	Fortigate: ColorDarkGreen,
-	FMC:       ColorBlue,
+	Ovirt:        ColorRed,
+	Vmware:       ColorGreen,
+	Dnac:         ColorBlue,
+	PaloAlto:     ColorOrange,
+	Fortigate:    ColorDarkGreen,
+	FMC:          ColorBlue,
+	Panorama:     ColorOrange,
+	FortiManager: ColorDarkGreen,
 }
 
 const (
@@ -143,6 +174,49 @@ const (
 	CustomFieldArpIPLastSeenName        = "last_seen"
 	CustomFieldArpIPLastSeenLabel       = "Last seen"
 	CustomFieldArpIPLastSeenDescription = "Last time the IP was found in the arp table"
+
+	// Custom fields for virtualization.virtualmachine, so we can track vm's placement in vsphere.
+	CustomFieldVMFolderName              = "vmware_folder"
+	CustomFieldVMFolderLabel             = "VMware folder"
+	CustomFieldVMFolderDescription       = "Inventory path of the vm's folder in vsphere"
+	CustomFieldVMResourcePoolName        = "vmware_resource_pool"
+	CustomFieldVMResourcePoolLabel       = "VMware resource pool"
+	CustomFieldVMResourcePoolDescription = "Inventory path of the vm's resource pool in vsphere"
+	CustomFieldVMVAppName                = "vmware_vapp"
+	CustomFieldVMVAppLabel               = "VMware vApp"
+	CustomFieldVMVAppDescription         = "Name of the vApp, that the vm is part of"
+
+	// Custom fields for virtualization.virtualmachine, so we can track vm's pool and storage in ovirt.
+	CustomFieldVMPoolName                  = "ovirt_vm_pool"
+	CustomFieldVMPoolLabel                 = "oVirt vm pool"
+	CustomFieldVMPoolDescription           = "Name of the vm pool, that the vm is part of"
+	CustomFieldVMStorageDomainsName        = "ovirt_storage_domains"
+	CustomFieldVMStorageDomainsLabel       = "oVirt storage domains"
+	CustomFieldVMStorageDomainsDescription = "Storage domains of the vm's disks"
+	CustomFieldVMDisksName                 = "ovirt_disks"
+	CustomFieldVMDisksLabel                = "oVirt disks"
+	CustomFieldVMDisksDescription          = "Vm's disks with their storage domains and provisioned sizes"
+
+	// Custom field for ipam.prefix, so we can track next hop of prefixes collected from routing tables.
+	CustomFieldPrefixNextHopName        = "next_hop"
+	CustomFieldPrefixNextHopLabel       = "Next hop"
+	CustomFieldPrefixNextHopDescription = "Next hop address or interface of the route to the prefix"
+
+	// Custom fields for dcim.device, so we can track device's bgp configuration.
+	CustomFieldBGPLocalASName        = "bgp_local_as"
+	CustomFieldBGPLocalASLabel       = "BGP local AS"
+	CustomFieldBGPLocalASDescription = "Local autonomous system number of the device's bgp process"
+	CustomFieldBGPPeersName          = "bgp_peers"
+	CustomFieldBGPPeersLabel         = "BGP peers"
+	CustomFieldBGPPeersDescription   = "Bgp peers of the device with their remote autonomous system numbers"
+
+	// Custom fields for dcim.device, so we can track uplink and wireless controller of access points.
+	CustomFieldAPUplinkName                  = "ap_uplink"
+	CustomFieldAPUplinkLabel                 = "AP uplink"
+	CustomFieldAPUplinkDescription           = "Switch port to which the access point is connected"
+	CustomFieldWirelessControllerName        = "wireless_controller"
+	CustomFieldWirelessControllerLabel       = "Wireless controller"
+	CustomFieldWirelessControllerDescription = "Wireless lan controller with which the access point is associated"
 )
 
 // Device Role constants.
@@ -155,6 +229,9 @@ const (
 
 	DeviceRoleContainer      = "Container"
 	DeviceRoleContainerColor = "0db7ed"
+
+	DeviceRoleVMTemplate      = "VM Template"
+	DeviceRoleVMTemplateColor = ColorGrey
 )
 
 // Constants used for variables in our contexts.
@@ -178,15 +255,26 @@ const (
 	ContentTypeDcimDeviceType               = "dcim.devicetype"
 	ContentTypeDcimInterface                = "dcim.interface"
 	ContentTypeDcimLocation                 = "dcim.location"
+	ContentTypeDcimMACAddress               = "dcim.macaddress"
 	ContentTypeDcimManufacturer             = "dcim.manufacturer"
+	ContentTypeDcimModule                   = "dcim.module"
+	ContentTypeDcimModuleBay                = "dcim.modulebay"
+	ContentTypeDcimModuleType               = "dcim.moduletype"
+	ContentTypeDcimInventoryItem            = "dcim.inventoryitem"
 	ContentTypeDcimPlatform                 = "dcim.platform"
 	ContentTypeDcimRegion                   = "dcim.region"
 	ContentTypeDcimSite                     = "dcim.site"
+	ContentTypeDcimVirtualChassis           = "dcim.virtualchassis"
 	ContentTypeVirtualDeviceContext         = "dcim.virtualdevicecontext"
+	ContentTypeIpamFHRPGroup                = "ipam.fhrpgroup"
 	ContentTypeIpamIPAddress                = "ipam.ipaddress"
 	ContentTypeIpamVlanGroup                = "ipam.vlangroup"
 	ContentTypeIpamVlan                     = "ipam.vlan"
 	ContentTypeIpamPrefix                   = "ipam.prefix"
+	ContentTypeIpamVRF                      = "ipam.vrf"
+	ContentTypeIpamIPRange                  = "ipam.iprange"
+	ContentTypeIpamASN                      = "ipam.asn"
+	ContentTypeIpamRIR                      = "ipam.rir"
 	ContentTypeTenancyTenantGroup           = "tenancy.tenantgroup"
 	ContentTypeTenancyTenant                = "tenancy.tenant"
 	ContentTypeTenancyContact               = "tenancy.contact"
@@ -198,6 +286,15 @@ const (
 	ContentTypeVirtualizationClusterType    = "virtualization.clustertype"
 	ContentTypeVirtualizationVirtualMachine = "virtualization.virtualmachine"
 	ContentTypeVirtualizationVMInterface    = "virtualization.vminterface"
+	ContentTypeVpnTunnel                    = "vpn.tunnel"
+	ContentTypeVpnTunnelTermination         = "vpn.tunneltermination"
+	ContentTypeVpnIKEProposal               = "vpn.ikeproposal"
+	ContentTypeVpnIKEPolicy                 = "vpn.ikepolicy"
+	ContentTypeVpnIPSecProposal             = "vpn.ipsecproposal"
+	ContentTypeVpnIPSecPolicy               = "vpn.ipsecpolicy"
+	ContentTypeVpnIPSecProfile              = "vpn.ipsecprofile"
+	ContentTypeWirelessWirelessLAN          = "wireless.wirelesslan"
+	ContentTypeBGPSession                   = "netbox_bgp.bgpsession"
 )
 
 // Here all mappings are defined so we don't hardcode api paths of objects
@@ -211,10 +308,16 @@ const (
 	ContactAssignmentsAPIPath = "/api/tenancy/contact-assignments/"
 
 	// IPAM paths.
-	PrefixesAPIPath    = "/api/ipam/prefixes/"
-	VlanGroupsAPIPath  = "/api/ipam/vlan-groups/"
-	VlansAPIPath       = "/api/ipam/vlans/"
-	IPAddressesAPIPath = "/api/ipam/ip-addresses/"
+	PrefixesAPIPath             = "/api/ipam/prefixes/"
+	VlanGroupsAPIPath           = "/api/ipam/vlan-groups/"
+	VlansAPIPath                = "/api/ipam/vlans/"
+	IPAddressesAPIPath          = "/api/ipam/ip-addresses/"
+	FHRPGroupsAPIPath           = "/api/ipam/fhrp-groups/"
+	FHRPGroupAssignmentsAPIPath = "/api/ipam/fhrp-group-assignments/"
+	VRFsAPIPath                 = "/api/ipam/vrfs/"
+	IPRangesAPIPath             = "/api/ipam/ip-ranges/"
+	ASNsAPIPath                 = "/api/ipam/asns/"
+	RIRsAPIPath                 = "/api/ipam/rirs/"
 
 	// Virtualization paths.
 	ClusterTypesAPIPath    = "/api/virtualization/cluster-types/"
@@ -229,11 +332,37 @@ const (
 	DeviceTypesAPIPath           = "/api/dcim/device-types/"
 	InterfacesAPIPath            = "/api/dcim/interfaces/"
 	SitesAPIPath                 = "/api/dcim/sites/"
+	RegionsAPIPath               = "/api/dcim/regions/"
+	LocationsAPIPath             = "/api/dcim/locations/"
 	ManufacturersAPIPath         = "/api/dcim/manufacturers/"
 	PlatformsAPIPath             = "/api/dcim/platforms/"
 	VirtualDeviceContextsAPIPath = "/api/dcim/virtual-device-contexts/"
+	MACAddressesAPIPath          = "/api/dcim/mac-addresses/"
+	VirtualChassisAPIPath        = "/api/dcim/virtual-chassis/"
+	ModuleTypesAPIPath           = "/api/dcim/module-types/"
+	ModuleBaysAPIPath            = "/api/dcim/module-bays/"
+	ModulesAPIPath               = "/api/dcim/modules/"
+	InventoryItemsAPIPath        = "/api/dcim/inventory-items/"
+
+	// VPN paths.
+	TunnelsAPIPath            = "/api/vpn/tunnels/"
+	TunnelTerminationsAPIPath = "/api/vpn/tunnel-terminations/"
+	IKEProposalsAPIPath       = "/api/vpn/ike-proposals/"
+	IKEPoliciesAPIPath        = "/api/vpn/ike-policies/"
+	IPSecProposalsAPIPath     = "/api/vpn/ipsec-proposals/"
+	IPSecPoliciesAPIPath      = "/api/vpn/ipsec-policies/"
+	IPSecProfilesAPIPath      = "/api/vpn/ipsec-profiles/"
+
+	// Wireless paths.
+	WirelessLANsAPIPath = "/api/wireless/wireless-lans/"
 
 	// Extras paths.
 	CustomFieldsAPIPath = "/api/extras/custom-fields/"
 	TagsAPIPath         = "/api/extras/tags/"
+
+	// Plugin paths. Objects of plugins are synced only if plugin is installed.
+	BGPSessionsAPIPath = "/api/plugins/bgp/session/"
+
+	// Status path.
+	StatusAPIPath = "/api/status/"
 )
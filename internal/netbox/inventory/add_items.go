@@ -3,6 +3,9 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
@@ -16,6 +19,8 @@ func (nbi *NetboxInventory) AddTag(ctx context.Context, newTag *objects.Tag) (*o
 	defer nbi.TagsLock.Unlock()
 	if _, ok := nbi.TagsIndexByName[newTag.Name]; ok {
 		oldTag := nbi.TagsIndexByName[newTag.Name]
+		// Delete id from orphan manager, because it still exists in the sources
+		delete(nbi.OrphanManager[constants.TagsAPIPath], oldTag.ID)
 		diffMap, err := utils.JSONDiffMapExceptID(newTag, oldTag, false, nbi.SourcePriority)
 		if err != nil {
 			return nil, err
@@ -81,6 +86,8 @@ func (nbi *NetboxInventory) AddSite(ctx context.Context, newSite *objects.Site)
 	defer nbi.SitesLock.Unlock()
 	if _, ok := nbi.SitesIndexByName[newSite.Name]; ok {
 		oldSite := nbi.SitesIndexByName[newSite.Name]
+		// Delete id from orphan manager, because it still exists in the sources
+		delete(nbi.OrphanManager[constants.SitesAPIPath], oldSite.ID)
 		diffMap, err := utils.JSONDiffMapExceptID(newSite, oldSite, false, nbi.SourcePriority)
 		if err != nil {
 			return nil, err
@@ -106,6 +113,98 @@ func (nbi *NetboxInventory) AddSite(ctx context.Context, newSite *objects.Site)
 	return nbi.SitesIndexByName[newSite.Name], nil
 }
 
+// AddRegion adds newRegion to the local netbox inventory.
+func (nbi *NetboxInventory) AddRegion(ctx context.Context, newRegion *objects.Region) (*objects.Region, error) {
+	nbi.RegionsLock.Lock()
+	defer nbi.RegionsLock.Unlock()
+	newRegion.Tags = append(newRegion.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newRegion.NetboxObject)
+	parentID := regionParentID(newRegion)
+	if oldRegion, ok := nbi.RegionsIndexByParentIDAndName[parentID][newRegion.Name]; ok {
+		delete(nbi.OrphanManager[constants.RegionsAPIPath], oldRegion.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newRegion, oldRegion, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Region ", newRegion.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedRegion, err := service.Patch[objects.Region](ctx, nbi.NetboxAPI, oldRegion.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.RegionsIndexByParentIDAndName[parentID][newRegion.Name] = patchedRegion
+		} else {
+			nbi.Logger.Debug(ctx, "Region ", newRegion.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Region ", newRegion.Name, " does not exist in Netbox. Creating it...")
+		newRegion, err := service.Create[objects.Region](ctx, nbi.NetboxAPI, newRegion)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.RegionsIndexByParentIDAndName == nil {
+			nbi.RegionsIndexByParentIDAndName = make(map[int]map[string]*objects.Region)
+		}
+		if nbi.RegionsIndexByParentIDAndName[parentID] == nil {
+			nbi.RegionsIndexByParentIDAndName[parentID] = make(map[string]*objects.Region)
+		}
+		nbi.RegionsIndexByParentIDAndName[parentID][newRegion.Name] = newRegion
+		return newRegion, nil
+	}
+	return nbi.RegionsIndexByParentIDAndName[parentID][newRegion.Name], nil
+}
+
+// regionParentID returns id of the region's parent, or 0 for root regions.
+func regionParentID(region *objects.Region) int {
+	if region.Parent == nil {
+		return 0
+	}
+	return region.Parent.ID
+}
+
+// AddLocation adds newLocation to the local netbox inventory.
+func (nbi *NetboxInventory) AddLocation(ctx context.Context, newLocation *objects.Location) (*objects.Location, error) {
+	nbi.LocationsLock.Lock()
+	defer nbi.LocationsLock.Unlock()
+	newLocation.Tags = append(newLocation.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newLocation.NetboxObject)
+	if newLocation.Site == nil {
+		return nil, fmt.Errorf("location %s is not assigned to a site, but it should be", newLocation.Name)
+	}
+	if oldLocation, ok := nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID][newLocation.Name]; ok {
+		delete(nbi.OrphanManager[constants.LocationsAPIPath], oldLocation.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newLocation, oldLocation, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Location ", newLocation.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedLocation, err := service.Patch[objects.Location](ctx, nbi.NetboxAPI, oldLocation.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID][newLocation.Name] = patchedLocation
+		} else {
+			nbi.Logger.Debug(ctx, "Location ", newLocation.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Location ", newLocation.Name, " does not exist in Netbox. Creating it...")
+		newLocation, err := service.Create[objects.Location](ctx, nbi.NetboxAPI, newLocation)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.LocationsIndexBySiteIDAndName == nil {
+			nbi.LocationsIndexBySiteIDAndName = make(map[int]map[string]*objects.Location)
+		}
+		if nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID] == nil {
+			nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID] = make(map[string]*objects.Location)
+		}
+		nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID][newLocation.Name] = newLocation
+		return newLocation, nil
+	}
+	return nbi.LocationsIndexBySiteIDAndName[newLocation.Site.ID][newLocation.Name], nil
+}
+
 // AddContactRole adds the newContactRole to the local netbox inventory.
 func (nbi *NetboxInventory) AddContactRole(ctx context.Context, newContactRole *objects.ContactRole) (*objects.ContactRole, error) {
 	newContactRole.NetboxObject.Tags = []*objects.Tag{nbi.SsotTag}
@@ -398,6 +497,62 @@ func (nbi *NetboxInventory) AddCluster(ctx context.Context, newCluster *objects.
 	return nbi.ClustersIndexByName[newCluster.Name], nil
 }
 
+// MoveDevice moves already existing device with the given name from oldSite to newSite
+// and newLocation, so the device is kept, when site of the device changes (e.g. dnac
+// floors, that were synced as sites, are now synced as locations within their building).
+// Nothing is done, if the device already exists in newSite or doesn't exist in oldSite.
+func (nbi *NetboxInventory) MoveDevice(ctx context.Context, name string, oldSite *objects.Site, newSite *objects.Site, newLocation *objects.Location) error {
+	nbi.DevicesLock.Lock()
+	defer nbi.DevicesLock.Unlock()
+	if oldSite == nil || newSite == nil || oldSite.ID == newSite.ID {
+		return nil
+	}
+	if _, ok := nbi.DevicesIndexByNameAndSiteID[name][newSite.ID]; ok {
+		return nil
+	}
+	oldDevice, ok := nbi.DevicesIndexByNameAndSiteID[name][oldSite.ID]
+	if !ok {
+		return nil
+	}
+	var locationID interface{}
+	if newLocation != nil {
+		locationID = newLocation.ID
+	}
+	nbi.Logger.Debug(ctx, "Moving device ", name, " from site ", oldSite.Name, " to site ", newSite.Name)
+	patchedDevice, err := service.Patch[objects.Device](ctx, nbi.NetboxAPI, oldDevice.ID, map[string]interface{}{
+		"site":     newSite.ID,
+		"location": locationID,
+	})
+	if err != nil {
+		return err
+	}
+	delete(nbi.DevicesIndexByNameAndSiteID[name], oldSite.ID)
+	nbi.DevicesIndexByNameAndSiteID[name][newSite.ID] = patchedDevice
+	return nil
+}
+
+// RenameCluster renames already existing cluster, so devices and virtual
+// machines assigned to it are kept, when name of the cluster changes.
+func (nbi *NetboxInventory) RenameCluster(ctx context.Context, oldName string, newName string) (*objects.Cluster, error) {
+	nbi.ClustersLock.Lock()
+	defer nbi.ClustersLock.Unlock()
+	oldCluster, ok := nbi.ClustersIndexByName[oldName]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s does not exist in the inventory", oldName)
+	}
+	if _, ok := nbi.ClustersIndexByName[newName]; ok {
+		return nil, fmt.Errorf("cluster %s already exists in the inventory", newName)
+	}
+	nbi.Logger.Debug(ctx, "Renaming cluster ", oldName, " to ", newName)
+	patchedCluster, err := service.Patch[objects.Cluster](ctx, nbi.NetboxAPI, oldCluster.ID, map[string]interface{}{"name": newName})
+	if err != nil {
+		return nil, err
+	}
+	delete(nbi.ClustersIndexByName, oldName)
+	nbi.ClustersIndexByName[newName] = patchedCluster
+	return patchedCluster, nil
+}
+
 // AddDeviceRole adds a new device role to the Netbox inventory.
 // It takes a context and a newDeviceRole object as input and returns the created device role object and an error, if any.
 // If the device role already exists in Netbox, it checks if it is up to date and patches it if necessary.
@@ -579,6 +734,72 @@ func (nbi *NetboxInventory) AddDevice(ctx context.Context, newDevice *objects.De
 	return nbi.DevicesIndexByNameAndSiteID[newDevice.Name][newDevice.Site.ID], nil
 }
 
+// AddVirtualChassis adds new virtual chassis to the local inventory.
+func (nbi *NetboxInventory) AddVirtualChassis(ctx context.Context, newVirtualChassis *objects.VirtualChassis) (*objects.VirtualChassis, error) {
+	nbi.VirtualChassisLock.Lock()
+	defer nbi.VirtualChassisLock.Unlock()
+	newVirtualChassis.Tags = append(newVirtualChassis.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newVirtualChassis.NetboxObject)
+	if _, ok := nbi.VirtualChassisIndexByName[newVirtualChassis.Name]; ok {
+		oldVirtualChassis := nbi.VirtualChassisIndexByName[newVirtualChassis.Name]
+		delete(nbi.OrphanManager[constants.VirtualChassisAPIPath], oldVirtualChassis.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newVirtualChassis, oldVirtualChassis, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Virtual chassis ", newVirtualChassis.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedVirtualChassis, err := service.Patch[objects.VirtualChassis](ctx, nbi.NetboxAPI, oldVirtualChassis.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.VirtualChassisIndexByName[newVirtualChassis.Name] = patchedVirtualChassis
+		} else {
+			nbi.Logger.Debug(ctx, "Virtual chassis ", newVirtualChassis.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Virtual chassis ", newVirtualChassis.Name, " does not exist in Netbox. Creating it...")
+		newVirtualChassis, err := service.Create[objects.VirtualChassis](ctx, nbi.NetboxAPI, newVirtualChassis)
+		if err != nil {
+			return nil, err
+		}
+		nbi.VirtualChassisIndexByName[newVirtualChassis.Name] = newVirtualChassis
+	}
+	return nbi.VirtualChassisIndexByName[newVirtualChassis.Name], nil
+}
+
+// GetVirtualChassis returns already synced virtual chassis with the given name.
+func (nbi *NetboxInventory) GetVirtualChassis(name string) (*objects.VirtualChassis, bool) {
+	nbi.VirtualChassisLock.Lock()
+	defer nbi.VirtualChassisLock.Unlock()
+	virtualChassis, ok := nbi.VirtualChassisIndexByName[name]
+	return virtualChassis, ok
+}
+
+// SetVirtualChassisMaster sets master of the already synced virtual chassis.
+// Only the master is patched, so other attributes of the virtual chassis
+// stay owned by the source that created it.
+func (nbi *NetboxInventory) SetVirtualChassisMaster(ctx context.Context, virtualChassis *objects.VirtualChassis, master *objects.Device) (*objects.VirtualChassis, error) {
+	nbi.VirtualChassisLock.Lock()
+	defer nbi.VirtualChassisLock.Unlock()
+	oldVirtualChassis, ok := nbi.VirtualChassisIndexByName[virtualChassis.Name]
+	if !ok {
+		return nil, fmt.Errorf("virtual chassis %s does not exist in the inventory", virtualChassis.Name)
+	}
+	delete(nbi.OrphanManager[constants.VirtualChassisAPIPath], oldVirtualChassis.ID)
+	if oldVirtualChassis.Master != nil && oldVirtualChassis.Master.ID == master.ID {
+		nbi.Logger.Debug(ctx, "Device ", master.Name, " is already master of virtual chassis ", virtualChassis.Name)
+		return oldVirtualChassis, nil
+	}
+	nbi.Logger.Debug(ctx, "Setting device ", master.Name, " as master of virtual chassis ", virtualChassis.Name)
+	patchedVirtualChassis, err := service.Patch[objects.VirtualChassis](ctx, nbi.NetboxAPI, oldVirtualChassis.ID, map[string]interface{}{"master": master.ID})
+	if err != nil {
+		return nil, err
+	}
+	nbi.VirtualChassisIndexByName[virtualChassis.Name] = patchedVirtualChassis
+	return patchedVirtualChassis, nil
+}
+
 // AddVirtualDeviceContext adds new virtual device context to the local inventory.
 func (nbi *NetboxInventory) AddVirtualDeviceContext(ctx context.Context, newVDC *objects.VirtualDeviceContext) (*objects.VirtualDeviceContext, error) {
 	nbi.DevicesLock.Lock()
@@ -619,6 +840,160 @@ func (nbi *NetboxInventory) AddVirtualDeviceContext(ctx context.Context, newVDC
 	return nbi.VirtualDeviceContextsIndexByNameAndDeviceID[newVDC.Name][newVDC.Device.ID], nil
 }
 
+// AddModuleType adds newModuleType to the local netbox inventory.
+func (nbi *NetboxInventory) AddModuleType(ctx context.Context, newModuleType *objects.ModuleType) (*objects.ModuleType, error) {
+	nbi.ModuleTypesLock.Lock()
+	defer nbi.ModuleTypesLock.Unlock()
+	newModuleType.Tags = append(newModuleType.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModuleType.NetboxObject)
+	if newModuleType.Manufacturer == nil {
+		return nil, fmt.Errorf("module type %s is not assigned to a manufacturer, but it should be", newModuleType)
+	}
+	if oldModuleType, ok := nbi.ModuleTypesIndexByModel[newModuleType.Model]; ok {
+		delete(nbi.OrphanManager[constants.ModuleTypesAPIPath], oldModuleType.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newModuleType, oldModuleType, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Module type ", newModuleType.Model, " already exists in Netbox but is out of date. Patching it...")
+			patchedModuleType, err := service.Patch[objects.ModuleType](ctx, nbi.NetboxAPI, oldModuleType.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.ModuleTypesIndexByModel[newModuleType.Model] = patchedModuleType
+		} else {
+			nbi.Logger.Debug(ctx, "Module type ", newModuleType.Model, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module type ", newModuleType.Model, " does not exist in Netbox. Creating it...")
+		newModuleType, err := service.Create[objects.ModuleType](ctx, nbi.NetboxAPI, newModuleType)
+		if err != nil {
+			return nil, err
+		}
+		nbi.ModuleTypesIndexByModel[newModuleType.Model] = newModuleType
+		return newModuleType, nil
+	}
+	return nbi.ModuleTypesIndexByModel[newModuleType.Model], nil
+}
+
+// AddModuleBay adds newModuleBay to the local netbox inventory.
+func (nbi *NetboxInventory) AddModuleBay(ctx context.Context, newModuleBay *objects.ModuleBay) (*objects.ModuleBay, error) {
+	nbi.ModuleBaysLock.Lock()
+	defer nbi.ModuleBaysLock.Unlock()
+	newModuleBay.Tags = append(newModuleBay.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModuleBay.NetboxObject)
+	if newModuleBay.Device == nil {
+		return nil, fmt.Errorf("module bay %s is not assigned to a device, but it should be", newModuleBay.Name)
+	}
+	if oldModuleBay, ok := nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name]; ok {
+		delete(nbi.OrphanManager[constants.ModuleBaysAPIPath], oldModuleBay.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newModuleBay, oldModuleBay, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Module bay ", newModuleBay.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedModuleBay, err := service.Patch[objects.ModuleBay](ctx, nbi.NetboxAPI, oldModuleBay.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name] = patchedModuleBay
+		} else {
+			nbi.Logger.Debug(ctx, "Module bay ", newModuleBay.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module bay ", newModuleBay.Name, " does not exist in Netbox. Creating it...")
+		newModuleBay, err := service.Create[objects.ModuleBay](ctx, nbi.NetboxAPI, newModuleBay)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID] == nil {
+			nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID] = make(map[string]*objects.ModuleBay)
+		}
+		nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name] = newModuleBay
+		return newModuleBay, nil
+	}
+	return nbi.ModuleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name], nil
+}
+
+// AddModule adds newModule to the local netbox inventory.
+func (nbi *NetboxInventory) AddModule(ctx context.Context, newModule *objects.Module) (*objects.Module, error) {
+	nbi.ModulesLock.Lock()
+	defer nbi.ModulesLock.Unlock()
+	newModule.Tags = append(newModule.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModule.NetboxObject)
+	if newModule.ModuleBay == nil || newModule.ModuleType == nil {
+		return nil, fmt.Errorf("module %s is not assigned to a module bay and module type, but it should be", newModule.Serial)
+	}
+	if oldModule, ok := nbi.ModulesIndexByModuleBayID[newModule.ModuleBay.ID]; ok {
+		delete(nbi.OrphanManager[constants.ModulesAPIPath], oldModule.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newModule, oldModule, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Module in bay ", newModule.ModuleBay.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedModule, err := service.Patch[objects.Module](ctx, nbi.NetboxAPI, oldModule.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.ModulesIndexByModuleBayID[newModule.ModuleBay.ID] = patchedModule
+		} else {
+			nbi.Logger.Debug(ctx, "Module in bay ", newModule.ModuleBay.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module in bay ", newModule.ModuleBay.Name, " does not exist in Netbox. Creating it...")
+		newModule, err := service.Create[objects.Module](ctx, nbi.NetboxAPI, newModule)
+		if err != nil {
+			return nil, err
+		}
+		nbi.ModulesIndexByModuleBayID[newModule.ModuleBay.ID] = newModule
+		return newModule, nil
+	}
+	return nbi.ModulesIndexByModuleBayID[newModule.ModuleBay.ID], nil
+}
+
+// AddInventoryItem adds newInventoryItem to the local netbox inventory.
+func (nbi *NetboxInventory) AddInventoryItem(ctx context.Context, newInventoryItem *objects.InventoryItem) (*objects.InventoryItem, error) {
+	nbi.InventoryItemsLock.Lock()
+	defer nbi.InventoryItemsLock.Unlock()
+	newInventoryItem.Tags = append(newInventoryItem.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newInventoryItem.NetboxObject)
+	if newInventoryItem.Device == nil {
+		return nil, fmt.Errorf("inventory item %s is not assigned to a device, but it should be", newInventoryItem.Name)
+	}
+	if oldInventoryItem, ok := nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID][newInventoryItem.Name]; ok {
+		delete(nbi.OrphanManager[constants.InventoryItemsAPIPath], oldInventoryItem.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newInventoryItem, oldInventoryItem, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Inventory item ", newInventoryItem.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedInventoryItem, err := service.Patch[objects.InventoryItem](ctx, nbi.NetboxAPI, oldInventoryItem.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID][newInventoryItem.Name] = patchedInventoryItem
+		} else {
+			nbi.Logger.Debug(ctx, "Inventory item ", newInventoryItem.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Inventory item ", newInventoryItem.Name, " does not exist in Netbox. Creating it...")
+		newInventoryItem, err := service.Create[objects.InventoryItem](ctx, nbi.NetboxAPI, newInventoryItem)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID] == nil {
+			nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID] = make(map[string]*objects.InventoryItem)
+		}
+		nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID][newInventoryItem.Name] = newInventoryItem
+		return newInventoryItem, nil
+	}
+	return nbi.InventoryItemsIndexByDeviceIDAndName[newInventoryItem.Device.ID][newInventoryItem.Name], nil
+}
+
 func (nbi *NetboxInventory) AddVlanGroup(ctx context.Context, newVlanGroup *objects.VlanGroup) (*objects.VlanGroup, error) {
 	nbi.VlanGroupsLock.Lock()
 	defer nbi.VlanGroupsLock.Unlock()
@@ -692,6 +1067,13 @@ func (nbi *NetboxInventory) AddVlan(ctx context.Context, newVlan *objects.Vlan)
 func (nbi *NetboxInventory) AddInterface(ctx context.Context, newInterface *objects.Interface) (*objects.Interface, error) {
 	nbi.InterfacesLock.Lock()
 	defer nbi.InterfacesLock.Unlock()
+	// Since netbox 4.2 mac address is a standalone object, which is
+	// set as interface's primary mac address after the interface is synced.
+	macAddress := newInterface.MAC
+	macTags := slices.Clone(newInterface.Tags)
+	if nbi.SupportsMACAddressObjects() {
+		newInterface.MAC = ""
+	}
 	newInterface.Tags = append(newInterface.Tags, nbi.SsotTag)
 	addSourceNameCustomField(ctx, &newInterface.NetboxObject)
 	if _, ok := nbi.InterfacesIndexByDeviceIDAndName[newInterface.Device.ID][newInterface.Name]; ok {
@@ -723,7 +1105,23 @@ func (nbi *NetboxInventory) AddInterface(ctx context.Context, newInterface *obje
 		}
 		nbi.InterfacesIndexByDeviceIDAndName[newInterface.Device.ID][newInterface.Name] = newInterface
 	}
-	return nbi.InterfacesIndexByDeviceIDAndName[newInterface.Device.ID][newInterface.Name], nil
+	nbInterface := nbi.InterfacesIndexByDeviceIDAndName[newInterface.Device.ID][newInterface.Name]
+	if macAddress != "" && nbi.SupportsMACAddressObjects() {
+		nbMACAddress, err := nbi.addInterfaceMACAddress(ctx, macAddress, macTags, objects.AssignedObjectTypeDeviceInterface, nbInterface.ID)
+		if err != nil {
+			return nil, err
+		}
+		if nbInterface.PrimaryMACAddress == nil || nbInterface.PrimaryMACAddress.ID != nbMACAddress.ID {
+			nbi.Logger.Debug(ctx, "Setting primary mac address ", macAddress, " of interface ", nbInterface.Name)
+			patchedInterface, err := service.Patch[objects.Interface](ctx, nbi.NetboxAPI, nbInterface.ID, map[string]interface{}{"primary_mac_address": nbMACAddress.ID})
+			if err != nil {
+				return nil, err
+			}
+			nbi.InterfacesIndexByDeviceIDAndName[newInterface.Device.ID][newInterface.Name] = patchedInterface
+			nbInterface = patchedInterface
+		}
+	}
+	return nbInterface, nil
 }
 
 func (nbi *NetboxInventory) AddVM(ctx context.Context, newVM *objects.VM) (*objects.VM, error) {
@@ -768,6 +1166,13 @@ func (nbi *NetboxInventory) AddVM(ctx context.Context, newVM *objects.VM) (*obje
 }
 
 func (nbi *NetboxInventory) AddVMInterface(ctx context.Context, newVMInterface *objects.VMInterface) (*objects.VMInterface, error) {
+	// Since netbox 4.2 mac address is a standalone object, which is
+	// set as interface's primary mac address after the interface is synced.
+	macAddress := newVMInterface.MACAddress
+	macTags := slices.Clone(newVMInterface.Tags)
+	if nbi.SupportsMACAddressObjects() {
+		newVMInterface.MACAddress = ""
+	}
 	newVMInterface.Tags = append(newVMInterface.Tags, nbi.SsotTag)
 	nbi.VMInterfacesLock.Lock()
 	defer nbi.VMInterfacesLock.Unlock()
@@ -800,7 +1205,23 @@ func (nbi *NetboxInventory) AddVMInterface(ctx context.Context, newVMInterface *
 		}
 		nbi.VMInterfacesIndexByVMIdAndName[newVMInterface.VM.ID][newVMInterface.Name] = newVMInterface
 	}
-	return nbi.VMInterfacesIndexByVMIdAndName[newVMInterface.VM.ID][newVMInterface.Name], nil
+	nbVMInterface := nbi.VMInterfacesIndexByVMIdAndName[newVMInterface.VM.ID][newVMInterface.Name]
+	if macAddress != "" && nbi.SupportsMACAddressObjects() {
+		nbMACAddress, err := nbi.addInterfaceMACAddress(ctx, macAddress, macTags, objects.AssignedObjectTypeVMInterface, nbVMInterface.ID)
+		if err != nil {
+			return nil, err
+		}
+		if nbVMInterface.PrimaryMACAddress == nil || nbVMInterface.PrimaryMACAddress.ID != nbMACAddress.ID {
+			nbi.Logger.Debug(ctx, "Setting primary mac address ", macAddress, " of vm interface ", nbVMInterface.Name)
+			patchedVMInterface, err := service.Patch[objects.VMInterface](ctx, nbi.NetboxAPI, nbVMInterface.ID, map[string]interface{}{"primary_mac_address": nbMACAddress.ID})
+			if err != nil {
+				return nil, err
+			}
+			nbi.VMInterfacesIndexByVMIdAndName[newVMInterface.VM.ID][newVMInterface.Name] = patchedVMInterface
+			nbVMInterface = patchedVMInterface
+		}
+	}
+	return nbVMInterface, nil
 }
 
 func (nbi *NetboxInventory) AddIPAddress(ctx context.Context, newIPAddress *objects.IPAddress) (*objects.IPAddress, error) {
@@ -808,11 +1229,12 @@ func (nbi *NetboxInventory) AddIPAddress(ctx context.Context, newIPAddress *obje
 	nbi.IPAddressesLock.Lock()
 	defer nbi.IPAddressesLock.Unlock()
 	addSourceNameCustomField(ctx, &newIPAddress.NetboxObject)
-	if _, ok := nbi.IPAdressesIndexByAddress[newIPAddress.Address]; ok {
+	ipAddressesIndex := nbi.ipAddressIndexOf(newIPAddress.Vrf, newIPAddress.Address)
+	if _, ok := ipAddressesIndex[newIPAddress.Address]; ok {
 		// Delete id from orphan manager, because it still exists in the sources
-		delete(nbi.OrphanManager[constants.IPAddressesAPIPath], nbi.IPAdressesIndexByAddress[newIPAddress.Address].ID)
-		diffMap, err := utils.JSONDiffMapExceptID(newIPAddress, nbi.IPAdressesIndexByAddress[newIPAddress.Address], false, nbi.SourcePriority)
-		oldIPAddress := nbi.IPAdressesIndexByAddress[newIPAddress.Address]
+		delete(nbi.OrphanManager[constants.IPAddressesAPIPath], ipAddressesIndex[newIPAddress.Address].ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIPAddress, ipAddressesIndex[newIPAddress.Address], false, nbi.SourcePriority)
+		oldIPAddress := ipAddressesIndex[newIPAddress.Address]
 		if err != nil {
 			return nil, err
 		}
@@ -822,7 +1244,7 @@ func (nbi *NetboxInventory) AddIPAddress(ctx context.Context, newIPAddress *obje
 			if err != nil {
 				return nil, err
 			}
-			nbi.IPAdressesIndexByAddress[newIPAddress.Address] = patchedIPAddress
+			ipAddressesIndex[newIPAddress.Address] = patchedIPAddress
 			return patchedIPAddress, nil
 		}
 		nbi.Logger.Debug(ctx, "IP address ", newIPAddress.Address, " already exists in Netbox and is up to date...")
@@ -832,10 +1254,93 @@ func (nbi *NetboxInventory) AddIPAddress(ctx context.Context, newIPAddress *obje
 		if err != nil {
 			return nil, err
 		}
-		nbi.IPAdressesIndexByAddress[newIPAddress.Address] = newIPAddress
+		ipAddressesIndex[newIPAddress.Address] = newIPAddress
 		return newIPAddress, nil
 	}
-	return nbi.IPAdressesIndexByAddress[newIPAddress.Address], nil
+	return ipAddressesIndex[newIPAddress.Address], nil
+}
+
+// Helper function that returns index of ip addresses within the vrf.
+// IP addresses without vrf are part of the global index.
+func (nbi *NetboxInventory) ipAddressesIndex(vrf *objects.VRF) map[string]*objects.IPAddress {
+	if vrf == nil {
+		return nbi.IPAdressesIndexByAddress
+	}
+	if nbi.IPAddressesIndexByVRFIDAndAddress == nil {
+		nbi.IPAddressesIndexByVRFIDAndAddress = make(map[int]map[string]*objects.IPAddress)
+	}
+	if nbi.IPAddressesIndexByVRFIDAndAddress[vrf.ID] == nil {
+		nbi.IPAddressesIndexByVRFIDAndAddress[vrf.ID] = make(map[string]*objects.IPAddress)
+	}
+	return nbi.IPAddressesIndexByVRFIDAndAddress[vrf.ID]
+}
+
+// Helper function that returns index of ip addresses, which contains the address within the vrf.
+// Sources without vrfs sync ip addresses to the global index, but users can assign them to
+// their own vrfs in netbox. So if the address is not in the global index, ip address with the
+// same address, that is assigned to a single vrf not managed by netbox-ssot, is used.
+func (nbi *NetboxInventory) ipAddressIndexOf(vrf *objects.VRF, address string) map[string]*objects.IPAddress {
+	ipAddressesIndex := nbi.ipAddressesIndex(vrf)
+	if _, ok := ipAddressesIndex[address]; ok || vrf != nil {
+		return ipAddressesIndex
+	}
+	var userVRFIndex map[string]*objects.IPAddress
+	for vrfID, vrfIndex := range nbi.IPAddressesIndexByVRFIDAndAddress {
+		if _, ok := vrfIndex[address]; !ok || nbi.isSsotVRF(vrfID) {
+			continue
+		}
+		if userVRFIndex != nil {
+			// Address is ambiguous, so it is synced to the global index
+			return ipAddressesIndex
+		}
+		userVRFIndex = vrfIndex
+	}
+	if userVRFIndex != nil {
+		return userVRFIndex
+	}
+	return ipAddressesIndex
+}
+
+// GetIPAddress returns already synced ip address within the vrf. Like in AddIPAddress,
+// addresses without vrf are also looked up in vrfs assigned by users.
+func (nbi *NetboxInventory) GetIPAddress(vrf *objects.VRF, address string) (*objects.IPAddress, bool) {
+	nbi.IPAddressesLock.Lock()
+	defer nbi.IPAddressesLock.Unlock()
+	ipAddress, ok := nbi.ipAddressIndexOf(vrf, address)[address]
+	return ipAddress, ok
+}
+
+// ClaimPrefixNextHop returns true, if next hop of the prefix within the vrf wasn't synced
+// yet in this run. Same prefix can be learned by multiple devices with different next hops,
+// so only the first device syncs it, otherwise the prefix would be patched by each of them.
+func (nbi *NetboxInventory) ClaimPrefixNextHop(vrf *objects.VRF, prefix string) bool {
+	nbi.PrefixesLock.Lock()
+	defer nbi.PrefixesLock.Unlock()
+	vrfID := 0
+	if vrf != nil {
+		vrfID = vrf.ID
+	}
+	key := fmt.Sprintf("%d/%s", vrfID, prefix)
+	if nbi.PrefixesWithNextHop == nil {
+		nbi.PrefixesWithNextHop = make(map[string]bool)
+	}
+	if nbi.PrefixesWithNextHop[key] {
+		return false
+	}
+	nbi.PrefixesWithNextHop[key] = true
+	return true
+}
+
+// isSsotVRF returns true, if vrf with vrfID is managed by netbox-ssot.
+func (nbi *NetboxInventory) isSsotVRF(vrfID int) bool {
+	nbi.VRFsLock.Lock()
+	defer nbi.VRFsLock.Unlock()
+	for _, vrf := range nbi.VRFsIndexByName {
+		if vrf.ID == vrfID {
+			return slices.IndexFunc(vrf.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0
+		}
+	}
+	return false
 }
 
 func (nbi *NetboxInventory) AddPrefix(ctx context.Context, newPrefix *objects.Prefix) (*objects.Prefix, error) {
@@ -846,11 +1351,12 @@ func (nbi *NetboxInventory) AddPrefix(ctx context.Context, newPrefix *objects.Pr
 	}
 	newPrefix.NetboxObject.CustomFields[constants.CustomFieldSourceName] = ctx.Value(constants.CtxSourceKey).(string) //nolint:forcetypeassert
 	defer nbi.PrefixesLock.Unlock()
-	if _, ok := nbi.PrefixesIndexByPrefix[newPrefix.Prefix]; ok {
+	prefixesIndex := nbi.prefixIndexOf(newPrefix.Vrf, newPrefix.Prefix)
+	if _, ok := prefixesIndex[newPrefix.Prefix]; ok {
 		// Delete id from orphan manager, because it still exists in the sources
-		delete(nbi.OrphanManager[constants.PrefixesAPIPath], nbi.PrefixesIndexByPrefix[newPrefix.Prefix].ID)
-		diffMap, err := utils.JSONDiffMapExceptID(newPrefix, nbi.PrefixesIndexByPrefix[newPrefix.Prefix], false, nbi.SourcePriority)
-		oldPrefix := nbi.PrefixesIndexByPrefix[newPrefix.Prefix]
+		delete(nbi.OrphanManager[constants.PrefixesAPIPath], prefixesIndex[newPrefix.Prefix].ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newPrefix, prefixesIndex[newPrefix.Prefix], false, nbi.SourcePriority)
+		oldPrefix := prefixesIndex[newPrefix.Prefix]
 		if err != nil {
 			return nil, err
 		}
@@ -860,7 +1366,7 @@ func (nbi *NetboxInventory) AddPrefix(ctx context.Context, newPrefix *objects.Pr
 			if err != nil {
 				return nil, err
 			}
-			nbi.PrefixesIndexByPrefix[newPrefix.Prefix] = patchedPrefix
+			prefixesIndex[newPrefix.Prefix] = patchedPrefix
 		} else {
 			nbi.Logger.Debug(ctx, "IP address ", newPrefix.Prefix, " already exists in Netbox and is up to date...")
 		}
@@ -870,16 +1376,864 @@ func (nbi *NetboxInventory) AddPrefix(ctx context.Context, newPrefix *objects.Pr
 		if err != nil {
 			return nil, err
 		}
-		nbi.PrefixesIndexByPrefix[newPrefix.Prefix] = newPrefix
+		prefixesIndex[newPrefix.Prefix] = newPrefix
 		return newPrefix, nil
 	}
-	return nbi.PrefixesIndexByPrefix[newPrefix.Prefix], nil
+	return prefixesIndex[newPrefix.Prefix], nil
 }
 
-// Helper function that adds source name to custom field of the netbox object.
-func addSourceNameCustomField(ctx context.Context, netboxObject *objects.NetboxObject) {
-	if netboxObject.CustomFields == nil {
-		netboxObject.CustomFields = make(map[string]interface{})
+// Helper function that returns index of prefixes within the vrf.
+// Prefixes without vrf are part of the global index.
+func (nbi *NetboxInventory) prefixesIndex(vrf *objects.VRF) map[string]*objects.Prefix {
+	if vrf == nil {
+		return nbi.PrefixesIndexByPrefix
 	}
-	netboxObject.CustomFields[constants.CustomFieldSourceName] = ctx.Value(constants.CtxSourceKey).(string) //nolint
+	if nbi.PrefixesIndexByVRFIDAndPrefix == nil {
+		nbi.PrefixesIndexByVRFIDAndPrefix = make(map[int]map[string]*objects.Prefix)
+	}
+	if nbi.PrefixesIndexByVRFIDAndPrefix[vrf.ID] == nil {
+		nbi.PrefixesIndexByVRFIDAndPrefix[vrf.ID] = make(map[string]*objects.Prefix)
+	}
+	return nbi.PrefixesIndexByVRFIDAndPrefix[vrf.ID]
+}
+
+// Helper function that returns index of prefixes, which contains the prefix within the vrf.
+// Like for ip addresses, prefixes without vrf are also looked up in vrfs assigned by users.
+func (nbi *NetboxInventory) prefixIndexOf(vrf *objects.VRF, prefix string) map[string]*objects.Prefix {
+	prefixesIndex := nbi.prefixesIndex(vrf)
+	if _, ok := prefixesIndex[prefix]; ok || vrf != nil {
+		return prefixesIndex
+	}
+	var userVRFIndex map[string]*objects.Prefix
+	for vrfID, vrfIndex := range nbi.PrefixesIndexByVRFIDAndPrefix {
+		if _, ok := vrfIndex[prefix]; !ok || nbi.isSsotVRF(vrfID) {
+			continue
+		}
+		if userVRFIndex != nil {
+			return prefixesIndex
+		}
+		userVRFIndex = vrfIndex
+	}
+	if userVRFIndex != nil {
+		return userVRFIndex
+	}
+	return prefixesIndex
+}
+
+// AddMACAddress adds newMACAddress to the local inventory. Mac addresses are
+// indexed by their mac, and assigned object, because the same mac can appear
+// on multiple interfaces (e.g. subinterfaces). Only supported on netbox >= 4.2.
+func (nbi *NetboxInventory) AddMACAddress(ctx context.Context, newMACAddress *objects.MACAddress) (*objects.MACAddress, error) {
+	newMACAddress.Tags = append(newMACAddress.Tags, nbi.SsotTag)
+	nbi.MACAddressesLock.Lock()
+	defer nbi.MACAddressesLock.Unlock()
+	addSourceNameCustomField(ctx, &newMACAddress.NetboxObject)
+	// Sources report mac addresses in different cases, while netbox returns them upper cased
+	newMACAddress.MAC = strings.ToUpper(newMACAddress.MAC)
+	if !nbi.SupportsMACAddressObjects() {
+		return nil, fmt.Errorf("mac address objects are not supported on netbox version %s", nbi.NetboxAPI.Version)
+	}
+	if oldMACAddress, ok := nbi.MACAddressesIndexByMACAndAssignedObject[newMACAddress.MAC][newMACAddress.AssignedObjectType][newMACAddress.AssignedObjectID]; ok {
+		// Delete id from orphan manager, because it still exists in the sources
+		delete(nbi.OrphanManager[constants.MACAddressesAPIPath], oldMACAddress.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newMACAddress, oldMACAddress, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "MAC address ", newMACAddress.MAC, " already exists in Netbox but is out of date. Patching it...")
+			patchedMACAddress, err := service.Patch[objects.MACAddress](ctx, nbi.NetboxAPI, oldMACAddress.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.indexMACAddress(patchedMACAddress)
+			return patchedMACAddress, nil
+		}
+		nbi.Logger.Debug(ctx, "MAC address ", newMACAddress.MAC, " already exists in Netbox and is up to date...")
+		return oldMACAddress, nil
+	}
+	nbi.Logger.Debug(ctx, "MAC address ", newMACAddress.MAC, " does not exist in Netbox. Creating it...")
+	newMACAddress, err := service.Create[objects.MACAddress](ctx, nbi.NetboxAPI, newMACAddress)
+	if err != nil {
+		return nil, err
+	}
+	nbi.indexMACAddress(newMACAddress)
+	return newMACAddress, nil
+}
+
+// Helper function that stores macAddress into MACAddressesIndexByMACAndAssignedObject.
+// Mac addresses are indexed upper cased, so lookups don't depend on the case reported by the source.
+func (nbi *NetboxInventory) indexMACAddress(macAddress *objects.MACAddress) {
+	mac := strings.ToUpper(macAddress.MAC)
+	if nbi.MACAddressesIndexByMACAndAssignedObject[mac] == nil {
+		nbi.MACAddressesIndexByMACAndAssignedObject[mac] = make(map[objects.AssignedObjectType]map[int]*objects.MACAddress)
+	}
+	if nbi.MACAddressesIndexByMACAndAssignedObject[mac][macAddress.AssignedObjectType] == nil {
+		nbi.MACAddressesIndexByMACAndAssignedObject[mac][macAddress.AssignedObjectType] = make(map[int]*objects.MACAddress)
+	}
+	nbi.MACAddressesIndexByMACAndAssignedObject[mac][macAddress.AssignedObjectType][macAddress.AssignedObjectID] = macAddress
+}
+
+// Helper function that creates mac address object for the interface with assignedObjectType
+// and assignedObjectID. It returns the mac address object that should be set as a primary
+// mac address of the interface.
+func (nbi *NetboxInventory) addInterfaceMACAddress(ctx context.Context, mac string, tags []*objects.Tag, assignedObjectType objects.AssignedObjectType, assignedObjectID int) (*objects.MACAddress, error) {
+	nbMACAddress, err := nbi.AddMACAddress(ctx, &objects.MACAddress{
+		NetboxObject: objects.NetboxObject{
+			Tags: tags,
+		},
+		MAC:                mac,
+		AssignedObjectType: assignedObjectType,
+		AssignedObjectID:   assignedObjectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add mac address %s: %s", mac, err)
+	}
+	return nbMACAddress, nil
+}
+
+// AddVRF adds newVRF to the local inventory.
+func (nbi *NetboxInventory) AddVRF(ctx context.Context, newVRF *objects.VRF) (*objects.VRF, error) {
+	nbi.VRFsLock.Lock()
+	defer nbi.VRFsLock.Unlock()
+	newVRF.Tags = append(newVRF.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newVRF.NetboxObject)
+	if _, ok := nbi.VRFsIndexByName[newVRF.Name]; ok {
+		oldVRF := nbi.VRFsIndexByName[newVRF.Name]
+		delete(nbi.OrphanManager[constants.VRFsAPIPath], oldVRF.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newVRF, oldVRF, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "VRF ", newVRF.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedVRF, err := service.Patch[objects.VRF](ctx, nbi.NetboxAPI, oldVRF.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.VRFsIndexByName[newVRF.Name] = patchedVRF
+		} else {
+			nbi.Logger.Debug(ctx, "VRF ", newVRF.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "VRF ", newVRF.Name, " does not exist in Netbox. Creating it...")
+		newVRF, err := service.Create[objects.VRF](ctx, nbi.NetboxAPI, newVRF)
+		if err != nil {
+			return nil, err
+		}
+		nbi.VRFsIndexByName[newVRF.Name] = newVRF
+	}
+	return nbi.VRFsIndexByName[newVRF.Name], nil
+}
+
+// AddFHRPGroup adds newFHRPGroup to the local inventory.
+func (nbi *NetboxInventory) AddFHRPGroup(ctx context.Context, newFHRPGroup *objects.FHRPGroup) (*objects.FHRPGroup, error) {
+	nbi.FHRPGroupsLock.Lock()
+	defer nbi.FHRPGroupsLock.Unlock()
+	newFHRPGroup.Tags = append(newFHRPGroup.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newFHRPGroup.NetboxObject)
+	if _, ok := nbi.FHRPGroupsIndexByName[newFHRPGroup.Name]; ok {
+		oldFHRPGroup := nbi.FHRPGroupsIndexByName[newFHRPGroup.Name]
+		delete(nbi.OrphanManager[constants.FHRPGroupsAPIPath], oldFHRPGroup.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newFHRPGroup, oldFHRPGroup, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "FHRP group ", newFHRPGroup.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedFHRPGroup, err := service.Patch[objects.FHRPGroup](ctx, nbi.NetboxAPI, oldFHRPGroup.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.FHRPGroupsIndexByName[newFHRPGroup.Name] = patchedFHRPGroup
+		} else {
+			nbi.Logger.Debug(ctx, "FHRP group ", newFHRPGroup.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "FHRP group ", newFHRPGroup.Name, " does not exist in Netbox. Creating it...")
+		newFHRPGroup, err := service.Create[objects.FHRPGroup](ctx, nbi.NetboxAPI, newFHRPGroup)
+		if err != nil {
+			return nil, err
+		}
+		nbi.FHRPGroupsIndexByName[newFHRPGroup.Name] = newFHRPGroup
+	}
+	return nbi.FHRPGroupsIndexByName[newFHRPGroup.Name], nil
+}
+
+// AddFHRPGroupAssignment adds newFHRPGroupAssignment to the local inventory.
+func (nbi *NetboxInventory) AddFHRPGroupAssignment(ctx context.Context, newFHRPGroupAssignment *objects.FHRPGroupAssignment) (*objects.FHRPGroupAssignment, error) {
+	nbi.FHRPGroupAssignmentsLock.Lock()
+	defer nbi.FHRPGroupAssignmentsLock.Unlock()
+	if newFHRPGroupAssignment.Group == nil {
+		return nil, fmt.Errorf("FHRPGroupAssignment %s is not assigned to a group, but it should be", newFHRPGroupAssignment)
+	}
+	groupID := newFHRPGroupAssignment.Group.ID
+	if oldFHRPGroupAssignment, ok := nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID][newFHRPGroupAssignment.InterfaceType][newFHRPGroupAssignment.InterfaceID]; ok {
+		// Delete id from orphan manager, because it still exists in the sources
+		delete(nbi.OrphanManager[constants.FHRPGroupAssignmentsAPIPath], oldFHRPGroupAssignment.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newFHRPGroupAssignment, oldFHRPGroupAssignment, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "FHRP group assignment ", newFHRPGroupAssignment, " already exists in Netbox but is out of date. Patching it...")
+			patchedFHRPGroupAssignment, err := service.Patch[objects.FHRPGroupAssignment](ctx, nbi.NetboxAPI, oldFHRPGroupAssignment.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.indexFHRPGroupAssignment(patchedFHRPGroupAssignment)
+			return patchedFHRPGroupAssignment, nil
+		}
+		nbi.Logger.Debug(ctx, "FHRP group assignment ", newFHRPGroupAssignment, " already exists in Netbox and is up to date...")
+		return oldFHRPGroupAssignment, nil
+	}
+	nbi.Logger.Debug(ctx, "FHRP group assignment ", newFHRPGroupAssignment, " does not exist in Netbox. Creating it...")
+	newFHRPGroupAssignment, err := service.Create[objects.FHRPGroupAssignment](ctx, nbi.NetboxAPI, newFHRPGroupAssignment)
+	if err != nil {
+		return nil, err
+	}
+	nbi.indexFHRPGroupAssignment(newFHRPGroupAssignment)
+	return newFHRPGroupAssignment, nil
+}
+
+// Helper function that stores fhrpGroupAssignment into FHRPGroupAssignmentsIndexByGroupIDAndInterface.
+func (nbi *NetboxInventory) indexFHRPGroupAssignment(fhrpGroupAssignment *objects.FHRPGroupAssignment) {
+	if fhrpGroupAssignment.Group == nil {
+		return
+	}
+	groupID := fhrpGroupAssignment.Group.ID
+	if nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID] == nil {
+		nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID] = make(map[objects.AssignedObjectType]map[int]*objects.FHRPGroupAssignment)
+	}
+	if nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID][fhrpGroupAssignment.InterfaceType] == nil {
+		nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID][fhrpGroupAssignment.InterfaceType] = make(map[int]*objects.FHRPGroupAssignment)
+	}
+	nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface[groupID][fhrpGroupAssignment.InterfaceType][fhrpGroupAssignment.InterfaceID] = fhrpGroupAssignment
+}
+
+// AddIKEProposal adds newIKEProposal to the local inventory.
+func (nbi *NetboxInventory) AddIKEProposal(ctx context.Context, newIKEProposal *objects.IKEProposal) (*objects.IKEProposal, error) {
+	nbi.IKEProposalsLock.Lock()
+	defer nbi.IKEProposalsLock.Unlock()
+	newIKEProposal.Tags = append(newIKEProposal.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIKEProposal.NetboxObject)
+	if _, ok := nbi.IKEProposalsIndexByName[newIKEProposal.Name]; ok {
+		oldIKEProposal := nbi.IKEProposalsIndexByName[newIKEProposal.Name]
+		delete(nbi.OrphanManager[constants.IKEProposalsAPIPath], oldIKEProposal.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIKEProposal, oldIKEProposal, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "IKE proposal ", newIKEProposal.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedIKEProposal, err := service.Patch[objects.IKEProposal](ctx, nbi.NetboxAPI, oldIKEProposal.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.IKEProposalsIndexByName[newIKEProposal.Name] = patchedIKEProposal
+		} else {
+			nbi.Logger.Debug(ctx, "IKE proposal ", newIKEProposal.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IKE proposal ", newIKEProposal.Name, " does not exist in Netbox. Creating it...")
+		newIKEProposal, err := service.Create[objects.IKEProposal](ctx, nbi.NetboxAPI, newIKEProposal)
+		if err != nil {
+			return nil, err
+		}
+		nbi.IKEProposalsIndexByName[newIKEProposal.Name] = newIKEProposal
+	}
+	return nbi.IKEProposalsIndexByName[newIKEProposal.Name], nil
+}
+
+// AddIKEPolicy adds newIKEPolicy to the local inventory.
+func (nbi *NetboxInventory) AddIKEPolicy(ctx context.Context, newIKEPolicy *objects.IKEPolicy) (*objects.IKEPolicy, error) {
+	nbi.IKEPoliciesLock.Lock()
+	defer nbi.IKEPoliciesLock.Unlock()
+	newIKEPolicy.Tags = append(newIKEPolicy.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIKEPolicy.NetboxObject)
+	if _, ok := nbi.IKEPoliciesIndexByName[newIKEPolicy.Name]; ok {
+		oldIKEPolicy := nbi.IKEPoliciesIndexByName[newIKEPolicy.Name]
+		delete(nbi.OrphanManager[constants.IKEPoliciesAPIPath], oldIKEPolicy.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIKEPolicy, oldIKEPolicy, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "IKE policy ", newIKEPolicy.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedIKEPolicy, err := service.Patch[objects.IKEPolicy](ctx, nbi.NetboxAPI, oldIKEPolicy.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.IKEPoliciesIndexByName[newIKEPolicy.Name] = patchedIKEPolicy
+		} else {
+			nbi.Logger.Debug(ctx, "IKE policy ", newIKEPolicy.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IKE policy ", newIKEPolicy.Name, " does not exist in Netbox. Creating it...")
+		newIKEPolicy, err := service.Create[objects.IKEPolicy](ctx, nbi.NetboxAPI, newIKEPolicy)
+		if err != nil {
+			return nil, err
+		}
+		nbi.IKEPoliciesIndexByName[newIKEPolicy.Name] = newIKEPolicy
+	}
+	return nbi.IKEPoliciesIndexByName[newIKEPolicy.Name], nil
+}
+
+// AddIPSecProposal adds newIPSecProposal to the local inventory.
+func (nbi *NetboxInventory) AddIPSecProposal(ctx context.Context, newIPSecProposal *objects.IPSecProposal) (*objects.IPSecProposal, error) {
+	nbi.IPSecProposalsLock.Lock()
+	defer nbi.IPSecProposalsLock.Unlock()
+	newIPSecProposal.Tags = append(newIPSecProposal.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIPSecProposal.NetboxObject)
+	if _, ok := nbi.IPSecProposalsIndexByName[newIPSecProposal.Name]; ok {
+		oldIPSecProposal := nbi.IPSecProposalsIndexByName[newIPSecProposal.Name]
+		delete(nbi.OrphanManager[constants.IPSecProposalsAPIPath], oldIPSecProposal.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIPSecProposal, oldIPSecProposal, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "IPSec proposal ", newIPSecProposal.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedIPSecProposal, err := service.Patch[objects.IPSecProposal](ctx, nbi.NetboxAPI, oldIPSecProposal.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.IPSecProposalsIndexByName[newIPSecProposal.Name] = patchedIPSecProposal
+		} else {
+			nbi.Logger.Debug(ctx, "IPSec proposal ", newIPSecProposal.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IPSec proposal ", newIPSecProposal.Name, " does not exist in Netbox. Creating it...")
+		newIPSecProposal, err := service.Create[objects.IPSecProposal](ctx, nbi.NetboxAPI, newIPSecProposal)
+		if err != nil {
+			return nil, err
+		}
+		nbi.IPSecProposalsIndexByName[newIPSecProposal.Name] = newIPSecProposal
+	}
+	return nbi.IPSecProposalsIndexByName[newIPSecProposal.Name], nil
+}
+
+// AddIPSecPolicy adds newIPSecPolicy to the local inventory.
+func (nbi *NetboxInventory) AddIPSecPolicy(ctx context.Context, newIPSecPolicy *objects.IPSecPolicy) (*objects.IPSecPolicy, error) {
+	nbi.IPSecPoliciesLock.Lock()
+	defer nbi.IPSecPoliciesLock.Unlock()
+	newIPSecPolicy.Tags = append(newIPSecPolicy.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIPSecPolicy.NetboxObject)
+	if _, ok := nbi.IPSecPoliciesIndexByName[newIPSecPolicy.Name]; ok {
+		oldIPSecPolicy := nbi.IPSecPoliciesIndexByName[newIPSecPolicy.Name]
+		delete(nbi.OrphanManager[constants.IPSecPoliciesAPIPath], oldIPSecPolicy.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIPSecPolicy, oldIPSecPolicy, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "IPSec policy ", newIPSecPolicy.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedIPSecPolicy, err := service.Patch[objects.IPSecPolicy](ctx, nbi.NetboxAPI, oldIPSecPolicy.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.IPSecPoliciesIndexByName[newIPSecPolicy.Name] = patchedIPSecPolicy
+		} else {
+			nbi.Logger.Debug(ctx, "IPSec policy ", newIPSecPolicy.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IPSec policy ", newIPSecPolicy.Name, " does not exist in Netbox. Creating it...")
+		newIPSecPolicy, err := service.Create[objects.IPSecPolicy](ctx, nbi.NetboxAPI, newIPSecPolicy)
+		if err != nil {
+			return nil, err
+		}
+		nbi.IPSecPoliciesIndexByName[newIPSecPolicy.Name] = newIPSecPolicy
+	}
+	return nbi.IPSecPoliciesIndexByName[newIPSecPolicy.Name], nil
+}
+
+// AddIPSecProfile adds newIPSecProfile to the local inventory.
+func (nbi *NetboxInventory) AddIPSecProfile(ctx context.Context, newIPSecProfile *objects.IPSecProfile) (*objects.IPSecProfile, error) {
+	nbi.IPSecProfilesLock.Lock()
+	defer nbi.IPSecProfilesLock.Unlock()
+	newIPSecProfile.Tags = append(newIPSecProfile.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIPSecProfile.NetboxObject)
+	if _, ok := nbi.IPSecProfilesIndexByName[newIPSecProfile.Name]; ok {
+		oldIPSecProfile := nbi.IPSecProfilesIndexByName[newIPSecProfile.Name]
+		delete(nbi.OrphanManager[constants.IPSecProfilesAPIPath], oldIPSecProfile.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newIPSecProfile, oldIPSecProfile, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "IPSec profile ", newIPSecProfile.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedIPSecProfile, err := service.Patch[objects.IPSecProfile](ctx, nbi.NetboxAPI, oldIPSecProfile.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.IPSecProfilesIndexByName[newIPSecProfile.Name] = patchedIPSecProfile
+		} else {
+			nbi.Logger.Debug(ctx, "IPSec profile ", newIPSecProfile.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IPSec profile ", newIPSecProfile.Name, " does not exist in Netbox. Creating it...")
+		newIPSecProfile, err := service.Create[objects.IPSecProfile](ctx, nbi.NetboxAPI, newIPSecProfile)
+		if err != nil {
+			return nil, err
+		}
+		nbi.IPSecProfilesIndexByName[newIPSecProfile.Name] = newIPSecProfile
+	}
+	return nbi.IPSecProfilesIndexByName[newIPSecProfile.Name], nil
+}
+
+// AddTunnel adds newTunnel to the local inventory.
+func (nbi *NetboxInventory) AddTunnel(ctx context.Context, newTunnel *objects.Tunnel) (*objects.Tunnel, error) {
+	nbi.TunnelsLock.Lock()
+	defer nbi.TunnelsLock.Unlock()
+	newTunnel.Tags = append(newTunnel.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTunnel.NetboxObject)
+	if _, ok := nbi.TunnelsIndexByName[newTunnel.Name]; ok {
+		oldTunnel := nbi.TunnelsIndexByName[newTunnel.Name]
+		delete(nbi.OrphanManager[constants.TunnelsAPIPath], oldTunnel.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newTunnel, oldTunnel, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Tunnel ", newTunnel.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedTunnel, err := service.Patch[objects.Tunnel](ctx, nbi.NetboxAPI, oldTunnel.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.TunnelsIndexByName[newTunnel.Name] = patchedTunnel
+		} else {
+			nbi.Logger.Debug(ctx, "Tunnel ", newTunnel.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Tunnel ", newTunnel.Name, " does not exist in Netbox. Creating it...")
+		newTunnel, err := service.Create[objects.Tunnel](ctx, nbi.NetboxAPI, newTunnel)
+		if err != nil {
+			return nil, err
+		}
+		nbi.TunnelsIndexByName[newTunnel.Name] = newTunnel
+	}
+	return nbi.TunnelsIndexByName[newTunnel.Name], nil
+}
+
+// AddTunnelTermination adds newTunnelTermination to the local inventory.
+// Tunnel terminations are indexed by their termination, because each
+// interface can terminate only one tunnel.
+func (nbi *NetboxInventory) AddTunnelTermination(ctx context.Context, newTunnelTermination *objects.TunnelTermination) (*objects.TunnelTermination, error) {
+	nbi.TunnelTerminationsLock.Lock()
+	defer nbi.TunnelTerminationsLock.Unlock()
+	if newTunnelTermination.Tunnel == nil {
+		return nil, fmt.Errorf("tunnel termination %s is not assigned to a tunnel, but it should be", newTunnelTermination)
+	}
+	newTunnelTermination.Tags = append(newTunnelTermination.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTunnelTermination.NetboxObject)
+	terminationType := newTunnelTermination.TerminationType
+	terminationID := newTunnelTermination.TerminationID
+	if _, ok := nbi.TunnelTerminationsIndexByTermination[terminationType][terminationID]; ok {
+		oldTunnelTermination := nbi.TunnelTerminationsIndexByTermination[terminationType][terminationID]
+		delete(nbi.OrphanManager[constants.TunnelTerminationsAPIPath], oldTunnelTermination.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newTunnelTermination, oldTunnelTermination, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Tunnel termination ", newTunnelTermination, " already exists in Netbox but is out of date. Patching it...")
+			patchedTunnelTermination, err := service.Patch[objects.TunnelTermination](ctx, nbi.NetboxAPI, oldTunnelTermination.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.TunnelTerminationsIndexByTermination[terminationType][terminationID] = patchedTunnelTermination
+		} else {
+			nbi.Logger.Debug(ctx, "Tunnel termination ", newTunnelTermination, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Tunnel termination ", newTunnelTermination, " does not exist in Netbox. Creating it...")
+		newTunnelTermination, err := service.Create[objects.TunnelTermination](ctx, nbi.NetboxAPI, newTunnelTermination)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.TunnelTerminationsIndexByTermination[terminationType] == nil {
+			nbi.TunnelTerminationsIndexByTermination[terminationType] = make(map[int]*objects.TunnelTermination)
+		}
+		nbi.TunnelTerminationsIndexByTermination[terminationType][terminationID] = newTunnelTermination
+	}
+	return nbi.TunnelTerminationsIndexByTermination[terminationType][terminationID], nil
+}
+
+// Helper function that adds source name to custom field of the netbox object.
+func addSourceNameCustomField(ctx context.Context, netboxObject *objects.NetboxObject) {
+	if netboxObject.CustomFields == nil {
+		netboxObject.CustomFields = make(map[string]interface{})
+	}
+	netboxObject.CustomFields[constants.CustomFieldSourceName] = ctx.Value(constants.CtxSourceKey).(string) //nolint
+}
+
+// AddIPRange adds newIPRange to the local inventory. Ip ranges are indexed by their
+// vrf and start and end address without mask, so masks of the addresses don't matter.
+// Netbox doesn't allow overlapping ip ranges within a vrf, so when newIPRange overlaps
+// with an ip range managed by netbox-ssot, that wasn't synced yet in this run (e.g. dhcp
+// pool was resized), the existing ip range is patched. Other overlapping ip ranges are
+// left as they are and returned instead.
+func (nbi *NetboxInventory) AddIPRange(ctx context.Context, newIPRange *objects.IPRange) (*objects.IPRange, error) {
+	nbi.IPRangesLock.Lock()
+	defer nbi.IPRangesLock.Unlock()
+	newIPRange.Tags = append(newIPRange.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIPRange.NetboxObject)
+	ipRangesIndex := nbi.ipRangesIndex(newIPRange.Vrf)
+	newIPRangeKey := ipRangeKey(newIPRange)
+	oldIPRange, ok := ipRangesIndex[newIPRangeKey]
+	if !ok {
+		oldIPRange = overlappingIPRange(ipRangesIndex, newIPRange)
+		if oldIPRange != nil && !nbi.OrphanManager[constants.IPRangesAPIPath][oldIPRange.ID] {
+			nbi.Logger.Warningf(ctx, "IP range %s overlaps with ip range %s, that is not managed by netbox-ssot or was already synced. Skipping it...", newIPRangeKey, ipRangeKey(oldIPRange))
+			return oldIPRange, nil
+		}
+	}
+	if oldIPRange == nil {
+		nbi.Logger.Debug(ctx, "IP range ", newIPRangeKey, " does not exist in Netbox. Creating it...")
+		newIPRange, err := service.Create[objects.IPRange](ctx, nbi.NetboxAPI, newIPRange)
+		if err != nil {
+			return nil, err
+		}
+		ipRangesIndex[newIPRangeKey] = newIPRange
+		return newIPRange, nil
+	}
+	delete(nbi.OrphanManager[constants.IPRangesAPIPath], oldIPRange.ID)
+	diffMap, err := utils.JSONDiffMapExceptID(newIPRange, oldIPRange, false, nbi.SourcePriority)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffMap) == 0 {
+		nbi.Logger.Debug(ctx, "IP range ", newIPRangeKey, " already exists in Netbox and is up to date...")
+		return oldIPRange, nil
+	}
+	nbi.Logger.Debug(ctx, "IP range ", ipRangeKey(oldIPRange), " already exists in Netbox but is out of date. Patching it...")
+	patchedIPRange, err := service.Patch[objects.IPRange](ctx, nbi.NetboxAPI, oldIPRange.ID, diffMap)
+	if err != nil {
+		return nil, err
+	}
+	delete(ipRangesIndex, ipRangeKey(oldIPRange))
+	ipRangesIndex[ipRangeKey(patchedIPRange)] = patchedIPRange
+	return patchedIPRange, nil
+}
+
+// IsIPRangeSynced returns true if an ip range within the vrf, that overlaps with
+// addresses from startAddress to endAddress, was already synced in this run,
+// or is not managed by netbox-ssot.
+func (nbi *NetboxInventory) IsIPRangeSynced(vrf *objects.VRF, startAddress string, endAddress string) bool {
+	nbi.IPRangesLock.Lock()
+	defer nbi.IPRangesLock.Unlock()
+	ipRange := overlappingIPRange(nbi.ipRangesIndex(vrf), &objects.IPRange{StartAddress: startAddress, EndAddress: endAddress})
+	return ipRange != nil && !nbi.OrphanManager[constants.IPRangesAPIPath][ipRange.ID]
+}
+
+// Helper function that returns index of ip ranges within the vrf.
+// Ip ranges without vrf are part of the global index.
+func (nbi *NetboxInventory) ipRangesIndex(vrf *objects.VRF) map[string]*objects.IPRange {
+	if vrf == nil {
+		if nbi.IPRangesIndexByAddresses == nil {
+			nbi.IPRangesIndexByAddresses = make(map[string]*objects.IPRange)
+		}
+		return nbi.IPRangesIndexByAddresses
+	}
+	if nbi.IPRangesIndexByVRFIDAndAddresses == nil {
+		nbi.IPRangesIndexByVRFIDAndAddresses = make(map[int]map[string]*objects.IPRange)
+	}
+	if nbi.IPRangesIndexByVRFIDAndAddresses[vrf.ID] == nil {
+		nbi.IPRangesIndexByVRFIDAndAddresses[vrf.ID] = make(map[string]*objects.IPRange)
+	}
+	return nbi.IPRangesIndexByVRFIDAndAddresses[vrf.ID]
+}
+
+// ipRangeKey returns key of the ip range in ip ranges index, which consists
+// of start and end address without mask (e.g. "10.0.0.10-10.0.0.20").
+func ipRangeKey(ipRange *objects.IPRange) string {
+	return fmt.Sprintf("%s-%s", strings.Split(ipRange.StartAddress, "/")[0], strings.Split(ipRange.EndAddress, "/")[0])
+}
+
+// ipRangeBounds returns start and end address of the ip range without mask.
+func ipRangeBounds(ipRange *objects.IPRange) (netip.Addr, netip.Addr, bool) {
+	startAddr, err := netip.ParseAddr(strings.Split(ipRange.StartAddress, "/")[0])
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	endAddr, err := netip.ParseAddr(strings.Split(ipRange.EndAddress, "/")[0])
+	if err != nil || startAddr.BitLen() != endAddr.BitLen() {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	return startAddr, endAddr, true
+}
+
+// overlappingIPRange returns ip range from ipRangesIndex, that overlaps with ipRange, or nil.
+func overlappingIPRange(ipRangesIndex map[string]*objects.IPRange, ipRange *objects.IPRange) *objects.IPRange {
+	startAddr, endAddr, ok := ipRangeBounds(ipRange)
+	if !ok {
+		return nil
+	}
+	for _, indexedIPRange := range ipRangesIndex {
+		indexedStartAddr, indexedEndAddr, ok := ipRangeBounds(indexedIPRange)
+		if !ok || indexedStartAddr.BitLen() != startAddr.BitLen() {
+			continue
+		}
+		if !endAddr.Less(indexedStartAddr) && !indexedEndAddr.Less(startAddr) {
+			return indexedIPRange
+		}
+	}
+	return nil
+}
+
+// AddRIR adds newRIR to the local netbox inventory.
+func (nbi *NetboxInventory) AddRIR(ctx context.Context, newRIR *objects.RIR) (*objects.RIR, error) {
+	nbi.RIRsLock.Lock()
+	defer nbi.RIRsLock.Unlock()
+	newRIR.Tags = append(newRIR.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newRIR.NetboxObject)
+	if oldRIR, ok := nbi.RIRsIndexByName[newRIR.Name]; ok {
+		delete(nbi.OrphanManager[constants.RIRsAPIPath], oldRIR.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newRIR, oldRIR, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "RIR ", newRIR.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedRIR, err := service.Patch[objects.RIR](ctx, nbi.NetboxAPI, oldRIR.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.RIRsIndexByName[newRIR.Name] = patchedRIR
+		} else {
+			nbi.Logger.Debug(ctx, "RIR ", newRIR.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "RIR ", newRIR.Name, " does not exist in Netbox. Creating it...")
+		newRIR, err := service.Create[objects.RIR](ctx, nbi.NetboxAPI, newRIR)
+		if err != nil {
+			return nil, err
+		}
+		nbi.RIRsIndexByName[newRIR.Name] = newRIR
+		return newRIR, nil
+	}
+	return nbi.RIRsIndexByName[newRIR.Name], nil
+}
+
+// AddASN adds newASN to the local netbox inventory.
+func (nbi *NetboxInventory) AddASN(ctx context.Context, newASN *objects.ASN) (*objects.ASN, error) {
+	nbi.ASNsLock.Lock()
+	defer nbi.ASNsLock.Unlock()
+	newASN.Tags = append(newASN.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newASN.NetboxObject)
+	if newASN.RIR == nil {
+		return nil, fmt.Errorf("asn %s is not assigned to a rir, but it should be", newASN)
+	}
+	if oldASN, ok := nbi.ASNsIndexByASN[newASN.ASN]; ok {
+		delete(nbi.OrphanManager[constants.ASNsAPIPath], oldASN.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newASN, oldASN, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "ASN ", newASN.ASN, " already exists in Netbox but is out of date. Patching it...")
+			patchedASN, err := service.Patch[objects.ASN](ctx, nbi.NetboxAPI, oldASN.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.ASNsIndexByASN[newASN.ASN] = patchedASN
+		} else {
+			nbi.Logger.Debug(ctx, "ASN ", newASN.ASN, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "ASN ", newASN.ASN, " does not exist in Netbox. Creating it...")
+		newASN, err := service.Create[objects.ASN](ctx, nbi.NetboxAPI, newASN)
+		if err != nil {
+			return nil, err
+		}
+		nbi.ASNsIndexByASN[newASN.ASN] = newASN
+		return newASN, nil
+	}
+	return nbi.ASNsIndexByASN[newASN.ASN], nil
+}
+
+// AddWirelessLAN adds newWirelessLAN to the local netbox inventory.
+func (nbi *NetboxInventory) AddWirelessLAN(ctx context.Context, newWirelessLAN *objects.WirelessLAN) (*objects.WirelessLAN, error) {
+	nbi.WirelessLANsLock.Lock()
+	defer nbi.WirelessLANsLock.Unlock()
+	newWirelessLAN.Tags = append(newWirelessLAN.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newWirelessLAN.NetboxObject)
+	if oldWirelessLAN, ok := nbi.WirelessLANsIndexBySSID[newWirelessLAN.SSID]; ok {
+		delete(nbi.OrphanManager[constants.WirelessLANsAPIPath], oldWirelessLAN.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newWirelessLAN, oldWirelessLAN, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "Wireless lan ", newWirelessLAN.SSID, " already exists in Netbox but is out of date. Patching it...")
+			patchedWirelessLAN, err := service.Patch[objects.WirelessLAN](ctx, nbi.NetboxAPI, oldWirelessLAN.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.WirelessLANsIndexBySSID[newWirelessLAN.SSID] = patchedWirelessLAN
+		} else {
+			nbi.Logger.Debug(ctx, "Wireless lan ", newWirelessLAN.SSID, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Wireless lan ", newWirelessLAN.SSID, " does not exist in Netbox. Creating it...")
+		newWirelessLAN, err := service.Create[objects.WirelessLAN](ctx, nbi.NetboxAPI, newWirelessLAN)
+		if err != nil {
+			return nil, err
+		}
+		nbi.WirelessLANsIndexBySSID[newWirelessLAN.SSID] = newWirelessLAN
+		return newWirelessLAN, nil
+	}
+	return nbi.WirelessLANsIndexBySSID[newWirelessLAN.SSID], nil
+}
+
+// AddSiteASN assigns asn to the site, keeping asns that are already assigned to it.
+func (nbi *NetboxInventory) AddSiteASN(ctx context.Context, site *objects.Site, asn *objects.ASN) (*objects.Site, error) {
+	nbi.SitesLock.Lock()
+	defer nbi.SitesLock.Unlock()
+	oldSite, ok := nbi.SitesIndexByName[site.Name]
+	if !ok {
+		return nil, fmt.Errorf("site %s does not exist in the inventory", site.Name)
+	}
+	if nbi.SiteASNsSynced == nil {
+		nbi.SiteASNsSynced = make(map[int]map[int]bool)
+	}
+	if nbi.SiteASNsSynced[oldSite.ID] == nil {
+		nbi.SiteASNsSynced[oldSite.ID] = make(map[int]bool)
+	}
+	nbi.SiteASNsSynced[oldSite.ID][asn.ID] = true
+	asnIDs := make([]int, 0, len(oldSite.ASNs)+1)
+	for _, siteASN := range oldSite.ASNs {
+		if siteASN.ID == asn.ID {
+			nbi.Logger.Debug(ctx, "ASN ", asn.ASN, " is already assigned to site ", site.Name)
+			return oldSite, nil
+		}
+		asnIDs = append(asnIDs, siteASN.ID)
+	}
+	asnIDs = append(asnIDs, asn.ID)
+	nbi.Logger.Debug(ctx, "Assigning ASN ", asn.ASN, " to site ", site.Name)
+	patchedSite, err := service.Patch[objects.Site](ctx, nbi.NetboxAPI, oldSite.ID, map[string]interface{}{"asns": asnIDs})
+	if err != nil {
+		return nil, err
+	}
+	nbi.SitesIndexByName[site.Name] = patchedSite
+	return patchedSite, nil
+}
+
+// AddDeviceCustomFields sets customFields of the already synced device.
+// Only custom fields that differ from the existing values are patched.
+func (nbi *NetboxInventory) AddDeviceCustomFields(ctx context.Context, device *objects.Device, customFields map[string]interface{}) (*objects.Device, error) {
+	nbi.DevicesLock.Lock()
+	defer nbi.DevicesLock.Unlock()
+	if device.Site == nil {
+		return nil, fmt.Errorf("device %s is not assigned to a site, but it should be", device)
+	}
+	oldDevice, ok := nbi.DevicesIndexByNameAndSiteID[device.Name][device.Site.ID]
+	if !ok {
+		return nil, fmt.Errorf("device %s does not exist in the inventory", device)
+	}
+	diffCustomFields := make(map[string]interface{})
+	for name, value := range customFields {
+		if oldDevice.CustomFields[name] != value {
+			diffCustomFields[name] = value
+		}
+	}
+	if len(diffCustomFields) == 0 {
+		nbi.Logger.Debug(ctx, "Custom fields of device ", device.Name, " are up to date...")
+		return oldDevice, nil
+	}
+	nbi.Logger.Debug(ctx, "Custom fields of device ", device.Name, " are out of date. Patching them...")
+	patchedDevice, err := service.Patch[objects.Device](ctx, nbi.NetboxAPI, oldDevice.ID, map[string]interface{}{"custom_fields": diffCustomFields})
+	if err != nil {
+		return nil, err
+	}
+	nbi.DevicesIndexByNameAndSiteID[device.Name][device.Site.ID] = patchedDevice
+	return patchedDevice, nil
+}
+
+// AddBGPSession adds newBGPSession to the local netbox inventory.
+// Bgp sessions are objects of the netbox-bgp plugin, so an error is
+// returned if the plugin is not installed.
+func (nbi *NetboxInventory) AddBGPSession(ctx context.Context, newBGPSession *objects.BGPSession) (*objects.BGPSession, error) {
+	if !nbi.BGPPluginInstalled {
+		return nil, fmt.Errorf("bgp session %s can't be added, because netbox-bgp plugin is not installed", newBGPSession.Name)
+	}
+	nbi.BGPSessionsLock.Lock()
+	defer nbi.BGPSessionsLock.Unlock()
+	newBGPSession.Tags = append(newBGPSession.Tags, nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newBGPSession.NetboxObject)
+	if nbi.BGPSessionsIndexByName == nil {
+		nbi.BGPSessionsIndexByName = make(map[string]*objects.BGPSession)
+	}
+	if oldBGPSession, ok := nbi.BGPSessionsIndexByName[newBGPSession.Name]; ok {
+		delete(nbi.OrphanManager[constants.BGPSessionsAPIPath], oldBGPSession.ID)
+		diffMap, err := utils.JSONDiffMapExceptID(newBGPSession, oldBGPSession, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(ctx, "BGP session ", newBGPSession.Name, " already exists in Netbox but is out of date. Patching it...")
+			patchedBGPSession, err := service.Patch[objects.BGPSession](ctx, nbi.NetboxAPI, oldBGPSession.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.BGPSessionsIndexByName[newBGPSession.Name] = patchedBGPSession
+		} else {
+			nbi.Logger.Debug(ctx, "BGP session ", newBGPSession.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "BGP session ", newBGPSession.Name, " does not exist in Netbox. Creating it...")
+		newBGPSession, err := service.Create[objects.BGPSession](ctx, nbi.NetboxAPI, newBGPSession)
+		if err != nil {
+			return nil, err
+		}
+		nbi.BGPSessionsIndexByName[newBGPSession.Name] = newBGPSession
+		return newBGPSession, nil
+	}
+	return nbi.BGPSessionsIndexByName[newBGPSession.Name], nil
+}
+
+// GetSyncedIPAddressHosts returns hosts, for which ip address within the vrf was
+// already synced in this run, or is not managed by netbox-ssot. Such ip addresses
+// won't be removed as orphans, so they don't have to be synced again.
+func (nbi *NetboxInventory) GetSyncedIPAddressHosts(vrf *objects.VRF, hosts []string) map[string]bool {
+	host2IPAddress := nbi.GetIPAddressesByHosts(vrf, hosts)
+	nbi.IPAddressesLock.Lock()
+	defer nbi.IPAddressesLock.Unlock()
+	syncedHosts := make(map[string]bool, len(host2IPAddress))
+	for host, ipAddress := range host2IPAddress {
+		if !nbi.OrphanManager[constants.IPAddressesAPIPath][ipAddress.ID] {
+			syncedHosts[host] = true
+		}
+	}
+	return syncedHosts
+}
+
+// GetIPAddressByHost returns ip address within the vrf, whose host part
+// matches the host regardless of the mask (e.g. 10.0.0.1 matches 10.0.0.1/24).
+func (nbi *NetboxInventory) GetIPAddressByHost(vrf *objects.VRF, host string) (*objects.IPAddress, bool) {
+	ipAddress, ok := nbi.GetIPAddressesByHosts(vrf, []string{host})[host]
+	return ipAddress, ok
+}
+
+// GetIPAddressesByHosts returns ip addresses within the vrf, whose host part matches
+// one of the hosts, indexed by their host. Ip addresses index is scanned only once,
+// so it should be preferred over GetIPAddressByHost when looking up multiple hosts.
+func (nbi *NetboxInventory) GetIPAddressesByHosts(vrf *objects.VRF, hosts []string) map[string]*objects.IPAddress {
+	nbi.IPAddressesLock.Lock()
+	defer nbi.IPAddressesLock.Unlock()
+	wantedHosts := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		wantedHosts[host] = true
+	}
+	host2IPAddress := make(map[string]*objects.IPAddress, len(wantedHosts))
+	for address, ipAddress := range nbi.ipAddressesIndex(vrf) {
+		host := strings.Split(address, "/")[0]
+		if _, ok := host2IPAddress[host]; !ok && wantedHosts[host] {
+			host2IPAddress[host] = ipAddress
+			if len(host2IPAddress) == len(wantedHosts) {
+				break
+			}
+		}
+	}
+	return host2IPAddress
 }
@@ -720,3 +720,372 @@ func TestNetboxInventory_AddPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestNetboxInventory_AddMACAddress(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		newMACAddress *objects.MACAddress
+	}
+	tests := []struct {
+		name    string
+		nbi     *NetboxInventory
+		args    args
+		want    *objects.MACAddress
+		wantErr bool
+	}{
+		{
+			name:    "Test add mac address on netbox < 4.2",
+			nbi:     MockInventory,
+			args:    args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newMACAddress: &objects.MACAddress{MAC: "00:11:22:33:44:55"}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.nbi.AddMACAddress(tt.args.ctx, tt.args.newMACAddress)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxInventory.AddMACAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.AddMACAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_indexMACAddress(t *testing.T) {
+	nbi := &NetboxInventory{MACAddressesIndexByMACAndAssignedObject: make(map[string]map[objects.AssignedObjectType]map[int]*objects.MACAddress)}
+	macAddress := &objects.MACAddress{MAC: "aa:bb:cc:dd:ee:ff", AssignedObjectType: objects.AssignedObjectTypeDeviceInterface, AssignedObjectID: 1}
+	nbi.indexMACAddress(macAddress)
+	if got := nbi.MACAddressesIndexByMACAndAssignedObject["AA:BB:CC:DD:EE:FF"][objects.AssignedObjectTypeDeviceInterface][1]; got != macAddress {
+		t.Errorf("indexMACAddress() didn't index mac address upper cased, got %v", got)
+	}
+}
+
+func TestNetboxInventory_GetIPAddress(t *testing.T) {
+	ssotTag := &objects.Tag{Name: "netbox-ssot", Slug: "netbox-ssot"}
+	userVRF := &objects.VRF{NetboxObject: objects.NetboxObject{ID: 1}, Name: "user"}
+	ssotVRF := &objects.VRF{NetboxObject: objects.NetboxObject{ID: 2, Tags: []*objects.Tag{ssotTag}}, Name: "ssot"}
+	globalIP := &objects.IPAddress{Address: "10.0.0.1/24"}
+	userVRFIP := &objects.IPAddress{Address: "10.0.0.2/24", Vrf: userVRF}
+	ssotVRFIP := &objects.IPAddress{Address: "10.0.0.3/24", Vrf: ssotVRF}
+	nbi := &NetboxInventory{
+		SsotTag:                  ssotTag,
+		VRFsIndexByName:          map[string]*objects.VRF{userVRF.Name: userVRF, ssotVRF.Name: ssotVRF},
+		IPAdressesIndexByAddress: map[string]*objects.IPAddress{globalIP.Address: globalIP},
+		IPAddressesIndexByVRFIDAndAddress: map[int]map[string]*objects.IPAddress{
+			userVRF.ID: {userVRFIP.Address: userVRFIP},
+			ssotVRF.ID: {ssotVRFIP.Address: ssotVRFIP},
+		},
+	}
+	tests := []struct {
+		name    string
+		vrf     *objects.VRF
+		address string
+		want    *objects.IPAddress
+	}{
+		{name: "Global ip address", address: globalIP.Address, want: globalIP},
+		{name: "Global ip address assigned to user vrf", address: userVRFIP.Address, want: userVRFIP},
+		{name: "Ip address in vrf managed by netbox-ssot", address: ssotVRFIP.Address, want: nil},
+		{name: "Ip address within vrf", vrf: ssotVRF, address: ssotVRFIP.Address, want: ssotVRFIP},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := nbi.GetIPAddress(tt.vrf, tt.address)
+			if got != tt.want {
+				t.Errorf("NetboxInventory.GetIPAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_GetSyncedIPAddressHosts(t *testing.T) {
+	syncedIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 1}, Address: "10.0.0.1/24"}
+	orphanIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 2}, Address: "10.0.0.2/32"}
+	nbi := &NetboxInventory{
+		IPAdressesIndexByAddress: map[string]*objects.IPAddress{
+			syncedIP.Address: syncedIP,
+			orphanIP.Address: orphanIP,
+		},
+		OrphanManager: map[string]map[int]bool{
+			constants.IPAddressesAPIPath: {orphanIP.ID: true},
+		},
+	}
+	// 10.0.0.2 is not synced yet and 10.0.0.3 doesn't exist
+	want := map[string]bool{"10.0.0.1": true}
+	if got := nbi.GetSyncedIPAddressHosts(nil, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NetboxInventory.GetSyncedIPAddressHosts() = %v, want %v", got, want)
+	}
+}
+
+func TestNetboxInventory_IsIPRangeSynced(t *testing.T) {
+	syncedRange := &objects.IPRange{NetboxObject: objects.NetboxObject{ID: 1}, StartAddress: "10.0.0.10/24", EndAddress: "10.0.0.20/24"}
+	orphanRange := &objects.IPRange{NetboxObject: objects.NetboxObject{ID: 2}, StartAddress: "10.0.1.10/32", EndAddress: "10.0.1.20/32"}
+	nbi := &NetboxInventory{
+		OrphanManager: map[string]map[int]bool{
+			constants.IPRangesAPIPath: {orphanRange.ID: true},
+		},
+	}
+	nbi.ipRangesIndex(nil)[ipRangeKey(syncedRange)] = syncedRange
+	nbi.ipRangesIndex(nil)[ipRangeKey(orphanRange)] = orphanRange
+	tests := []struct {
+		name         string
+		startAddress string
+		endAddress   string
+		want         bool
+	}{
+		{name: "Same range with different mask", startAddress: "10.0.0.10", endAddress: "10.0.0.20", want: true},
+		{name: "Overlapping range", startAddress: "10.0.0.15", endAddress: "10.0.0.30", want: true},
+		{name: "Range not synced yet", startAddress: "10.0.1.10", endAddress: "10.0.1.20", want: false},
+		{name: "Range without overlap", startAddress: "10.0.0.21", endAddress: "10.0.0.30", want: false},
+		{name: "Ipv6 range", startAddress: "2001:db8::10", endAddress: "2001:db8::20", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nbi.IsIPRangeSynced(nil, tt.startAddress, tt.endAddress); got != tt.want {
+				t.Errorf("NetboxInventory.IsIPRangeSynced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_GetIPAddressesByHosts(t *testing.T) {
+	vrf := &objects.VRF{NetboxObject: objects.NetboxObject{ID: 1}, Name: "vrf"}
+	globalIP := &objects.IPAddress{Address: "10.0.0.1/24"}
+	vrfIP := &objects.IPAddress{Address: "10.0.0.2/32", Vrf: vrf}
+	nbi := &NetboxInventory{
+		IPAdressesIndexByAddress: map[string]*objects.IPAddress{globalIP.Address: globalIP},
+		IPAddressesIndexByVRFIDAndAddress: map[int]map[string]*objects.IPAddress{
+			vrf.ID: {vrfIP.Address: vrfIP},
+		},
+	}
+	tests := []struct {
+		name  string
+		vrf   *objects.VRF
+		hosts []string
+		want  map[string]*objects.IPAddress
+	}{
+		{name: "Global hosts", hosts: []string{"10.0.0.1", "10.0.0.2"}, want: map[string]*objects.IPAddress{"10.0.0.1": globalIP}},
+		{name: "Hosts within vrf", vrf: vrf, hosts: []string{"10.0.0.1", "10.0.0.2"}, want: map[string]*objects.IPAddress{"10.0.0.2": vrfIP}},
+		{name: "No hosts", hosts: []string{}, want: map[string]*objects.IPAddress{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nbi.GetIPAddressesByHosts(tt.vrf, tt.hosts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.GetIPAddressesByHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_MoveDevice(t *testing.T) {
+	oldSite := &objects.Site{NetboxObject: objects.NetboxObject{ID: 1}, Name: "Floor 1"}
+	newSite := &objects.Site{NetboxObject: objects.NetboxObject{ID: 2}, Name: "HQ"}
+	oldDevice := &objects.Device{NetboxObject: objects.NetboxObject{ID: 1}, Name: "switch1", Site: oldSite}
+	newDevice := &objects.Device{NetboxObject: objects.NetboxObject{ID: 2}, Name: "switch2", Site: newSite}
+	tests := []struct {
+		name    string
+		device  string
+		oldSite *objects.Site
+	}{
+		{name: "Device without old site", device: oldDevice.Name, oldSite: nil},
+		{name: "Old site is the same as new site", device: newDevice.Name, oldSite: newSite},
+		{name: "Device already in new site", device: newDevice.Name, oldSite: oldSite},
+		{name: "Device not in old site", device: "switch3", oldSite: oldSite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nbi := &NetboxInventory{
+				DevicesIndexByNameAndSiteID: map[string]map[int]*objects.Device{
+					oldDevice.Name: {oldSite.ID: oldDevice},
+					newDevice.Name: {oldSite.ID: oldDevice, newSite.ID: newDevice},
+				},
+			}
+			want := map[string]map[int]*objects.Device{
+				oldDevice.Name: {oldSite.ID: oldDevice},
+				newDevice.Name: {oldSite.ID: oldDevice, newSite.ID: newDevice},
+			}
+			if err := nbi.MoveDevice(context.Background(), tt.device, tt.oldSite, newSite, nil); err != nil {
+				t.Errorf("NetboxInventory.MoveDevice() error = %v", err)
+			}
+			if !reflect.DeepEqual(nbi.DevicesIndexByNameAndSiteID, want) {
+				t.Errorf("NetboxInventory.MoveDevice() changed devices index to %v, want %v", nbi.DevicesIndexByNameAndSiteID, want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_AddFHRPGroupAssignment(t *testing.T) {
+	type args struct {
+		ctx                    context.Context
+		newFHRPGroupAssignment *objects.FHRPGroupAssignment
+	}
+	tests := []struct {
+		name    string
+		nbi     *NetboxInventory
+		args    args
+		want    *objects.FHRPGroupAssignment
+		wantErr bool
+	}{
+		{
+			name:    "Test add fhrp group assignment without group",
+			nbi:     MockInventory,
+			args:    args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newFHRPGroupAssignment: &objects.FHRPGroupAssignment{InterfaceType: objects.AssignedObjectTypeDeviceInterface, InterfaceID: 1, Priority: 100}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.nbi.AddFHRPGroupAssignment(tt.args.ctx, tt.args.newFHRPGroupAssignment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxInventory.AddFHRPGroupAssignment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.AddFHRPGroupAssignment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_AddTunnelTermination(t *testing.T) {
+	type args struct {
+		ctx                  context.Context
+		newTunnelTermination *objects.TunnelTermination
+	}
+	tests := []struct {
+		name    string
+		nbi     *NetboxInventory
+		args    args
+		want    *objects.TunnelTermination
+		wantErr bool
+	}{
+		{
+			name:    "Test add tunnel termination without tunnel",
+			nbi:     MockInventory,
+			args:    args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newTunnelTermination: &objects.TunnelTermination{Role: &objects.TunnelTerminationRolePeer, TerminationType: objects.AssignedObjectTypeDeviceInterface, TerminationID: 1}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.nbi.AddTunnelTermination(tt.args.ctx, tt.args.newTunnelTermination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxInventory.AddTunnelTermination() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.AddTunnelTermination() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_AddBGPSession(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		newBGPSession *objects.BGPSession
+	}
+	tests := []struct {
+		name               string
+		nbi                *NetboxInventory
+		bgpPluginInstalled bool
+		args               args
+		want               *objects.BGPSession
+		wantErr            bool
+	}{
+		{
+			name:               "Test add bgp session without netbox-bgp plugin",
+			nbi:                MockInventory,
+			bgpPluginInstalled: false,
+			args:               args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newBGPSession: &objects.BGPSession{Name: "MockBGPSession"}},
+			want:               nil,
+			wantErr:            true,
+		},
+		{
+			name:               "Test add new bgp session",
+			nbi:                MockInventory,
+			bgpPluginInstalled: true,
+			args: args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newBGPSession: &objects.BGPSession{
+				Name:          "MockBGPSession",
+				LocalAddress:  service.MockBGPSessionCreateResponse.LocalAddress,
+				RemoteAddress: service.MockBGPSessionCreateResponse.RemoteAddress,
+				LocalAS:       service.MockBGPSessionCreateResponse.LocalAS,
+				RemoteAS:      service.MockBGPSessionCreateResponse.RemoteAS,
+				Status:        &objects.BGPSessionStatusActive,
+			}},
+			want: &service.MockBGPSessionCreateResponse,
+		},
+	}
+	mockServer := service.CreateMockServer()
+	defer mockServer.Close()
+	service.MockNetboxClient.BaseURL = mockServer.URL
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.nbi.BGPPluginInstalled = tt.bgpPluginInstalled
+			defer func() { tt.nbi.BGPPluginInstalled = false }()
+			got, err := tt.nbi.AddBGPSession(tt.args.ctx, tt.args.newBGPSession)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxInventory.AddBGPSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.AddBGPSession() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxInventory_AddInventoryItem(t *testing.T) {
+	type args struct {
+		ctx              context.Context
+		newInventoryItem *objects.InventoryItem
+	}
+	tests := []struct {
+		name    string
+		nbi     *NetboxInventory
+		args    args
+		want    *objects.InventoryItem
+		wantErr bool
+	}{
+		{
+			name:    "Test add inventory item without device",
+			nbi:     MockInventory,
+			args:    args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newInventoryItem: &objects.InventoryItem{Name: "Switch 1 - Power Supply A"}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test add new inventory item",
+			nbi:  MockInventory,
+			args: args{ctx: context.WithValue(context.Background(), constants.CtxSourceKey, "test"), newInventoryItem: &objects.InventoryItem{
+				Device: service.MockInventoryItemCreateResponse.Device,
+				Name:   "Switch 1 - Power Supply A",
+				PartID: "PWR-C1-715WAC-P",
+				Serial: "ART1234ABCD",
+			}},
+			want: &service.MockInventoryItemCreateResponse,
+		},
+	}
+	mockServer := service.CreateMockServer()
+	defer mockServer.Close()
+	service.MockNetboxClient.BaseURL = mockServer.URL
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.nbi.AddInventoryItem(tt.args.ctx, tt.args.newInventoryItem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxInventory.AddInventoryItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxInventory.AddInventoryItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package inventory
 
-import "context"
+import (
+	"context"
+	"slices"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/service"
+)
 
 func (nbi *NetboxInventory) DeleteOrphans(ctx context.Context) error {
 	// Ensure OrphanObjectPriority and OrphanManager lengths are the same,
@@ -9,6 +15,8 @@ func (nbi *NetboxInventory) DeleteOrphans(ctx context.Context) error {
 		panic("len(nbi.OrphanManager) != len(nbi.OrphanObjectPriority). This should not happen. Every orphan managed object must have its corresponding priority")
 	}
 
+	nbi.deleteOrphanSiteASNs(ctx)
+
 	for i := 0; i < len(nbi.OrphanObjectPriority); i++ {
 		objectAPIPath := nbi.OrphanObjectPriority[i]
 		ids := nbi.OrphanManager[objectAPIPath]
@@ -25,3 +33,42 @@ func (nbi *NetboxInventory) DeleteOrphans(ctx context.Context) error {
 	}
 	return nil
 }
+
+// deleteOrphanSiteASNs unassigns asns managed by netbox-ssot from sites, if they
+// were not assigned to the site in this run (e.g. bgp was removed from all devices
+// of the site). Asns not managed by netbox-ssot are kept.
+func (nbi *NetboxInventory) deleteOrphanSiteASNs(ctx context.Context) {
+	nbi.SitesLock.Lock()
+	defer nbi.SitesLock.Unlock()
+	for siteName, site := range nbi.SitesIndexByName {
+		asnIDs := make([]int, 0, len(site.ASNs))
+		for _, siteASN := range site.ASNs {
+			if !nbi.SiteASNsSynced[site.ID][siteASN.ID] && nbi.isSsotASN(siteASN.ID) {
+				continue
+			}
+			asnIDs = append(asnIDs, siteASN.ID)
+		}
+		if len(asnIDs) == len(site.ASNs) {
+			continue
+		}
+		nbi.Logger.Infof(ctx, "Unassigning orphaned asns from site %s", siteName)
+		patchedSite, err := service.Patch[objects.Site](ctx, nbi.NetboxAPI, site.ID, map[string]interface{}{"asns": asnIDs})
+		if err != nil {
+			nbi.Logger.Errorf(ctx, "unassign asns from site %s: %s", siteName, err)
+			continue
+		}
+		nbi.SitesIndexByName[siteName] = patchedSite
+	}
+}
+
+// isSsotASN returns true, if asn with asnID is managed by netbox-ssot.
+func (nbi *NetboxInventory) isSsotASN(asnID int) bool {
+	nbi.ASNsLock.Lock()
+	defer nbi.ASNsLock.Unlock()
+	for _, asn := range nbi.ASNsIndexByASN {
+		if asn.ID == asnID {
+			return slices.IndexFunc(asn.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -19,9 +20,15 @@ func (nbi *NetboxInventory) InitTags(ctx context.Context) error {
 		return err
 	}
 	nbi.TagsIndexByName = make(map[string]*objects.Tag)
+	nbi.OrphanManager[constants.TagsAPIPath] = make(map[int]bool)
 	for i := range nbTags {
 		tag := nbTags[i]
 		nbi.TagsIndexByName[tag.Name] = &tag
+		// Tags can't be tagged, so only tags of content library items are
+		// recognized by their slug and added to the orphan manager
+		if strings.HasPrefix(tag.Slug, constants.ContentLibraryTagSlugPrefix) {
+			nbi.OrphanManager[constants.TagsAPIPath][tag.ID] = true
+		}
 	}
 	nbi.Logger.Debug(ctx, "Successfully collected tags from Netbox: ", nbi.TagsIndexByName)
 
@@ -171,14 +178,70 @@ func (nbi *NetboxInventory) InitSites(ctx context.Context) error {
 	}
 	// We also create an index of sites by name for easier access
 	nbi.SitesIndexByName = make(map[string]*objects.Site)
+	nbi.OrphanManager[constants.SitesAPIPath] = make(map[int]bool)
 	for i := range nbSites {
 		site := &nbSites[i]
 		nbi.SitesIndexByName[site.Name] = site
+		// OrphanManager takes care only of sites synced from sources. Sites created from
+		// relations and the default site have no source, so they are never removed
+		sourceName, _ := site.CustomFields[constants.CustomFieldSourceName].(string)
+		isSsotSite := slices.IndexFunc(site.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0
+		if isSsotSite && sourceName != "" && sourceName != nbi.SsotTag.Name {
+			nbi.OrphanManager[constants.SitesAPIPath][site.ID] = true
+		}
 	}
 	nbi.Logger.Debug(ctx, "Successfully collected sites from Netbox: ", nbi.SitesIndexByName)
 	return nil
 }
 
+// Collects all regions from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitRegions(ctx context.Context) error {
+	nbRegions, err := service.GetAll[objects.Region](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.RegionsIndexByParentIDAndName = make(map[int]map[string]*objects.Region)
+	nbi.OrphanManager[constants.RegionsAPIPath] = make(map[int]bool)
+	for i := range nbRegions {
+		region := &nbRegions[i]
+		parentID := regionParentID(region)
+		if nbi.RegionsIndexByParentIDAndName[parentID] == nil {
+			nbi.RegionsIndexByParentIDAndName[parentID] = make(map[string]*objects.Region)
+		}
+		nbi.RegionsIndexByParentIDAndName[parentID][region.Name] = region
+		if slices.IndexFunc(region.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.RegionsAPIPath][region.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected regions from Netbox: ", nbi.RegionsIndexByParentIDAndName)
+	return nil
+}
+
+// Collects all locations from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitLocations(ctx context.Context) error {
+	nbLocations, err := service.GetAll[objects.Location](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.LocationsIndexBySiteIDAndName = make(map[int]map[string]*objects.Location)
+	nbi.OrphanManager[constants.LocationsAPIPath] = make(map[int]bool)
+	for i := range nbLocations {
+		location := &nbLocations[i]
+		if location.Site == nil {
+			continue
+		}
+		if nbi.LocationsIndexBySiteIDAndName[location.Site.ID] == nil {
+			nbi.LocationsIndexBySiteIDAndName[location.Site.ID] = make(map[string]*objects.Location)
+		}
+		nbi.LocationsIndexBySiteIDAndName[location.Site.ID][location.Name] = location
+		if slices.IndexFunc(location.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.LocationsAPIPath][location.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected locations from Netbox: ", nbi.LocationsIndexBySiteIDAndName)
+	return nil
+}
+
 // InitDefaultSite inits default site, which is used for hosts that have no corresponding site.
 // This is because site is required for adding new hosts.
 func (nbi *NetboxInventory) InitDefaultSite(ctx context.Context) error {
@@ -269,6 +332,25 @@ func (nbi *NetboxInventory) InitDevices(ctx context.Context) error {
 	return nil
 }
 
+// Collects all virtual chassis from Netbox API and stores them in the local inventory.
+func (nbi *NetboxInventory) InitVirtualChassis(ctx context.Context) error {
+	nbVirtualChassis, err := service.GetAll[objects.VirtualChassis](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.VirtualChassisIndexByName = make(map[string]*objects.VirtualChassis)
+	nbi.OrphanManager[constants.VirtualChassisAPIPath] = make(map[int]bool)
+	for i := range nbVirtualChassis {
+		virtualChassis := &nbVirtualChassis[i]
+		nbi.VirtualChassisIndexByName[virtualChassis.Name] = virtualChassis
+		if slices.IndexFunc(virtualChassis.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.VirtualChassisAPIPath][virtualChassis.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected virtual chassis from Netbox: ", nbi.VirtualChassisIndexByName)
+	return nil
+}
+
 // Collect all devices from Netbox API and store them in the NetBoxInventory.
 func (nbi *NetboxInventory) InitVirtualDeviceContexts(ctx context.Context) error {
 	nbVirtualDeviceContexts, err := service.GetAll[objects.VirtualDeviceContext](ctx, nbi.NetboxAPI, "")
@@ -294,6 +376,88 @@ func (nbi *NetboxInventory) InitVirtualDeviceContexts(ctx context.Context) error
 	return nil
 }
 
+// Collects all module types from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitModuleTypes(ctx context.Context) error {
+	moduleTypes, err := service.GetAll[objects.ModuleType](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.ModuleTypesIndexByModel = make(map[string]*objects.ModuleType)
+	nbi.OrphanManager[constants.ModuleTypesAPIPath] = make(map[int]bool)
+	for i := range moduleTypes {
+		moduleType := &moduleTypes[i]
+		nbi.ModuleTypesIndexByModel[moduleType.Model] = moduleType
+		if slices.IndexFunc(moduleType.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.ModuleTypesAPIPath][moduleType.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected module types from Netbox: ", nbi.ModuleTypesIndexByModel)
+	return nil
+}
+
+// Collects all module bays from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitModuleBays(ctx context.Context) error {
+	moduleBays, err := service.GetAll[objects.ModuleBay](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.ModuleBaysIndexByDeviceIDAndName = make(map[int]map[string]*objects.ModuleBay)
+	nbi.OrphanManager[constants.ModuleBaysAPIPath] = make(map[int]bool)
+	for i := range moduleBays {
+		moduleBay := &moduleBays[i]
+		if nbi.ModuleBaysIndexByDeviceIDAndName[moduleBay.Device.ID] == nil {
+			nbi.ModuleBaysIndexByDeviceIDAndName[moduleBay.Device.ID] = make(map[string]*objects.ModuleBay)
+		}
+		nbi.ModuleBaysIndexByDeviceIDAndName[moduleBay.Device.ID][moduleBay.Name] = moduleBay
+		if slices.IndexFunc(moduleBay.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.ModuleBaysAPIPath][moduleBay.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected module bays from Netbox: ", nbi.ModuleBaysIndexByDeviceIDAndName)
+	return nil
+}
+
+// Collects all modules from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitModules(ctx context.Context) error {
+	modules, err := service.GetAll[objects.Module](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.ModulesIndexByModuleBayID = make(map[int]*objects.Module)
+	nbi.OrphanManager[constants.ModulesAPIPath] = make(map[int]bool)
+	for i := range modules {
+		module := &modules[i]
+		nbi.ModulesIndexByModuleBayID[module.ModuleBay.ID] = module
+		if slices.IndexFunc(module.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.ModulesAPIPath][module.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected modules from Netbox: ", nbi.ModulesIndexByModuleBayID)
+	return nil
+}
+
+// Collects all inventory items from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitInventoryItems(ctx context.Context) error {
+	inventoryItems, err := service.GetAll[objects.InventoryItem](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.InventoryItemsIndexByDeviceIDAndName = make(map[int]map[string]*objects.InventoryItem)
+	nbi.OrphanManager[constants.InventoryItemsAPIPath] = make(map[int]bool)
+	for i := range inventoryItems {
+		inventoryItem := &inventoryItems[i]
+		if nbi.InventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID] == nil {
+			nbi.InventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID] = make(map[string]*objects.InventoryItem)
+		}
+		nbi.InventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID][inventoryItem.Name] = inventoryItem
+		if slices.IndexFunc(inventoryItem.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.InventoryItemsAPIPath][inventoryItem.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected inventory items from Netbox: ", nbi.InventoryItemsIndexByDeviceIDAndName)
+	return nil
+}
+
 // Collects all deviceRoles from Netbox API and store them in the
 // NetBoxInventory.
 func (nbi *NetboxInventory) InitDeviceRoles(ctx context.Context) error {
@@ -340,6 +504,13 @@ func (nbi *NetboxInventory) InitCustomFields(ctx context.Context) error {
 // - sourceId - this is used to store the ID of the source object in Netbox (interfaces).
 func (nbi *NetboxInventory) InitSsotCustomFields(ctx context.Context) error {
 	// Custom field for storing object's source name.
+	sourceContentTypes := []string{constants.ContentTypeDcimDevice, constants.ContentTypeDcimDeviceRole, constants.ContentTypeDcimDeviceType, constants.ContentTypeDcimInterface, constants.ContentTypeDcimLocation, constants.ContentTypeDcimManufacturer, constants.ContentTypeDcimModule, constants.ContentTypeDcimModuleBay, constants.ContentTypeDcimModuleType, constants.ContentTypeDcimInventoryItem, constants.ContentTypeDcimPlatform, constants.ContentTypeDcimRegion, constants.ContentTypeDcimSite, constants.ContentTypeDcimVirtualChassis, constants.ContentTypeVirtualDeviceContext, constants.ContentTypeIpamFHRPGroup, constants.ContentTypeIpamIPAddress, constants.ContentTypeIpamVlanGroup, constants.ContentTypeIpamVlan, constants.ContentTypeIpamPrefix, constants.ContentTypeIpamVRF, constants.ContentTypeIpamIPRange, constants.ContentTypeIpamRIR, constants.ContentTypeIpamASN, constants.ContentTypeTenancyTenantGroup, constants.ContentTypeTenancyTenant, constants.ContentTypeTenancyContact, constants.ContentTypeTenancyContactAssignment, constants.ContentTypeTenancyContactGroup, constants.ContentTypeTenancyContactRole, constants.ContentTypeVirtualizationCluster, constants.ContentTypeVirtualizationClusterGroup, constants.ContentTypeVirtualizationClusterType, constants.ContentTypeVirtualizationVirtualMachine, constants.ContentTypeVirtualizationVMInterface, constants.ContentTypeVpnTunnel, constants.ContentTypeVpnTunnelTermination, constants.ContentTypeVpnIKEProposal, constants.ContentTypeVpnIKEPolicy, constants.ContentTypeVpnIPSecProposal, constants.ContentTypeVpnIPSecPolicy, constants.ContentTypeVpnIPSecProfile, constants.ContentTypeWirelessWirelessLAN}
+	if nbi.SupportsMACAddressObjects() {
+		sourceContentTypes = append(sourceContentTypes, constants.ContentTypeDcimMACAddress)
+	}
+	if nbi.BGPPluginInstalled {
+		sourceContentTypes = append(sourceContentTypes, constants.ContentTypeBGPSession)
+	}
 	_, err := nbi.AddCustomField(ctx, &objects.CustomField{
 		Name:                  constants.CustomFieldSourceName,
 		Label:                 constants.CustomFieldSourceLabel,
@@ -350,7 +521,7 @@ func (nbi *NetboxInventory) InitSsotCustomFields(ctx context.Context) error {
 		DisplayWeight:         objects.DisplayWeightDefault,
 		Description:           constants.CustomFieldSourceDescription,
 		SearchWeight:          objects.SearchWeightDefault,
-		ContentTypes:          []string{constants.ContentTypeDcimDevice, constants.ContentTypeDcimDeviceRole, constants.ContentTypeDcimDeviceType, constants.ContentTypeDcimInterface, constants.ContentTypeDcimLocation, constants.ContentTypeDcimManufacturer, constants.ContentTypeDcimPlatform, constants.ContentTypeDcimRegion, constants.ContentTypeDcimSite, constants.ContentTypeVirtualDeviceContext, constants.ContentTypeIpamIPAddress, constants.ContentTypeIpamVlanGroup, constants.ContentTypeIpamVlan, constants.ContentTypeIpamPrefix, constants.ContentTypeTenancyTenantGroup, constants.ContentTypeTenancyTenant, constants.ContentTypeTenancyContact, constants.ContentTypeTenancyContactAssignment, constants.ContentTypeTenancyContactGroup, constants.ContentTypeTenancyContactRole, constants.ContentTypeVirtualizationCluster, constants.ContentTypeVirtualizationClusterGroup, constants.ContentTypeVirtualizationClusterType, constants.ContentTypeVirtualizationVirtualMachine, constants.ContentTypeVirtualizationVMInterface},
+		ContentTypes:          sourceContentTypes,
 	})
 	if err != nil {
 		return fmt.Errorf("add custom field %s", err)
@@ -399,7 +570,7 @@ func (nbi *NetboxInventory) InitSsotCustomFields(ctx context.Context) error {
 		Description:           constants.CustomFieldArpEntryDescription,
 		SearchWeight:          objects.SearchWeightDefault,
 		Default:               false,
-		ContentTypes:          []string{constants.ContentTypeIpamIPAddress},
+		ContentTypes:          nbi.ArpContentTypes(),
 	})
 	if err != nil {
 		return fmt.Errorf("add custom field: %s", err)
@@ -667,6 +838,103 @@ func (nbi *NetboxInventory) InitVMInterfaces(ctx context.Context) error {
 	return nil
 }
 
+// Collects all VRFs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitVRFs(ctx context.Context) error {
+	vrfs, err := service.GetAll[objects.VRF](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.VRFsIndexByName = make(map[string]*objects.VRF)
+	nbi.OrphanManager[constants.VRFsAPIPath] = make(map[int]bool)
+	for i := range vrfs {
+		vrf := &vrfs[i]
+		nbi.VRFsIndexByName[vrf.Name] = vrf
+		if slices.IndexFunc(vrf.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.VRFsAPIPath][vrf.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected VRFs from Netbox: ", nbi.VRFsIndexByName)
+	return nil
+}
+
+// Collects all IP ranges from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIPRanges(ctx context.Context) error {
+	ipRanges, err := service.GetAll[objects.IPRange](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	// Initializes internal indexes of ip ranges by vrf and addresses
+	nbi.IPRangesIndexByAddresses = make(map[string]*objects.IPRange)
+	nbi.IPRangesIndexByVRFIDAndAddresses = make(map[int]map[string]*objects.IPRange)
+	nbi.OrphanManager[constants.IPRangesAPIPath] = make(map[int]bool)
+	for i := range ipRanges {
+		ipRange := &ipRanges[i]
+		nbi.ipRangesIndex(ipRange.Vrf)[ipRangeKey(ipRange)] = ipRange
+		if slices.IndexFunc(ipRange.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IPRangesAPIPath][ipRange.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IP ranges from Netbox: ", nbi.IPRangesIndexByAddresses)
+	return nil
+}
+
+// Collects all RIRs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitRIRs(ctx context.Context) error {
+	rirs, err := service.GetAll[objects.RIR](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.RIRsIndexByName = make(map[string]*objects.RIR)
+	nbi.OrphanManager[constants.RIRsAPIPath] = make(map[int]bool)
+	for i := range rirs {
+		rir := &rirs[i]
+		nbi.RIRsIndexByName[rir.Name] = rir
+		if slices.IndexFunc(rir.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.RIRsAPIPath][rir.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected RIRs from Netbox: ", nbi.RIRsIndexByName)
+	return nil
+}
+
+// Collects all ASNs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitASNs(ctx context.Context) error {
+	asns, err := service.GetAll[objects.ASN](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.ASNsIndexByASN = make(map[int64]*objects.ASN)
+	nbi.OrphanManager[constants.ASNsAPIPath] = make(map[int]bool)
+	for i := range asns {
+		asn := &asns[i]
+		nbi.ASNsIndexByASN[asn.ASN] = asn
+		if slices.IndexFunc(asn.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.ASNsAPIPath][asn.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected ASNs from Netbox: ", nbi.ASNsIndexByASN)
+	return nil
+}
+
+// Collects all wireless lans from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitWirelessLANs(ctx context.Context) error {
+	wirelessLANs, err := service.GetAll[objects.WirelessLAN](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.WirelessLANsIndexBySSID = make(map[string]*objects.WirelessLAN)
+	nbi.OrphanManager[constants.WirelessLANsAPIPath] = make(map[int]bool)
+	for i := range wirelessLANs {
+		wirelessLAN := &wirelessLANs[i]
+		nbi.WirelessLANsIndexBySSID[wirelessLAN.SSID] = wirelessLAN
+		if slices.IndexFunc(wirelessLAN.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.WirelessLANsAPIPath][wirelessLAN.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected wireless lans from Netbox: ", nbi.WirelessLANsIndexBySSID)
+	return nil
+}
+
 // Collects all IP addresses from Netbox API and stores them to local inventory.
 func (nbi *NetboxInventory) InitIPAddresses(ctx context.Context) error {
 	ipAddresses, err := service.GetAll[objects.IPAddress](ctx, nbi.NetboxAPI, "")
@@ -674,29 +942,19 @@ func (nbi *NetboxInventory) InitIPAddresses(ctx context.Context) error {
 		return err
 	}
 
-	// Initializes internal index of IP addresses by address
+	// Initializes internal indexes of IP addresses by vrf and address
 	nbi.IPAdressesIndexByAddress = make(map[string]*objects.IPAddress)
+	nbi.IPAddressesIndexByVRFIDAndAddress = make(map[int]map[string]*objects.IPAddress)
 	// Add IP addresses to orphan manager
 	nbi.OrphanManager[constants.IPAddressesAPIPath] = make(map[int]bool, 0)
 
 	for i := range ipAddresses {
 		ipAddr := &ipAddresses[i]
-		nbi.IPAdressesIndexByAddress[ipAddr.Address] = ipAddr
+		nbi.ipAddressesIndex(ipAddr.Vrf)[ipAddr.Address] = ipAddr
 		if slices.IndexFunc(ipAddr.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
 			// Also check if IP is of type arp entry, if entry is older
-			if isArpEntry, ok := ipAddr.CustomFields[constants.CustomFieldArpEntryName]; ok {
-				if isArpEntry.(bool) { //nolint:forcetypeassert
-					if arpLastSeen, ok := ipAddr.CustomFields[constants.CustomFieldArpIPLastSeenName]; ok {
-						nowTime := time.Now()
-						lastSeenTime, err := time.Parse(constants.ArpLastSeenFormat, arpLastSeen.(string))
-						if err != nil {
-							nbi.Logger.Errorf(nbi.Ctx, "failed parsing last seen time: %s", err)
-						}
-						if int(nowTime.Sub(lastSeenTime).Seconds()) < nbi.NetboxConfig.ArpDataLifeSpan {
-							continue
-						}
-					}
-				}
+			if nbi.isArpEntryAlive(ipAddr.CustomFields) {
+				continue
 			}
 			nbi.OrphanManager[constants.IPAddressesAPIPath][ipAddr.ID] = true
 		}
@@ -706,6 +964,101 @@ func (nbi *NetboxInventory) InitIPAddresses(ctx context.Context) error {
 	return nil
 }
 
+// Helper function that returns true, if object with given customFields was
+// collected from arp table, and is still within the ArpDataLifeSpan.
+// Such objects shouldn't be marked as orphans.
+func (nbi *NetboxInventory) isArpEntryAlive(customFields map[string]interface{}) bool {
+	if isArpEntry, ok := customFields[constants.CustomFieldArpEntryName].(bool); !ok || !isArpEntry {
+		return false
+	}
+	arpLastSeen, ok := customFields[constants.CustomFieldArpIPLastSeenName].(string)
+	if !ok {
+		return false
+	}
+	lastSeenTime, err := time.Parse(constants.ArpLastSeenFormat, arpLastSeen)
+	if err != nil {
+		nbi.Logger.Errorf(nbi.Ctx, "failed parsing last seen time: %s", err)
+	}
+	return int(time.Since(lastSeenTime).Seconds()) < nbi.NetboxConfig.ArpDataLifeSpan
+}
+
+// Collects all mac addresses from Netbox API and stores them to local inventory.
+// Mac addresses are standalone objects only since netbox 4.2, so for older
+// versions we only initialize empty index.
+func (nbi *NetboxInventory) InitMACAddresses(ctx context.Context) error {
+	nbi.MACAddressesIndexByMACAndAssignedObject = make(map[string]map[objects.AssignedObjectType]map[int]*objects.MACAddress)
+	nbi.OrphanManager[constants.MACAddressesAPIPath] = make(map[int]bool, 0)
+	if !nbi.SupportsMACAddressObjects() {
+		nbi.Logger.Debugf(ctx, "Netbox version %s doesn't support mac address objects. Skipping...", nbi.NetboxAPI.Version)
+		return nil
+	}
+
+	macAddresses, err := service.GetAll[objects.MACAddress](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+
+	for i := range macAddresses {
+		macAddress := &macAddresses[i]
+		nbi.indexMACAddress(macAddress)
+		if slices.IndexFunc(macAddress.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			if nbi.isArpEntryAlive(macAddress.CustomFields) {
+				continue
+			}
+			nbi.OrphanManager[constants.MACAddressesAPIPath][macAddress.ID] = true
+		}
+	}
+
+	nbi.Logger.Debug(ctx, "Successfully collected mac addresses from Netbox: ", nbi.MACAddressesIndexByMACAndAssignedObject)
+	return nil
+}
+
+// Collects all FHRP groups from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitFHRPGroups(ctx context.Context) error {
+	fhrpGroups, err := service.GetAll[objects.FHRPGroup](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.FHRPGroupsIndexByName = make(map[string]*objects.FHRPGroup)
+	nbi.OrphanManager[constants.FHRPGroupsAPIPath] = make(map[int]bool)
+	for i := range fhrpGroups {
+		fhrpGroup := &fhrpGroups[i]
+		nbi.FHRPGroupsIndexByName[fhrpGroup.Name] = fhrpGroup
+		if slices.IndexFunc(fhrpGroup.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.FHRPGroupsAPIPath][fhrpGroup.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected FHRP groups from Netbox: ", nbi.FHRPGroupsIndexByName)
+	return nil
+}
+
+// Collects all FHRP group assignments from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitFHRPGroupAssignments(ctx context.Context) error {
+	fhrpGroupAssignments, err := service.GetAll[objects.FHRPGroupAssignment](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface = make(map[int]map[objects.AssignedObjectType]map[int]*objects.FHRPGroupAssignment)
+	nbi.OrphanManager[constants.FHRPGroupAssignmentsAPIPath] = make(map[int]bool)
+	// FHRP group assignments don't support tags, so assignments to groups
+	// managed by netbox-ssot are added to the orphan manager
+	ssotFHRPGroupIDs := make(map[int]bool)
+	for _, fhrpGroup := range nbi.FHRPGroupsIndexByName {
+		if slices.IndexFunc(fhrpGroup.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			ssotFHRPGroupIDs[fhrpGroup.ID] = true
+		}
+	}
+	for i := range fhrpGroupAssignments {
+		fhrpGroupAssignment := &fhrpGroupAssignments[i]
+		nbi.indexFHRPGroupAssignment(fhrpGroupAssignment)
+		if fhrpGroupAssignment.Group != nil && ssotFHRPGroupIDs[fhrpGroupAssignment.Group.ID] {
+			nbi.OrphanManager[constants.FHRPGroupAssignmentsAPIPath][fhrpGroupAssignment.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected FHRP group assignments from Netbox: ", nbi.FHRPGroupAssignmentsIndexByGroupIDAndInterface)
+	return nil
+}
+
 // Collects all Prefixes from Netbox API and stores them to local inventory.
 func (nbi *NetboxInventory) InitPrefixes(ctx context.Context) error {
 	prefixes, err := service.GetAll[objects.Prefix](ctx, nbi.NetboxAPI, "")
@@ -713,14 +1066,15 @@ func (nbi *NetboxInventory) InitPrefixes(ctx context.Context) error {
 		return err
 	}
 
-	// Initializes internal index of prefixes by prefix
+	// Initializes internal indexes of prefixes by vrf and prefix
 	nbi.PrefixesIndexByPrefix = make(map[string]*objects.Prefix)
+	nbi.PrefixesIndexByVRFIDAndPrefix = make(map[int]map[string]*objects.Prefix)
 	// Add prefixes to orphan manager
 	nbi.OrphanManager[constants.PrefixesAPIPath] = make(map[int]bool, 0)
 
 	for i := range prefixes {
 		prefix := &prefixes[i]
-		nbi.PrefixesIndexByPrefix[prefix.Prefix] = prefix
+		nbi.prefixesIndex(prefix.Vrf)[prefix.Prefix] = prefix
 		if slices.IndexFunc(prefix.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
 			nbi.OrphanManager[constants.PrefixesAPIPath][prefix.ID] = true
 		}
@@ -729,3 +1083,163 @@ func (nbi *NetboxInventory) InitPrefixes(ctx context.Context) error {
 	nbi.Logger.Debug(ctx, "Successfully collected prefixes from Netbox: ", nbi.PrefixesIndexByPrefix)
 	return nil
 }
+
+// Collects all IKE proposals from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIKEProposals(ctx context.Context) error {
+	nbIKEProposals, err := service.GetAll[objects.IKEProposal](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.IKEProposalsIndexByName = make(map[string]*objects.IKEProposal)
+	nbi.OrphanManager[constants.IKEProposalsAPIPath] = make(map[int]bool)
+	for i := range nbIKEProposals {
+		ikeProposal := &nbIKEProposals[i]
+		nbi.IKEProposalsIndexByName[ikeProposal.Name] = ikeProposal
+		if slices.IndexFunc(ikeProposal.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IKEProposalsAPIPath][ikeProposal.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IKE proposals from Netbox: ", nbi.IKEProposalsIndexByName)
+	return nil
+}
+
+// Collects all IKE policies from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIKEPolicies(ctx context.Context) error {
+	nbIKEPolicies, err := service.GetAll[objects.IKEPolicy](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.IKEPoliciesIndexByName = make(map[string]*objects.IKEPolicy)
+	nbi.OrphanManager[constants.IKEPoliciesAPIPath] = make(map[int]bool)
+	for i := range nbIKEPolicies {
+		ikePolicy := &nbIKEPolicies[i]
+		nbi.IKEPoliciesIndexByName[ikePolicy.Name] = ikePolicy
+		if slices.IndexFunc(ikePolicy.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IKEPoliciesAPIPath][ikePolicy.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IKE policies from Netbox: ", nbi.IKEPoliciesIndexByName)
+	return nil
+}
+
+// Collects all IPSec proposals from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIPSecProposals(ctx context.Context) error {
+	nbIPSecProposals, err := service.GetAll[objects.IPSecProposal](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.IPSecProposalsIndexByName = make(map[string]*objects.IPSecProposal)
+	nbi.OrphanManager[constants.IPSecProposalsAPIPath] = make(map[int]bool)
+	for i := range nbIPSecProposals {
+		ipsecProposal := &nbIPSecProposals[i]
+		nbi.IPSecProposalsIndexByName[ipsecProposal.Name] = ipsecProposal
+		if slices.IndexFunc(ipsecProposal.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IPSecProposalsAPIPath][ipsecProposal.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IPSec proposals from Netbox: ", nbi.IPSecProposalsIndexByName)
+	return nil
+}
+
+// Collects all IPSec policies from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIPSecPolicies(ctx context.Context) error {
+	nbIPSecPolicies, err := service.GetAll[objects.IPSecPolicy](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.IPSecPoliciesIndexByName = make(map[string]*objects.IPSecPolicy)
+	nbi.OrphanManager[constants.IPSecPoliciesAPIPath] = make(map[int]bool)
+	for i := range nbIPSecPolicies {
+		ipsecPolicy := &nbIPSecPolicies[i]
+		nbi.IPSecPoliciesIndexByName[ipsecPolicy.Name] = ipsecPolicy
+		if slices.IndexFunc(ipsecPolicy.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IPSecPoliciesAPIPath][ipsecPolicy.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IPSec policies from Netbox: ", nbi.IPSecPoliciesIndexByName)
+	return nil
+}
+
+// Collects all IPSec profiles from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitIPSecProfiles(ctx context.Context) error {
+	nbIPSecProfiles, err := service.GetAll[objects.IPSecProfile](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.IPSecProfilesIndexByName = make(map[string]*objects.IPSecProfile)
+	nbi.OrphanManager[constants.IPSecProfilesAPIPath] = make(map[int]bool)
+	for i := range nbIPSecProfiles {
+		ipsecProfile := &nbIPSecProfiles[i]
+		nbi.IPSecProfilesIndexByName[ipsecProfile.Name] = ipsecProfile
+		if slices.IndexFunc(ipsecProfile.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.IPSecProfilesAPIPath][ipsecProfile.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected IPSec profiles from Netbox: ", nbi.IPSecProfilesIndexByName)
+	return nil
+}
+
+// Collects all tunnels from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitTunnels(ctx context.Context) error {
+	nbTunnels, err := service.GetAll[objects.Tunnel](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.TunnelsIndexByName = make(map[string]*objects.Tunnel)
+	nbi.OrphanManager[constants.TunnelsAPIPath] = make(map[int]bool)
+	for i := range nbTunnels {
+		tunnel := &nbTunnels[i]
+		nbi.TunnelsIndexByName[tunnel.Name] = tunnel
+		if slices.IndexFunc(tunnel.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.TunnelsAPIPath][tunnel.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected tunnels from Netbox: ", nbi.TunnelsIndexByName)
+	return nil
+}
+
+// Collects all tunnel terminations from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) InitTunnelTerminations(ctx context.Context) error {
+	nbTunnelTerminations, err := service.GetAll[objects.TunnelTermination](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	nbi.TunnelTerminationsIndexByTermination = make(map[objects.AssignedObjectType]map[int]*objects.TunnelTermination)
+	nbi.OrphanManager[constants.TunnelTerminationsAPIPath] = make(map[int]bool)
+	for i := range nbTunnelTerminations {
+		tunnelTermination := &nbTunnelTerminations[i]
+		if nbi.TunnelTerminationsIndexByTermination[tunnelTermination.TerminationType] == nil {
+			nbi.TunnelTerminationsIndexByTermination[tunnelTermination.TerminationType] = make(map[int]*objects.TunnelTermination)
+		}
+		nbi.TunnelTerminationsIndexByTermination[tunnelTermination.TerminationType][tunnelTermination.TerminationID] = tunnelTermination
+		if slices.IndexFunc(tunnelTermination.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.TunnelTerminationsAPIPath][tunnelTermination.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected tunnel terminations from Netbox: ", nbi.TunnelTerminationsIndexByTermination)
+	return nil
+}
+
+// Collects all bgp sessions from netbox-bgp plugin and stores them to local inventory.
+// If the plugin is not installed, we only initialize empty index.
+func (nbi *NetboxInventory) InitBGPSessions(ctx context.Context) error {
+	nbi.BGPSessionsIndexByName = make(map[string]*objects.BGPSession)
+	nbi.OrphanManager[constants.BGPSessionsAPIPath] = make(map[int]bool)
+	if !nbi.BGPPluginInstalled {
+		nbi.Logger.Debug(ctx, "Netbox-bgp plugin is not installed. Skipping bgp sessions...")
+		return nil
+	}
+	bgpSessions, err := service.GetAll[objects.BGPSession](ctx, nbi.NetboxAPI, "")
+	if err != nil {
+		return err
+	}
+	for i := range bgpSessions {
+		bgpSession := &bgpSessions[i]
+		nbi.BGPSessionsIndexByName[bgpSession.Name] = bgpSession
+		if slices.IndexFunc(bgpSession.Tags, func(t *objects.Tag) bool { return t.Slug == nbi.SsotTag.Slug }) >= 0 {
+			nbi.OrphanManager[constants.BGPSessionsAPIPath][bgpSession.ID] = true
+		}
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected bgp sessions from Netbox: ", nbi.BGPSessionsIndexByName)
+	return nil
+}
@@ -36,6 +36,11 @@ type NetboxInventory struct {
 	ContactAssignmentsIndexByContentTypeAndObjectIDAndContactIDAndRoleID map[string]map[int]map[int]map[int]*objects.ContactAssignment
 	// SitesIndexByName is a map of all sites in the Netbox's inventory, indexed by their name
 	SitesIndexByName map[string]*objects.Site
+	// RegionsIndexByParentIDAndName is a map of all regions in the Netbox's inventory, indexed by their
+	// parent id and name, because region names are unique only within their parent. Root regions have parent id 0.
+	RegionsIndexByParentIDAndName map[int]map[string]*objects.Region
+	// LocationsIndexBySiteIDAndName is a map of all locations in the Netbox's inventory, indexed by their site id and name
+	LocationsIndexBySiteIDAndName map[int]map[string]*objects.Location
 	// ManufacturersIndexByName is a map of all manufacturers in the Netbox's inventory, indexed by their name
 	ManufacturersIndexByName map[string]*objects.Manufacturer
 	// PlatformsIndexByName is a map of all platforms in the Netbox's inventory, indexed by their name
@@ -47,10 +52,27 @@ type NetboxInventory struct {
 	// DevicesIndexByNameAndSiteID is a map of all devices in the Netbox's inventory, indexed by their name, and
 	// site ID (This is because, netbox constraints: https://github.com/netbox-community/netbox/blob/3d941411d438f77b66d2036edf690c14b459af58/netbox/dcim/models/devices.py#L775)
 	DevicesIndexByNameAndSiteID map[string]map[int]*objects.Device
+	// VirtualChassisIndexByName is a map of all virtual chassis in the Netbox's inventory indexed by their name.
+	VirtualChassisIndexByName map[string]*objects.VirtualChassis
 	// VirtualDeviceContextsIndexByNameAndDeviceID is a map of all virtual device contexts in the Netbox's inventory indexed by their name and device ID.
 	VirtualDeviceContextsIndexByNameAndDeviceID map[string]map[int]*objects.VirtualDeviceContext
-	// PrefixesIndexByPrefix is a map of all prefixes in the Netbox's inventory, indexed by their prefix
+	// PrefixesIndexByPrefix is a map of all prefixes in the Netbox's inventory, that are not assigned to any VRF,
+	// indexed by their prefix
 	PrefixesIndexByPrefix map[string]*objects.Prefix
+	// PrefixesIndexByVRFIDAndPrefix is a map of all prefixes in the Netbox's inventory, that are assigned to a VRF,
+	// indexed by their VRF ID and prefix. Same prefix can exist in multiple VRFs.
+	PrefixesIndexByVRFIDAndPrefix map[int]map[string]*objects.Prefix
+	// PrefixesWithNextHop is a set of prefixes (indexed by their VRF ID and prefix), whose
+	// next hop was already synced in this run.
+	PrefixesWithNextHop map[string]bool
+	// VRFsIndexByName is a map of all VRFs in the Netbox's inventory, indexed by their name.
+	VRFsIndexByName map[string]*objects.VRF
+	// IPRangesIndexByAddresses is a map of all ip ranges in the Netbox's inventory, that are not assigned
+	// to any VRF, indexed by their start and end address without mask (e.g. "10.0.0.10-10.0.0.20").
+	IPRangesIndexByAddresses map[string]*objects.IPRange
+	// IPRangesIndexByVRFIDAndAddresses is a map of all ip ranges in the Netbox's inventory, that are
+	// assigned to a VRF, indexed by their VRF ID and start and end address without mask.
+	IPRangesIndexByVRFIDAndAddresses map[int]map[string]*objects.IPRange
 	// VlanGroupsIndexByName is a map of all VlanGroups in the Netbox's inventory, indexed by their name
 	VlanGroupsIndexByName map[string]*objects.VlanGroup
 	// VlansIndexByVlanGroupIDAndVID is a map of all vlans in the Netbox's inventory, indexed by their VlanGroup and vid.
@@ -72,33 +94,106 @@ type NetboxInventory struct {
 	VMsIndexByNameAndClusterID map[string]map[int]*objects.VM
 	// VirtualMachineInterfacesIndexByVMAndName is a map of all virtual machine interfaces in the inventory, indexed by their's virtual machine id and their name
 	VMInterfacesIndexByVMIdAndName map[int]map[string]*objects.VMInterface
-	// IPAdressesIndexByAddress is a map of all IP addresses in the inventory, indexed by their address
+	// IPAdressesIndexByAddress is a map of all IP addresses in the inventory, that are not assigned to any VRF,
+	// indexed by their address
 	IPAdressesIndexByAddress map[string]*objects.IPAddress
+	// IPAddressesIndexByVRFIDAndAddress is a map of all IP addresses in the inventory, that are assigned to a VRF,
+	// indexed by their VRF ID and address. Same address can exist in multiple VRFs.
+	IPAddressesIndexByVRFIDAndAddress map[int]map[string]*objects.IPAddress
+	// MACAddressesIndexByMACAndAssignedObject is a map of all mac addresses in the inventory, indexed by their
+	// mac address, assigned object type and assigned object id. Unassigned mac addresses (e.g. collected from arp
+	// tables) are indexed with empty assigned object type and assigned object id 0.
+	MACAddressesIndexByMACAndAssignedObject map[string]map[objects.AssignedObjectType]map[int]*objects.MACAddress
+	// FHRPGroupsIndexByName is a map of all FHRP groups in the inventory, indexed by their name.
+	FHRPGroupsIndexByName map[string]*objects.FHRPGroup
+	// FHRPGroupAssignmentsIndexByGroupIDAndInterface is a map of all FHRP group assignments in the inventory,
+	// indexed by their group id, interface type and interface id.
+	FHRPGroupAssignmentsIndexByGroupIDAndInterface map[int]map[objects.AssignedObjectType]map[int]*objects.FHRPGroupAssignment
+	// IKEProposalsIndexByName is a map of all IKE proposals in the inventory, indexed by their name.
+	IKEProposalsIndexByName map[string]*objects.IKEProposal
+	// IKEPoliciesIndexByName is a map of all IKE policies in the inventory, indexed by their name.
+	IKEPoliciesIndexByName map[string]*objects.IKEPolicy
+	// IPSecProposalsIndexByName is a map of all IPSec proposals in the inventory, indexed by their name.
+	IPSecProposalsIndexByName map[string]*objects.IPSecProposal
+	// IPSecPoliciesIndexByName is a map of all IPSec policies in the inventory, indexed by their name.
+	IPSecPoliciesIndexByName map[string]*objects.IPSecPolicy
+	// IPSecProfilesIndexByName is a map of all IPSec profiles in the inventory, indexed by their name.
+	IPSecProfilesIndexByName map[string]*objects.IPSecProfile
+	// TunnelsIndexByName is a map of all tunnels in the inventory, indexed by their name.
+	TunnelsIndexByName map[string]*objects.Tunnel
+	// TunnelTerminationsIndexByTermination is a map of all tunnel terminations in the inventory,
+	// indexed by their termination type and termination id.
+	TunnelTerminationsIndexByTermination map[objects.AssignedObjectType]map[int]*objects.TunnelTermination
+	// RIRsIndexByName is a map of all RIRs in the inventory, indexed by their name.
+	RIRsIndexByName map[string]*objects.RIR
+	// ASNsIndexByASN is a map of all ASNs in the inventory, indexed by their autonomous system number.
+	ASNsIndexByASN map[int64]*objects.ASN
+	// ModuleTypesIndexByModel is a map of all module types in the inventory, indexed by their model.
+	ModuleTypesIndexByModel map[string]*objects.ModuleType
+	// ModuleBaysIndexByDeviceIDAndName is a map of all module bays in the inventory, indexed by their device id and name.
+	ModuleBaysIndexByDeviceIDAndName map[int]map[string]*objects.ModuleBay
+	// ModulesIndexByModuleBayID is a map of all modules in the inventory, indexed by id of the module bay they are installed in.
+	ModulesIndexByModuleBayID map[int]*objects.Module
+	// InventoryItemsIndexByDeviceIDAndName is a map of all inventory items in the inventory, indexed by their device id and name.
+	InventoryItemsIndexByDeviceIDAndName map[int]map[string]*objects.InventoryItem
+	// WirelessLANsIndexBySSID is a map of all wireless lans in the inventory, indexed by their ssid.
+	WirelessLANsIndexBySSID map[string]*objects.WirelessLAN
+	// BGPSessionsIndexByName is a map of all bgp sessions of netbox-bgp plugin in the inventory,
+	// indexed by their name. It is empty if the plugin is not installed.
+	BGPSessionsIndexByName map[string]*objects.BGPSession
+	// BGPPluginInstalled is true if netbox-bgp plugin is installed on the netbox instance.
+	BGPPluginInstalled bool
+	// SiteASNsSynced is a map of site IDs to set of asn IDs, that were assigned to the site
+	// in this run. Other asns managed by netbox-ssot are unassigned in DeleteOrphans.
+	SiteASNsSynced map[int]map[int]bool
 
 	// We also store locks for all objects, so inventory can be updated by multiple parallel goroutines
-	TenantsLock            sync.Mutex
-	TagsLock               sync.Mutex
-	SitesLock              sync.Mutex
-	ContactRolesLock       sync.Mutex
-	ContactGroupsLock      sync.Mutex
-	ContactsLock           sync.Mutex
-	ContactAssignmentsLock sync.Mutex
-	CustomFieldsLock       sync.Mutex
-	ClusterGroupsLock      sync.Mutex
-	ClusterTypesLock       sync.Mutex
-	ClustersLock           sync.Mutex
-	DeviceRolesLock        sync.Mutex
-	ManufacturersLock      sync.Mutex
-	DeviceTypesLock        sync.Mutex
-	PlatformsLock          sync.Mutex
-	DevicesLock            sync.Mutex
-	VlanGroupsLock         sync.Mutex
-	VlansLock              sync.Mutex
-	InterfacesLock         sync.Mutex
-	VMsLock                sync.Mutex
-	VMInterfacesLock       sync.Mutex
-	IPAddressesLock        sync.Mutex
-	PrefixesLock           sync.Mutex
+	TenantsLock              sync.Mutex
+	TagsLock                 sync.Mutex
+	SitesLock                sync.Mutex
+	RegionsLock              sync.Mutex
+	LocationsLock            sync.Mutex
+	ContactRolesLock         sync.Mutex
+	ContactGroupsLock        sync.Mutex
+	ContactsLock             sync.Mutex
+	ContactAssignmentsLock   sync.Mutex
+	CustomFieldsLock         sync.Mutex
+	ClusterGroupsLock        sync.Mutex
+	ClusterTypesLock         sync.Mutex
+	ClustersLock             sync.Mutex
+	DeviceRolesLock          sync.Mutex
+	ManufacturersLock        sync.Mutex
+	DeviceTypesLock          sync.Mutex
+	PlatformsLock            sync.Mutex
+	DevicesLock              sync.Mutex
+	VirtualChassisLock       sync.Mutex
+	VlanGroupsLock           sync.Mutex
+	VlansLock                sync.Mutex
+	InterfacesLock           sync.Mutex
+	VMsLock                  sync.Mutex
+	VMInterfacesLock         sync.Mutex
+	IPAddressesLock          sync.Mutex
+	PrefixesLock             sync.Mutex
+	VRFsLock                 sync.Mutex
+	IPRangesLock             sync.Mutex
+	MACAddressesLock         sync.Mutex
+	FHRPGroupsLock           sync.Mutex
+	FHRPGroupAssignmentsLock sync.Mutex
+	IKEProposalsLock         sync.Mutex
+	IKEPoliciesLock          sync.Mutex
+	IPSecProposalsLock       sync.Mutex
+	IPSecPoliciesLock        sync.Mutex
+	IPSecProfilesLock        sync.Mutex
+	TunnelsLock              sync.Mutex
+	TunnelTerminationsLock   sync.Mutex
+	RIRsLock                 sync.Mutex
+	ASNsLock                 sync.Mutex
+	ModuleTypesLock          sync.Mutex
+	ModuleBaysLock           sync.Mutex
+	ModulesLock              sync.Mutex
+	InventoryItemsLock       sync.Mutex
+	WirelessLANsLock         sync.Mutex
+	BGPSessionsLock          sync.Mutex
 
 	// Orphan manager is a map of objectAPIPath to a set of managed ids for that object type.
 	//
@@ -147,24 +242,49 @@ func NewNetboxInventory(ctx context.Context, logger *logger.Logger, nbConfig *pa
 	}
 	// Starts with 0 for easier integration with for loops
 	orphanObjectPriority := map[int]string{
-		0:  constants.VlanGroupsAPIPath,
-		1:  constants.PrefixesAPIPath,
-		2:  constants.VlansAPIPath,
-		3:  constants.IPAddressesAPIPath,
-		4:  constants.VirtualDeviceContextsAPIPath,
-		5:  constants.InterfacesAPIPath,
-		6:  constants.VMInterfacesAPIPath,
-		7:  constants.VirtualMachinesAPIPath,
-		8:  constants.DevicesAPIPath,
-		9:  constants.PlatformsAPIPath,
-		10: constants.DeviceTypesAPIPath,
-		11: constants.ManufacturersAPIPath,
-		12: constants.DeviceRolesAPIPath,
-		13: constants.ClustersAPIPath,
-		14: constants.ClusterTypesAPIPath,
-		15: constants.ClusterGroupsAPIPath,
-		16: constants.ContactAssignmentsAPIPath,
-		17: constants.ContactsAPIPath,
+		0:  constants.BGPSessionsAPIPath,
+		1:  constants.TunnelTerminationsAPIPath,
+		2:  constants.TunnelsAPIPath,
+		3:  constants.IPSecProfilesAPIPath,
+		4:  constants.IPSecPoliciesAPIPath,
+		5:  constants.IPSecProposalsAPIPath,
+		6:  constants.IKEPoliciesAPIPath,
+		7:  constants.IKEProposalsAPIPath,
+		8:  constants.WirelessLANsAPIPath,
+		9:  constants.VlanGroupsAPIPath,
+		10: constants.PrefixesAPIPath,
+		11: constants.VlansAPIPath,
+		12: constants.IPAddressesAPIPath,
+		13: constants.IPRangesAPIPath,
+		14: constants.VRFsAPIPath,
+		15: constants.MACAddressesAPIPath,
+		16: constants.FHRPGroupAssignmentsAPIPath,
+		17: constants.FHRPGroupsAPIPath,
+		18: constants.ModulesAPIPath,
+		19: constants.ModuleBaysAPIPath,
+		20: constants.InventoryItemsAPIPath,
+		21: constants.VirtualDeviceContextsAPIPath,
+		22: constants.InterfacesAPIPath,
+		23: constants.VMInterfacesAPIPath,
+		24: constants.VirtualMachinesAPIPath,
+		25: constants.DevicesAPIPath,
+		26: constants.VirtualChassisAPIPath,
+		27: constants.PlatformsAPIPath,
+		28: constants.ModuleTypesAPIPath,
+		29: constants.DeviceTypesAPIPath,
+		30: constants.ManufacturersAPIPath,
+		31: constants.DeviceRolesAPIPath,
+		32: constants.ClustersAPIPath,
+		33: constants.ClusterTypesAPIPath,
+		34: constants.ClusterGroupsAPIPath,
+		35: constants.ContactAssignmentsAPIPath,
+		36: constants.ContactsAPIPath,
+		37: constants.LocationsAPIPath,
+		38: constants.SitesAPIPath,
+		39: constants.RegionsAPIPath,
+		40: constants.ASNsAPIPath,
+		41: constants.RIRsAPIPath,
+		42: constants.TagsAPIPath,
 	}
 	nbi := &NetboxInventory{Ctx: ctx, Logger: logger, NetboxConfig: nbConfig, SourcePriority: sourcePriority, OrphanManager: make(map[string]map[int]bool), OrphanObjectPriority: orphanObjectPriority}
 	return nbi
@@ -177,6 +297,24 @@ func (nbi *NetboxInventory) Init() error {
 	nbi.Logger.Debug(nbi.Ctx, "Initializing Netbox API with baseURL: ", baseURL)
 	nbi.NetboxAPI = service.NewNetboxClient(nbi.Ctx, nbi.Logger, baseURL, nbi.NetboxConfig.APIToken, nbi.NetboxConfig.ValidateCert, nbi.NetboxConfig.Timeout)
 
+	// Netbox version determines the shape of requests and responses for some objects
+	version, err := nbi.NetboxAPI.GetVersion(nbi.Ctx)
+	if err != nil {
+		return fmt.Errorf("get netbox version: %s", err)
+	}
+	if !version.AtLeast(service.MinSupportedVersion) {
+		nbi.Logger.Warningf(nbi.Ctx, "Netbox version %s is not supported. Minimal supported version is %s", version, service.MinSupportedVersion)
+	}
+	nbi.NetboxAPI.Version = version
+	nbi.Logger.Infof(nbi.Ctx, "Connected to netbox version %s", version)
+
+	// Bgp sessions are synced only if netbox-bgp plugin is installed
+	nbi.BGPPluginInstalled, err = nbi.NetboxAPI.HasEndpoint(nbi.Ctx, constants.BGPSessionsAPIPath)
+	if err != nil {
+		nbi.Logger.Warningf(nbi.Ctx, "detect netbox-bgp plugin: %s. Skipping bgp sessions...", err)
+		nbi.BGPPluginInstalled = false
+	}
+
 	// Order matters. TODO: use parallelization in the future, on the init functions that can be parallelized
 	initFunctions := []func(context.Context) error{
 		nbi.InitCustomFields,
@@ -188,18 +326,41 @@ func (nbi *NetboxInventory) Init() error {
 		nbi.InitContacts,
 		nbi.InitContactAssignments,
 		nbi.InitTenants,
+		nbi.InitRegions,
 		nbi.InitSites,
+		nbi.InitLocations,
 		nbi.InitDefaultSite,
 		nbi.InitManufacturers,
 		nbi.InitPlatforms,
 		nbi.InitDevices,
+		nbi.InitVirtualChassis,
 		nbi.InitVirtualDeviceContexts,
+		nbi.InitModuleTypes,
+		nbi.InitModuleBays,
+		nbi.InitModules,
+		nbi.InitInventoryItems,
 		nbi.InitInterfaces,
+		nbi.InitVRFs,
+		nbi.InitIPRanges,
+		nbi.InitRIRs,
+		nbi.InitASNs,
 		nbi.InitIPAddresses,
+		nbi.InitMACAddresses,
+		nbi.InitFHRPGroups,
+		nbi.InitFHRPGroupAssignments,
+		nbi.InitIKEProposals,
+		nbi.InitIKEPolicies,
+		nbi.InitIPSecProposals,
+		nbi.InitIPSecPolicies,
+		nbi.InitIPSecProfiles,
+		nbi.InitTunnels,
+		nbi.InitTunnelTerminations,
+		nbi.InitBGPSessions,
 		nbi.InitVlanGroups,
 		nbi.InitDefaultVlanGroup,
 		nbi.InitPrefixes,
 		nbi.InitVlans,
+		nbi.InitWirelessLANs,
 		nbi.InitDeviceRoles,
 		nbi.InitDeviceTypes,
 		nbi.InitClusterGroups,
@@ -219,3 +380,19 @@ func (nbi *NetboxInventory) Init() error {
 
 	return nil
 }
+
+// ArpContentTypes returns content types of objects, that can be collected from
+// arp tables. Since netbox 4.2 also mac addresses are collected as standalone objects.
+func (nbi *NetboxInventory) ArpContentTypes() []string {
+	contentTypes := []string{constants.ContentTypeIpamIPAddress}
+	if nbi.SupportsMACAddressObjects() {
+		contentTypes = append(contentTypes, constants.ContentTypeDcimMACAddress)
+	}
+	return contentTypes
+}
+
+// SupportsMACAddressObjects returns true if connected netbox supports
+// standalone mac address objects (netbox >= 4.2).
+func (nbi *NetboxInventory) SupportsMACAddressObjects() bool {
+	return nbi.NetboxAPI.Version.AtLeast(service.Version4_2)
+}
@@ -62,14 +62,15 @@ var MockExistingSites = map[string]*objects.Site{
 }
 
 var MockInventory = &NetboxInventory{
-	Logger:             &logger.Logger{Logger: log.New(os.Stdout, "", log.LstdFlags)},
-	TagsIndexByName:    MockExistingTags,
-	TagsLock:           sync.Mutex{},
-	TenantsIndexByName: MockExistingTenants,
-	TenantsLock:        sync.Mutex{},
-	SitesIndexByName:   MockExistingSites,
-	SitesLock:          sync.Mutex{},
-	NetboxAPI:          service.MockNetboxClient,
+	Logger:                               &logger.Logger{Logger: log.New(os.Stdout, "", log.LstdFlags)},
+	TagsIndexByName:                      MockExistingTags,
+	TagsLock:                             sync.Mutex{},
+	TenantsIndexByName:                   MockExistingTenants,
+	TenantsLock:                          sync.Mutex{},
+	SitesIndexByName:                     MockExistingSites,
+	SitesLock:                            sync.Mutex{},
+	InventoryItemsIndexByDeviceIDAndName: map[int]map[string]*objects.InventoryItem{},
+	NetboxAPI:                            service.MockNetboxClient,
 	SsotTag: &objects.Tag{
 		ID:          0,
 		Name:        "netbox-ssot",
@@ -14,6 +14,18 @@ func (c Choice) String() string {
 	return c.Value
 }
 
+// IntChoice represents a choice in a Netbox's choice field, that has integer values
+// (e.g. IKE version or Diffie-Hellman group).
+// This struct is used as an embedded struct in other structs that represent IntChoice fields.
+type IntChoice struct {
+	Value int    `json:"value,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+func (c IntChoice) String() string {
+	return fmt.Sprintf("%d", c.Value)
+}
+
 const (
 	MaxDescriptionLength = 200
 )
@@ -32,6 +32,8 @@ type Site struct {
 	Status *SiteStatus `json:"status,omitempty"`
 	// Tenant of the site
 	Tenant *Tenant `json:"tenant,omitempty"`
+	// Region of the site
+	Region *Region `json:"region,omitempty"`
 
 	// Physical location of the building
 	PhysicalAddress string `json:"physical_address,omitempty"`
@@ -40,6 +42,9 @@ type Site struct {
 	Latitude float64 `json:"latitude,omitempty"`
 	// Longitude of the site.
 	Longitude float64 `json:"longitude,omitempty"`
+
+	// Autonomous system numbers assigned to the site.
+	ASNs []*ASN `json:"asns,omitempty"`
 }
 
 func (s Site) String() string {
@@ -61,25 +66,39 @@ func (p Platform) String() string {
 	return fmt.Sprintf("Platform{Name: %s, Manufacturer: %s}", p.Name, p.Manufacturer)
 }
 
+// Region represents a geographic area (e.g. continent, country or city), that sites belong to.
+// Regions can be nested.
 type Region struct {
 	NetboxObject
 	// Name is the name of the region. This field is required.
 	Name string `json:"name,omitempty"`
 	// Slug is a URL-friendly unique shorthand. This field is required.
 	Slug string `json:"slug,omitempty"`
+	// Parent region of the region.
+	Parent *Region `json:"parent,omitempty"`
+}
+
+func (r Region) String() string {
+	return fmt.Sprintf("Region{Name: %s}", r.Name)
 }
 
 // Location represents a physical location, such as a floor or room in a building.
 type Location struct {
 	NetboxObject
 	// Site is the site to which the location belongs. This field is required.
-	Site *Site
+	Site *Site `json:"site,omitempty"`
+	// Parent location of the location.
+	Parent *Location `json:"parent,omitempty"`
 	// Name is the name of the location. This field is required.
-	Name string
+	Name string `json:"name,omitempty"`
 	// URL-friendly unique shorthand. This field is required.
-	Slug string
+	Slug string `json:"slug,omitempty"`
 	// Status is the status of the location. This field is required.
-	Status *SiteStatus
+	Status *SiteStatus `json:"status,omitempty"`
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("Location{Name: %s, Site: %s}", l.Name, l.Site)
 }
 
 // Manufacturer represents a hardware manufacturer (e.g. Cisco, HP, ...).
@@ -230,12 +249,13 @@ type Device struct {
 	Tenant *Tenant `json:"tenant,omitempty"`
 
 	// Virtual Chassis
+	// VirtualChassis is the virtual chassis (e.g. switch stack or firewall HA pair) this device is member of.
+	VirtualChassis *VirtualChassis `json:"virtual_chassis,omitempty"`
+	// VCPosition is the position in the virtual chassis this device is identified by.
+	VCPosition int `json:"vc_position,omitempty"`
+	// VCPriority is the priority of the device in the virtual chassis (master election).
+	VCPriority int `json:"vc_priority,omitempty"`
 
-	// The position in the virtual chassis this device is identified by
-	// Position
-
-	// The priority of the device in the virtual chassis
-	// Priority
 	// Additional comments.
 	Comments string `json:"comments,omitempty"`
 }
@@ -432,6 +452,8 @@ type Interface struct {
 	MTU int `json:"mtu,omitempty"`
 	// MAC is the mac address of the interface
 	MAC string `json:"mac_address,omitempty"`
+	// PrimaryMACAddress is the primary mac address object of the interface (netbox >= 4.2).
+	PrimaryMACAddress *MACAddress `json:"primary_mac_address,omitempty"`
 
 	// Duplex is the duplex mode of the interface
 	Duplex *InterfaceDuplex `json:"duplex,omitempty"`
@@ -483,3 +505,126 @@ type VirtualDeviceContext struct {
 func (vdc VirtualDeviceContext) String() string {
 	return fmt.Sprintf("VirtualDeviceContext{Name: %s, Device: %s, Status: %s}", vdc.Name, vdc.Device, vdc.Status)
 }
+
+// MACAddress represents a mac address object, which can be assigned to
+// device or vm interface. It is only available on netbox >= 4.2.
+type MACAddress struct {
+	NetboxObject
+	// MAC is the mac address (e.g. 00:11:22:33:44:55). This field is required.
+	MAC string `json:"mac_address,omitempty"`
+	// AssignedObjectType is either a DeviceInterface or a VMInterface.
+	AssignedObjectType AssignedObjectType `json:"assigned_object_type,omitempty"`
+	// ID of the assigned object (either an ID of DeviceInterface or an ID of VMInterface).
+	AssignedObjectID int `json:"assigned_object_id,omitempty"`
+}
+
+func (mac MACAddress) String() string {
+	return fmt.Sprintf("MACAddress{ID: %d, MAC: %s, AssignedObjectType: %s, AssignedObjectID: %d}", mac.ID, mac.MAC, mac.AssignedObjectType, mac.AssignedObjectID)
+}
+
+// VirtualChassis represents a set of devices which share a common control plane
+// (e.g. switch stack or firewall HA pair).
+type VirtualChassis struct {
+	NetboxObject
+	// Name of the virtual chassis. This field is required.
+	Name string `json:"name,omitempty"`
+	// Domain of the virtual chassis (e.g. stack domain or HA group id).
+	Domain string `json:"domain,omitempty"`
+	// Master is the device which controls the virtual chassis. It must be a member of the virtual chassis.
+	Master *Device `json:"master,omitempty"`
+}
+
+func (vc VirtualChassis) String() string {
+	return fmt.Sprintf("VirtualChassis{Name: %s, Domain: %s}", vc.Name, vc.Domain)
+}
+
+type ModuleStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/dcim/choices.py
+var (
+	ModuleStatusOffline         = ModuleStatus{Choice{Value: "offline", Label: "Offline"}}
+	ModuleStatusActive          = ModuleStatus{Choice{Value: "active", Label: "Active"}}
+	ModuleStatusPlanned         = ModuleStatus{Choice{Value: "planned", Label: "Planned"}}
+	ModuleStatusStaged          = ModuleStatus{Choice{Value: "staged", Label: "Staged"}}
+	ModuleStatusFailed          = ModuleStatus{Choice{Value: "failed", Label: "Failed"}}
+	ModuleStatusDecommissioning = ModuleStatus{Choice{Value: "decommissioning", Label: "Decommissioning"}}
+)
+
+// ModuleType represents a type of hardware module (e.g. line card or power supply model).
+type ModuleType struct {
+	NetboxObject
+	// Manufacturer of the module type. This field is required.
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	// Model of the module type. This field is required.
+	Model string `json:"model,omitempty"`
+	// Part number of the module type.
+	PartNumber string `json:"part_number,omitempty"`
+}
+
+func (mt ModuleType) String() string {
+	return fmt.Sprintf("ModuleType{Model: %s}", mt.Model)
+}
+
+// ModuleBay represents a slot of the device, in which a module can be installed.
+type ModuleBay struct {
+	NetboxObject
+	// Device to which the module bay belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Name of the module bay. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label of the module bay.
+	Label string `json:"label,omitempty"`
+	// Position of the module bay within the device.
+	Position string `json:"position,omitempty"`
+}
+
+func (mb ModuleBay) String() string {
+	return fmt.Sprintf("ModuleBay{Name: %s, Device: %s}", mb.Name, mb.Device.Name)
+}
+
+// Module represents a hardware module installed in a module bay of the device.
+type Module struct {
+	NetboxObject
+	// Device in which the module is installed. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Module bay in which the module is installed. This field is required.
+	ModuleBay *ModuleBay `json:"module_bay,omitempty"`
+	// Type of the module. This field is required.
+	ModuleType *ModuleType `json:"module_type,omitempty"`
+	// Status of the module.
+	Status *ModuleStatus `json:"status,omitempty"`
+	// Serial number of the module.
+	Serial string `json:"serial,omitempty"`
+	// Comments about the module.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (m Module) String() string {
+	return fmt.Sprintf("Module{ModuleBay: %s, Serial: %s}", m.ModuleBay.Name, m.Serial)
+}
+
+// InventoryItem represents a hardware component of the device, which is
+// not modeled as a module (e.g. power supply or transceiver).
+type InventoryItem struct {
+	NetboxObject
+	// Device to which the inventory item belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Name of the inventory item. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label of the inventory item.
+	Label string `json:"label,omitempty"`
+	// Manufacturer of the inventory item.
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	// Manufacturer's part id of the inventory item.
+	PartID string `json:"part_id,omitempty"`
+	// Serial number of the inventory item.
+	Serial string `json:"serial,omitempty"`
+	// Discovered is true if the item was discovered automatically.
+	Discovered bool `json:"discovered,omitempty"`
+}
+
+func (ii InventoryItem) String() string {
+	return fmt.Sprintf("InventoryItem{Name: %s, Device: %s}", ii.Name, ii.Device.Name)
+}
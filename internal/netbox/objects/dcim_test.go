@@ -230,3 +230,57 @@ func TestVirtualDeviceContext_String(t *testing.T) {
 		})
 	}
 }
+
+func TestLocation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		l    Location
+		want string
+	}{
+		{
+			name: "Correct string output for location",
+			l: Location{
+				Name: "Floor 1",
+				Slug: "floor-1",
+				Site: &Site{
+					Name: "Test site",
+				},
+			},
+			want: fmt.Sprintf("Location{Name: %s, Site: %s}", "Floor 1", Site{Name: "Test site"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.String(); got != tt.want {
+				t.Errorf("Location.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryItem_String(t *testing.T) {
+	tests := []struct {
+		name string
+		ii   InventoryItem
+		want string
+	}{
+		{
+			name: "Correct string output for inventory item",
+			ii: InventoryItem{
+				Name:   "Switch 1 - Power Supply A",
+				PartID: "PWR-C1-715WAC-P",
+				Device: &Device{
+					Name: "Test device",
+				},
+			},
+			want: "InventoryItem{Name: Switch 1 - Power Supply A, Device: Test device}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ii.String(); got != tt.want {
+				t.Errorf("InventoryItem.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ type AssignedObjectType string
 const (
 	AssignedObjectTypeVMInterface     = "virtualization.vminterface"
 	AssignedObjectTypeDeviceInterface = "dcim.interface"
+	AssignedObjectTypeFHRPGroup       = "ipam.fhrpgroup"
 )
 
 type IPAddress struct {
@@ -49,6 +50,8 @@ type IPAddress struct {
 	DNSName string `json:"dns_name,omitempty"`
 	// Tenancy
 	Tenant *Tenant `json:"tenant,omitempty"`
+	// VRF that this IP address belongs to. Nil means global routing table.
+	Vrf *VRF `json:"vrf,omitempty"`
 
 	// AssignedObjectType is either a DeviceInterface or a VMInterface.
 	AssignedObjectType AssignedObjectType `json:"assigned_object_type,omitempty"`
@@ -113,8 +116,161 @@ func (v Vlan) String() string {
 	return fmt.Sprintf("Vlan{ID: %d, Name: %s, Vid: %d, Status: %s}", v.ID, v.Name, v.Vid, v.Status)
 }
 
+type FHRPGroupProtocol struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/ipam/choices.py#L126
+var (
+	FHRPGroupProtocolVRRP2     = FHRPGroupProtocol{Choice{Value: "vrrp2", Label: "VRRPv2"}}
+	FHRPGroupProtocolVRRP3     = FHRPGroupProtocol{Choice{Value: "vrrp3", Label: "VRRPv3"}}
+	FHRPGroupProtocolCARP      = FHRPGroupProtocol{Choice{Value: "carp", Label: "CARP"}}
+	FHRPGroupProtocolClusterXL = FHRPGroupProtocol{Choice{Value: "clusterxl", Label: "ClusterXL"}}
+	FHRPGroupProtocolHSRP      = FHRPGroupProtocol{Choice{Value: "hsrp", Label: "HSRP"}}
+	FHRPGroupProtocolGLBP      = FHRPGroupProtocol{Choice{Value: "glbp", Label: "GLBP"}}
+	FHRPGroupProtocolOther     = FHRPGroupProtocol{Choice{Value: "other", Label: "Other"}}
+)
+
+// FHRPGroupProtocol2IPAddressRole maps protocol of the FHRP group
+// to the role of the virtual ip addresses in that group.
+var FHRPGroupProtocol2IPAddressRole = map[FHRPGroupProtocol]*IPAddressRole{
+	FHRPGroupProtocolVRRP2: &IPAddressRoleVRRP,
+	FHRPGroupProtocolVRRP3: &IPAddressRoleVRRP,
+	FHRPGroupProtocolCARP:  &IPAddressRoleCARP,
+	FHRPGroupProtocolHSRP:  &IPAddressRoleHSRP,
+	FHRPGroupProtocolGLBP:  &IPAddressRoleGLBP,
+}
+
+type FHRPGroupAuthType struct {
+	Choice
+}
+
+var (
+	FHRPGroupAuthTypePlaintext = FHRPGroupAuthType{Choice{Value: "plaintext", Label: "Plaintext"}}
+	FHRPGroupAuthTypeMD5       = FHRPGroupAuthType{Choice{Value: "md5", Label: "MD5"}}
+)
+
+// FHRPGroup represents a first hop redundancy protocol group (e.g. VRRP or HSRP group).
+type FHRPGroup struct {
+	NetboxObject
+	// Name of the FHRP group.
+	Name string `json:"name,omitempty"`
+	// Protocol of the FHRP group. This field is required.
+	Protocol *FHRPGroupProtocol `json:"protocol,omitempty"`
+	// GroupID is the ID of the group in the protocol (e.g. VRID of VRRP group). This field is required.
+	GroupID int `json:"group_id"`
+	// Authentication type used by the group.
+	AuthType *FHRPGroupAuthType `json:"auth_type,omitempty"`
+	// Authentication key used by the group.
+	AuthKey  string `json:"auth_key,omitempty"`
+	Comments string `json:"comments,omitempty"`
+}
+
+func (fg FHRPGroup) String() string {
+	return fmt.Sprintf("FHRPGroup{ID: %d, Name: %s, Protocol: %s, GroupID: %d}", fg.ID, fg.Name, fg.Protocol, fg.GroupID)
+}
+
+// FHRPGroupAssignment represents an assignment of an interface to the FHRP group.
+type FHRPGroupAssignment struct {
+	NetboxObject
+	// FHRP group the interface is assigned to. This field is required.
+	Group *FHRPGroup `json:"group,omitempty"`
+	// InterfaceType is either a DeviceInterface or a VMInterface. This field is required.
+	InterfaceType AssignedObjectType `json:"interface_type,omitempty"`
+	// ID of the assigned interface. This field is required.
+	InterfaceID int `json:"interface_id,omitempty"`
+	// Priority of the interface in the group (0-255). This field is required.
+	Priority int `json:"priority"`
+}
+
+func (fga FHRPGroupAssignment) String() string {
+	return fmt.Sprintf("FHRPGroupAssignment{ID: %d, Group: %v, InterfaceType: %s, InterfaceID: %d, Priority: %d}", fga.ID, fga.Group, fga.InterfaceType, fga.InterfaceID, fga.Priority)
+}
+
+// VRF represents a virtual routing and forwarding instance, which
+// contains its own routing table and address space.
+type VRF struct {
+	NetboxObject
+	// Name of the VRF. This field is required.
+	Name string `json:"name,omitempty"`
+	// Route distinguisher of the VRF (RFC 4364).
+	RD string `json:"rd,omitempty"`
+	// Tenant that this VRF belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Comments about this VRF.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (v VRF) String() string {
+	return fmt.Sprintf("VRF{ID: %d, Name: %s, RD: %s}", v.ID, v.Name, v.RD)
+}
+
+type IPRangeStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/ipam/choices.py
+var (
+	IPRangeStatusActive     = IPRangeStatus{Choice{Value: "active", Label: "Active"}}
+	IPRangeStatusReserved   = IPRangeStatus{Choice{Value: "reserved", Label: "Reserved"}}
+	IPRangeStatusDeprecated = IPRangeStatus{Choice{Value: "deprecated", Label: "Deprecated"}}
+)
+
+// IPRange represents a range of ip addresses (e.g. dhcp pool), that are
+// not necessarily bound by subnet boundaries.
 type IPRange struct {
 	NetboxObject
+	// First ip address of the range (with mask). This field is required.
+	StartAddress string `json:"start_address,omitempty"`
+	// Last ip address of the range (with mask). This field is required.
+	EndAddress string `json:"end_address,omitempty"`
+	// Status of the ip range (default "active").
+	Status *IPRangeStatus `json:"status,omitempty"`
+	// Tenant that this ip range belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// VRF that this ip range belongs to. Nil means global routing table.
+	Vrf *VRF `json:"vrf,omitempty"`
+	// Treat the range as fully utilized.
+	MarkUtilized bool `json:"mark_utilized,omitempty"`
+	// Comments about this ip range.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (ir IPRange) String() string {
+	return fmt.Sprintf("IPRange{ID: %d, StartAddress: %s, EndAddress: %s}", ir.ID, ir.StartAddress, ir.EndAddress)
+}
+
+// RIR represents a regional internet registry, which is responsible
+// for allocation of ip address space and autonomous system numbers.
+type RIR struct {
+	NetboxObject
+	// Name of the RIR. This field is required.
+	Name string `json:"name,omitempty"`
+	// URL-friendly unique shorthand. This field is required.
+	Slug string `json:"slug,omitempty"`
+	// IP space and ASNs managed by this RIR are considered private.
+	IsPrivate bool `json:"is_private,omitempty"`
+}
+
+func (r RIR) String() string {
+	return fmt.Sprintf("RIR{ID: %d, Name: %s}", r.ID, r.Name)
+}
+
+// ASN represents a 16 or 32 bit autonomous system number.
+type ASN struct {
+	NetboxObject
+	// Autonomous system number. This field is required.
+	ASN int64 `json:"asn,omitempty"`
+	// RIR responsible for the ASN. This field is required.
+	RIR *RIR `json:"rir,omitempty"`
+	// Tenant that this ASN belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Comments about this ASN.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (a ASN) String() string {
+	return fmt.Sprintf("ASN{ID: %d, ASN: %d}", a.ID, a.ASN)
 }
 
 type PrefixStatus struct {
@@ -143,6 +299,8 @@ type Prefix struct {
 
 	// Tenant that this prefix belongs to.
 	Tenant *Tenant `json:"tenant,omitempty"`
+	// VRF that this prefix belongs to. Nil means global routing table.
+	Vrf *VRF `json:"vrf,omitempty"`
 
 	Comments string `json:"comments,omitempty"`
 }
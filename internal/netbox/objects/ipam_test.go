@@ -105,3 +105,29 @@ func TestPrefix_String(t *testing.T) {
 		})
 	}
 }
+
+func TestASN_String(t *testing.T) {
+	tests := []struct {
+		name string
+		asn  ASN
+		want string
+	}{
+		{
+			name: "Test asn correct string",
+			asn: ASN{
+				NetboxObject: NetboxObject{
+					ID: 1,
+				},
+				ASN: 4200000001,
+			},
+			want: "ASN{ID: 1, ASN: 4200000001}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.asn.String(); got != tt.want {
+				t.Errorf("ASN.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package objects
+
+import "fmt"
+
+type BGPSessionStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox-bgp/blob/main/netbox_bgp/choices.py
+var (
+	BGPSessionStatusActive  = BGPSessionStatus{Choice{Value: "active", Label: "Active"}}
+	BGPSessionStatusPlanned = BGPSessionStatus{Choice{Value: "planned", Label: "Planned"}}
+	BGPSessionStatusOffline = BGPSessionStatus{Choice{Value: "offline", Label: "Offline"}}
+	BGPSessionStatusFailed  = BGPSessionStatus{Choice{Value: "failed", Label: "Failed"}}
+)
+
+// BGPSession represents a bgp session between a device and its peer.
+// Sessions are objects of the netbox-bgp plugin, so they are only
+// synced if the plugin is installed.
+type BGPSession struct {
+	NetboxObject
+	// Name of the session. This field is required.
+	Name string `json:"name,omitempty"`
+	// Site of the session.
+	Site *Site `json:"site,omitempty"`
+	// Device on which the session is configured.
+	Device *Device `json:"device,omitempty"`
+	// Local ip address of the session. This field is required.
+	LocalAddress *IPAddress `json:"local_address,omitempty"`
+	// Ip address of the peer. This field is required.
+	RemoteAddress *IPAddress `json:"remote_address,omitempty"`
+	// Local autonomous system number. This field is required.
+	LocalAS *ASN `json:"local_as,omitempty"`
+	// Autonomous system number of the peer. This field is required.
+	RemoteAS *ASN `json:"remote_as,omitempty"`
+	// Status of the session. This field is required.
+	Status *BGPSessionStatus `json:"status,omitempty"`
+	// Tenant that this session belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (bs BGPSession) String() string {
+	return fmt.Sprintf("BGPSession{ID: %d, Name: %s}", bs.ID, bs.Name)
+}
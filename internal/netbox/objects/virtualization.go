@@ -126,6 +126,8 @@ type VMInterface struct {
 	Name string `json:"name,omitempty"`
 	// MAC address of the interface.
 	MACAddress string `json:"mac_address,omitempty"`
+	// PrimaryMACAddress is the primary mac address object of the interface (netbox >= 4.2).
+	PrimaryMACAddress *MACAddress `json:"primary_mac_address,omitempty"`
 	// MTU of the interface.
 	MTU int `json:"mtu,omitempty"`
 	// Enabled is true if interface is enabled, false otherwise.
@@ -0,0 +1,255 @@
+package objects
+
+import "fmt"
+
+type IKEVersion struct {
+	IntChoice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	IKEVersion1 = IKEVersion{IntChoice{Value: 1, Label: "IKEv1"}}
+	IKEVersion2 = IKEVersion{IntChoice{Value: 2, Label: "IKEv2"}}
+)
+
+type IKEMode struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	IKEModeAggressive = IKEMode{Choice{Value: "aggressive", Label: "Aggressive"}}
+	IKEModeMain       = IKEMode{Choice{Value: "main", Label: "Main"}}
+)
+
+type AuthenticationMethod struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	AuthenticationMethodPresharedKeys = AuthenticationMethod{Choice{Value: "preshared-keys", Label: "Pre-shared keys"}}
+	AuthenticationMethodCertificates  = AuthenticationMethod{Choice{Value: "certificates", Label: "Certificates"}}
+	AuthenticationMethodRSASignatures = AuthenticationMethod{Choice{Value: "rsa-signatures", Label: "RSA signatures"}}
+	AuthenticationMethodDSASignatures = AuthenticationMethod{Choice{Value: "dsa-signatures", Label: "DSA signatures"}}
+)
+
+type EncryptionAlgorithm struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	EncryptionAlgorithmAES128CBC = EncryptionAlgorithm{Choice{Value: "aes-128-cbc", Label: "128-bit AES (CBC)"}}
+	EncryptionAlgorithmAES128GCM = EncryptionAlgorithm{Choice{Value: "aes-128-gcm", Label: "128-bit AES (GCM)"}}
+	EncryptionAlgorithmAES192CBC = EncryptionAlgorithm{Choice{Value: "aes-192-cbc", Label: "192-bit AES (CBC)"}}
+	EncryptionAlgorithmAES192GCM = EncryptionAlgorithm{Choice{Value: "aes-192-gcm", Label: "192-bit AES (GCM)"}}
+	EncryptionAlgorithmAES256CBC = EncryptionAlgorithm{Choice{Value: "aes-256-cbc", Label: "256-bit AES (CBC)"}}
+	EncryptionAlgorithmAES256GCM = EncryptionAlgorithm{Choice{Value: "aes-256-gcm", Label: "256-bit AES (GCM)"}}
+	EncryptionAlgorithm3DESCBC   = EncryptionAlgorithm{Choice{Value: "3des-cbc", Label: "3DES"}}
+	EncryptionAlgorithmDESCBC    = EncryptionAlgorithm{Choice{Value: "des-cbc", Label: "DES"}}
+)
+
+type AuthenticationAlgorithm struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	AuthenticationAlgorithmHMACSHA1   = AuthenticationAlgorithm{Choice{Value: "hmac-sha1", Label: "SHA-1 HMAC"}}
+	AuthenticationAlgorithmHMACSHA256 = AuthenticationAlgorithm{Choice{Value: "hmac-sha256", Label: "SHA-256 HMAC"}}
+	AuthenticationAlgorithmHMACSHA384 = AuthenticationAlgorithm{Choice{Value: "hmac-sha384", Label: "SHA-384 HMAC"}}
+	AuthenticationAlgorithmHMACSHA512 = AuthenticationAlgorithm{Choice{Value: "hmac-sha512", Label: "SHA-512 HMAC"}}
+	AuthenticationAlgorithmHMACMD5    = AuthenticationAlgorithm{Choice{Value: "hmac-md5", Label: "MD5 HMAC"}}
+)
+
+// DHGroup represents Diffie-Hellman group used for key exchange.
+type DHGroup struct {
+	IntChoice
+}
+
+// DHGroups maps number of the Diffie-Hellman group to its netbox choice.
+var DHGroups = func() map[int]*DHGroup {
+	groupNumbers := []int{1, 2, 5, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34}
+	groups := make(map[int]*DHGroup, len(groupNumbers))
+	for _, number := range groupNumbers {
+		groups[number] = &DHGroup{IntChoice{Value: number, Label: fmt.Sprintf("Group %d", number)}}
+	}
+	return groups
+}()
+
+// IKEProposal represents a proposal used in phase 1 of IKE negotiation.
+type IKEProposal struct {
+	NetboxObject
+	// Name of the IKE proposal. This field is required.
+	Name string `json:"name,omitempty"`
+	// AuthenticationMethod used by the proposal. This field is required.
+	AuthenticationMethod *AuthenticationMethod `json:"authentication_method,omitempty"`
+	// EncryptionAlgorithm used by the proposal. This field is required.
+	EncryptionAlgorithm *EncryptionAlgorithm `json:"encryption_algorithm,omitempty"`
+	// AuthenticationAlgorithm used by the proposal.
+	AuthenticationAlgorithm *AuthenticationAlgorithm `json:"authentication_algorithm,omitempty"`
+	// Diffie-Hellman group used by the proposal. This field is required.
+	Group *DHGroup `json:"group,omitempty"`
+	// SALifetime is security association lifetime in seconds.
+	SALifetime int `json:"sa_lifetime,omitempty"`
+}
+
+func (ip IKEProposal) String() string {
+	return fmt.Sprintf("IKEProposal{ID: %d, Name: %s}", ip.ID, ip.Name)
+}
+
+// IKEPolicy represents a set of IKE proposals used in phase 1 of IKE negotiation.
+type IKEPolicy struct {
+	NetboxObject
+	// Name of the IKE policy. This field is required.
+	Name string `json:"name,omitempty"`
+	// Version of the IKE protocol. This field is required.
+	Version *IKEVersion `json:"version,omitempty"`
+	// Mode of IKEv1 negotiation.
+	Mode *IKEMode `json:"mode,omitempty"`
+	// Proposals used by the policy.
+	Proposals []*IKEProposal `json:"proposals,omitempty"`
+}
+
+func (ip IKEPolicy) String() string {
+	return fmt.Sprintf("IKEPolicy{ID: %d, Name: %s}", ip.ID, ip.Name)
+}
+
+// IPSecProposal represents a proposal used in phase 2 of IKE negotiation.
+type IPSecProposal struct {
+	NetboxObject
+	// Name of the IPSec proposal. This field is required.
+	Name string `json:"name,omitempty"`
+	// EncryptionAlgorithm used by the proposal.
+	EncryptionAlgorithm *EncryptionAlgorithm `json:"encryption_algorithm,omitempty"`
+	// AuthenticationAlgorithm used by the proposal.
+	AuthenticationAlgorithm *AuthenticationAlgorithm `json:"authentication_algorithm,omitempty"`
+	// SALifetimeSeconds is security association lifetime in seconds.
+	SALifetimeSeconds int `json:"sa_lifetime_seconds,omitempty"`
+	// SALifetimeData is security association lifetime in kilobytes.
+	SALifetimeData int `json:"sa_lifetime_data,omitempty"`
+}
+
+func (ip IPSecProposal) String() string {
+	return fmt.Sprintf("IPSecProposal{ID: %d, Name: %s}", ip.ID, ip.Name)
+}
+
+// IPSecPolicy represents a set of IPSec proposals used in phase 2 of IKE negotiation.
+type IPSecPolicy struct {
+	NetboxObject
+	// Name of the IPSec policy. This field is required.
+	Name string `json:"name,omitempty"`
+	// Proposals used by the policy.
+	Proposals []*IPSecProposal `json:"proposals,omitempty"`
+	// PFSGroup is Diffie-Hellman group used for perfect forward secrecy.
+	PFSGroup *DHGroup `json:"pfs_group,omitempty"`
+}
+
+func (ip IPSecPolicy) String() string {
+	return fmt.Sprintf("IPSecPolicy{ID: %d, Name: %s}", ip.ID, ip.Name)
+}
+
+type IPSecMode struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	IPSecModeESP = IPSecMode{Choice{Value: "esp", Label: "ESP"}}
+	IPSecModeAH  = IPSecMode{Choice{Value: "ah", Label: "AH"}}
+)
+
+// IPSecProfile combines IKE policy and IPSec policy used by the tunnel.
+type IPSecProfile struct {
+	NetboxObject
+	// Name of the IPSec profile. This field is required.
+	Name string `json:"name,omitempty"`
+	// Mode of the IPSec protocol. This field is required.
+	Mode *IPSecMode `json:"mode,omitempty"`
+	// IKEPolicy used in phase 1. This field is required.
+	IKEPolicy *IKEPolicy `json:"ike_policy,omitempty"`
+	// IPSecPolicy used in phase 2. This field is required.
+	IPSecPolicy *IPSecPolicy `json:"ipsec_policy,omitempty"`
+}
+
+func (ip IPSecProfile) String() string {
+	return fmt.Sprintf("IPSecProfile{ID: %d, Name: %s}", ip.ID, ip.Name)
+}
+
+type TunnelStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	TunnelStatusPlanned  = TunnelStatus{Choice{Value: "planned", Label: "Planned"}}
+	TunnelStatusActive   = TunnelStatus{Choice{Value: "active", Label: "Active"}}
+	TunnelStatusDisabled = TunnelStatus{Choice{Value: "disabled", Label: "Disabled"}}
+)
+
+type TunnelEncapsulation struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	TunnelEncapsulationIPSecTransport = TunnelEncapsulation{Choice{Value: "ipsec-transport", Label: "IPsec - Transport"}}
+	TunnelEncapsulationIPSecTunnel    = TunnelEncapsulation{Choice{Value: "ipsec-tunnel", Label: "IPsec - Tunnel"}}
+	TunnelEncapsulationIPIP           = TunnelEncapsulation{Choice{Value: "ip-ip", Label: "IP-in-IP"}}
+	TunnelEncapsulationGRE            = TunnelEncapsulation{Choice{Value: "gre", Label: "GRE"}}
+)
+
+// Tunnel represents a virtual point-to-point connection (e.g. IPSec tunnel).
+type Tunnel struct {
+	NetboxObject
+	// Name of the tunnel. This field is required.
+	Name string `json:"name,omitempty"`
+	// Status of the tunnel. This field is required.
+	Status *TunnelStatus `json:"status,omitempty"`
+	// Encapsulation used by the tunnel. This field is required.
+	Encapsulation *TunnelEncapsulation `json:"encapsulation,omitempty"`
+	// IPSecProfile used by the tunnel.
+	IPSecProfile *IPSecProfile `json:"ipsec_profile,omitempty"`
+	// Tenant of the tunnel.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// TunnelID is numeric identifier of the tunnel (e.g. GRE key).
+	TunnelID int `json:"tunnel_id,omitempty"`
+	// Comments about the tunnel.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (t Tunnel) String() string {
+	return fmt.Sprintf("Tunnel{ID: %d, Name: %s, Status: %s}", t.ID, t.Name, t.Status)
+}
+
+type TunnelTerminationRole struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/vpn/choices.py
+var (
+	TunnelTerminationRolePeer  = TunnelTerminationRole{Choice{Value: "peer", Label: "Peer"}}
+	TunnelTerminationRoleHub   = TunnelTerminationRole{Choice{Value: "hub", Label: "Hub"}}
+	TunnelTerminationRoleSpoke = TunnelTerminationRole{Choice{Value: "spoke", Label: "Spoke"}}
+)
+
+// TunnelTermination represents an endpoint of the tunnel on the interface.
+type TunnelTermination struct {
+	NetboxObject
+	// Tunnel that is terminated. This field is required.
+	Tunnel *Tunnel `json:"tunnel,omitempty"`
+	// Role of the termination in the tunnel. This field is required.
+	Role *TunnelTerminationRole `json:"role,omitempty"`
+	// TerminationType is the type of the object terminating the tunnel
+	// (e.g. dcim.interface). This field is required.
+	TerminationType AssignedObjectType `json:"termination_type,omitempty"`
+	// TerminationID is the ID of the object terminating the tunnel. This field is required.
+	TerminationID int `json:"termination_id,omitempty"`
+	// OutsideIP is the public IP address of the termination.
+	OutsideIP *IPAddress `json:"outside_ip,omitempty"`
+}
+
+func (tt TunnelTermination) String() string {
+	return fmt.Sprintf("TunnelTermination{ID: %d, Tunnel: %v, TerminationType: %s, TerminationID: %d}", tt.ID, tt.Tunnel, tt.TerminationType, tt.TerminationID)
+}
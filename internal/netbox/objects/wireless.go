@@ -0,0 +1,47 @@
+package objects
+
+import "fmt"
+
+type WirelessAuthType struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/wireless/choices.py
+var (
+	WirelessAuthTypeOpen          = WirelessAuthType{Choice{Value: "open", Label: "Open"}}
+	WirelessAuthTypeWEP           = WirelessAuthType{Choice{Value: "wep", Label: "WEP"}}
+	WirelessAuthTypeWPAPersonal   = WirelessAuthType{Choice{Value: "wpa-personal", Label: "WPA Personal (PSK)"}}
+	WirelessAuthTypeWPAEnterprise = WirelessAuthType{Choice{Value: "wpa-enterprise", Label: "WPA Enterprise"}}
+)
+
+type WirelessAuthCipher struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v3.7.8/netbox/wireless/choices.py
+var (
+	WirelessAuthCipherAuto = WirelessAuthCipher{Choice{Value: "auto", Label: "Auto"}}
+	WirelessAuthCipherTKIP = WirelessAuthCipher{Choice{Value: "tkip", Label: "TKIP"}}
+	WirelessAuthCipherAES  = WirelessAuthCipher{Choice{Value: "aes", Label: "AES"}}
+)
+
+// WirelessLAN represents a wireless network (SSID) broadcasted by access points.
+type WirelessLAN struct {
+	NetboxObject
+	// SSID of the wireless lan. This field is required.
+	SSID string `json:"ssid,omitempty"`
+	// Vlan to which the wireless clients are bridged.
+	Vlan *Vlan `json:"vlan,omitempty"`
+	// Tenant that this wireless lan belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Authentication type of the wireless lan.
+	AuthType *WirelessAuthType `json:"auth_type,omitempty"`
+	// Authentication cipher of the wireless lan.
+	AuthCipher *WirelessAuthCipher `json:"auth_cipher,omitempty"`
+	// Comments about the wireless lan.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (wl WirelessLAN) String() string {
+	return fmt.Sprintf("WirelessLAN{ID: %d, SSID: %s}", wl.ID, wl.SSID)
+}
@@ -19,6 +19,9 @@ type NetboxClient struct {
 	APIToken   string
 	Timeout    int // in seconds
 	MaxRetires int
+	// Version of the netbox instance. It determines the shape of
+	// requests and responses for some objects (see compatRules).
+	Version Version
 }
 
 const (
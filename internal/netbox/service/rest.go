@@ -36,6 +36,8 @@ var type2path = map[reflect.Type]string{
 	reflect.TypeOf((*objects.DeviceType)(nil)).Elem():           constants.DeviceTypesAPIPath,
 	reflect.TypeOf((*objects.Interface)(nil)).Elem():            constants.InterfacesAPIPath,
 	reflect.TypeOf((*objects.Site)(nil)).Elem():                 constants.SitesAPIPath,
+	reflect.TypeOf((*objects.Region)(nil)).Elem():               constants.RegionsAPIPath,
+	reflect.TypeOf((*objects.Location)(nil)).Elem():             constants.LocationsAPIPath,
 	reflect.TypeOf((*objects.Manufacturer)(nil)).Elem():         constants.ManufacturersAPIPath,
 	reflect.TypeOf((*objects.Platform)(nil)).Elem():             constants.PlatformsAPIPath,
 	reflect.TypeOf((*objects.Tenant)(nil)).Elem():               constants.TenantsAPIPath,
@@ -46,6 +48,27 @@ var type2path = map[reflect.Type]string{
 	reflect.TypeOf((*objects.Tag)(nil)).Elem():                  constants.TagsAPIPath,
 	reflect.TypeOf((*objects.ContactAssignment)(nil)).Elem():    constants.ContactAssignmentsAPIPath,
 	reflect.TypeOf((*objects.Prefix)(nil)).Elem():               constants.PrefixesAPIPath,
+	reflect.TypeOf((*objects.VRF)(nil)).Elem():                  constants.VRFsAPIPath,
+	reflect.TypeOf((*objects.IPRange)(nil)).Elem():              constants.IPRangesAPIPath,
+	reflect.TypeOf((*objects.ASN)(nil)).Elem():                  constants.ASNsAPIPath,
+	reflect.TypeOf((*objects.RIR)(nil)).Elem():                  constants.RIRsAPIPath,
+	reflect.TypeOf((*objects.ModuleType)(nil)).Elem():           constants.ModuleTypesAPIPath,
+	reflect.TypeOf((*objects.ModuleBay)(nil)).Elem():            constants.ModuleBaysAPIPath,
+	reflect.TypeOf((*objects.Module)(nil)).Elem():               constants.ModulesAPIPath,
+	reflect.TypeOf((*objects.InventoryItem)(nil)).Elem():        constants.InventoryItemsAPIPath,
+	reflect.TypeOf((*objects.WirelessLAN)(nil)).Elem():          constants.WirelessLANsAPIPath,
+	reflect.TypeOf((*objects.BGPSession)(nil)).Elem():           constants.BGPSessionsAPIPath,
+	reflect.TypeOf((*objects.MACAddress)(nil)).Elem():           constants.MACAddressesAPIPath,
+	reflect.TypeOf((*objects.VirtualChassis)(nil)).Elem():       constants.VirtualChassisAPIPath,
+	reflect.TypeOf((*objects.FHRPGroup)(nil)).Elem():            constants.FHRPGroupsAPIPath,
+	reflect.TypeOf((*objects.FHRPGroupAssignment)(nil)).Elem():  constants.FHRPGroupAssignmentsAPIPath,
+	reflect.TypeOf((*objects.Tunnel)(nil)).Elem():               constants.TunnelsAPIPath,
+	reflect.TypeOf((*objects.TunnelTermination)(nil)).Elem():    constants.TunnelTerminationsAPIPath,
+	reflect.TypeOf((*objects.IKEProposal)(nil)).Elem():          constants.IKEProposalsAPIPath,
+	reflect.TypeOf((*objects.IKEPolicy)(nil)).Elem():            constants.IKEPoliciesAPIPath,
+	reflect.TypeOf((*objects.IPSecProposal)(nil)).Elem():        constants.IPSecProposalsAPIPath,
+	reflect.TypeOf((*objects.IPSecPolicy)(nil)).Elem():          constants.IPSecPoliciesAPIPath,
+	reflect.TypeOf((*objects.IPSecProfile)(nil)).Elem():         constants.IPSecProfilesAPIPath,
 }
 
 // GetAll queries all objects of type T from Netbox's API.
@@ -73,13 +96,19 @@ func GetAll[T any](ctx context.Context, netboxClient *NetboxClient, extraParams
 			return nil, fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Body)
 		}
 
-		var responseObj Response[T]
+		var responseObj Response[json.RawMessage]
 		err = json.Unmarshal(response.Body, &responseObj)
 		if err != nil {
 			return nil, err
 		}
 
-		allResults = append(allResults, responseObj.Results...)
+		for _, rawResult := range responseObj.Results {
+			result, err := unmarshalObject[T](netboxClient, rawResult)
+			if err != nil {
+				return nil, err
+			}
+			allResults = append(allResults, *result)
+		}
 
 		if responseObj.Next == nil {
 			break
@@ -100,6 +129,7 @@ func Patch[T any](ctx context.Context, netboxClient *NetboxClient, objectID int,
 	path = fmt.Sprintf("%s%d/", path, objectID)
 	netboxClient.Logger.Debugf(ctx, "Patching %T with path %s with data: %v", dummy, path, body)
 
+	netboxClient.adaptRequest(reflect.TypeOf(dummy), body)
 	requestBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
@@ -115,14 +145,13 @@ func Patch[T any](ctx context.Context, netboxClient *NetboxClient, objectID int,
 		return nil, fmt.Errorf("unexpected status code: %d: %s", response.StatusCode, response.Body)
 	}
 
-	var objectResponse T
-	err = json.Unmarshal(response.Body, &objectResponse)
+	objectResponse, err := unmarshalObject[T](netboxClient, response.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	netboxClient.Logger.Debugf(ctx, "Successfully patched %T: %v", dummy, objectResponse)
-	return &objectResponse, nil
+	netboxClient.Logger.Debugf(ctx, "Successfully patched %T: %v", dummy, *objectResponse)
+	return objectResponse, nil
 }
 
 // Create func creates the new NetboxObject of type T, with the given api path and body.
@@ -131,7 +160,9 @@ func Create[T any](ctx context.Context, netboxClient *NetboxClient, object *T) (
 	path := type2path[reflect.TypeOf(dummy)]
 	netboxClient.Logger.Debugf(ctx, "Creating %T with path %s with data: %v", dummy, path, object)
 
-	requestBody, err := utils.NetboxJSONMarshal(object)
+	requestMap := utils.StructToNetboxJSONMap(object)
+	netboxClient.adaptRequest(reflect.TypeOf(dummy), requestMap)
+	requestBody, err := json.Marshal(requestMap)
 	if err != nil {
 		return nil, err
 	}
@@ -146,14 +177,29 @@ func Create[T any](ctx context.Context, netboxClient *NetboxClient, object *T) (
 		return nil, fmt.Errorf("unexpected status code: %d: %s", response.StatusCode, response.Body)
 	}
 
-	var objectResponse T
-	err = json.Unmarshal(response.Body, &objectResponse)
+	objectResponse, err := unmarshalObject[T](netboxClient, response.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	netboxClient.Logger.Debugf(ctx, "Successfully created %T: %v", dummy, objectResponse)
-	return &objectResponse, nil
+	netboxClient.Logger.Debugf(ctx, "Successfully created %T: %v", dummy, *objectResponse)
+	return objectResponse, nil
+}
+
+// unmarshalObject unmarshals raw json object returned by netbox into
+// object of type T. Before unmarshaling, response is transformed
+// into the shape of type T (see compatRules).
+func unmarshalObject[T any](netboxClient *NetboxClient, rawObject []byte) (*T, error) {
+	var object T
+	rawObject, err := netboxClient.adaptResponse(reflect.TypeOf(object), rawObject)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(rawObject, &object)
+	if err != nil {
+		return nil, err
+	}
+	return &object, nil
 }
 
 // Function that deletes object on path objectPath.
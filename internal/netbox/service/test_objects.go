@@ -147,8 +147,42 @@ var (
 	}
 )
 
+// Hardcoded mock api return values for bgp session endpoint of netbox-bgp plugin.
+var (
+	MockBGPSessionsGetResponse = Response[objects.BGPSession]{
+		Count:    0,
+		Next:     nil,
+		Previous: nil,
+		Results:  []objects.BGPSession{},
+	}
+	MockBGPSessionCreateResponse = objects.BGPSession{
+		NetboxObject: objects.NetboxObject{
+			ID: 1,
+		},
+		Name:          "MockBGPSession",
+		LocalAddress:  &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 1}, Address: "10.0.0.1/30"},
+		RemoteAddress: &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 2}, Address: "10.0.0.2/30"}, //nolint:gomnd
+		LocalAS:       &objects.ASN{NetboxObject: objects.NetboxObject{ID: 1}, ASN: 65001},                   //nolint:gomnd
+		RemoteAS:      &objects.ASN{NetboxObject: objects.NetboxObject{ID: 2}, ASN: 65002},                   //nolint:gomnd
+		Status:        &objects.BGPSessionStatusActive,
+	}
+)
+
+// Hardcoded mock api return values for inventory items endpoint.
+var (
+	MockInventoryItemCreateResponse = objects.InventoryItem{
+		NetboxObject: objects.NetboxObject{
+			ID: 1,
+		},
+		Device: &objects.Device{NetboxObject: objects.NetboxObject{ID: 1}, Name: "MockDevice"},
+		Name:   "Switch 1 - Power Supply A",
+		PartID: "PWR-C1-715WAC-P",
+		Serial: "ART1234ABCD",
+	}
+)
+
 const (
-	MockVersionResponseJSON = "{\"django-version\": \"4.2.10\"}"
+	MockVersionResponseJSON = "{\"django-version\": \"4.2.10\", \"netbox-version\": \"3.7.8\"}"
 )
 
 //nolint:gocyclo
@@ -277,6 +311,50 @@ func CreateMockServer() *httptest.Server {
 		}
 	})
 
+	handler.HandleFunc(constants.BGPSessionsAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			bgpSessionsResponseStr, err := json.Marshal(MockBGPSessionsGetResponse)
+			if err != nil {
+				log.Printf("Error marshaling bgp sessions response: %v", err)
+			}
+			_, err = io.WriteString(w, string(bgpSessionsResponseStr))
+			if err != nil {
+				log.Printf("Error writing response")
+			}
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			bgpSessionStr, err := json.Marshal(MockBGPSessionCreateResponse)
+			if err != nil {
+				log.Printf("Error marshaling bgp session create response: %v", err)
+			}
+			_, err = io.WriteString(w, string(bgpSessionStr))
+			if err != nil {
+				log.Printf("Error writing response")
+			}
+		default:
+			log.Printf("Wrong http method: %v", r.Method)
+		}
+	})
+
+	handler.HandleFunc(constants.InventoryItemsAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			inventoryItemStr, err := json.Marshal(MockInventoryItemCreateResponse)
+			if err != nil {
+				log.Printf("Error marshaling inventory item create response: %v", err)
+			}
+			_, err = io.WriteString(w, string(inventoryItemStr))
+			if err != nil {
+				log.Printf("Error writing response")
+			}
+		default:
+			log.Printf("Wrong http method: %v", r.Method)
+		}
+	})
+
 	handler.HandleFunc("/api/read-error", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError) // or any relevant status
 		//nolint:all
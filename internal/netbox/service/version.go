@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+// Version represents netbox's semantic version (e.g. 4.0.3).
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast returns true if version v is equal or newer than version other.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// Netbox versions that introduced breaking changes in the API.
+var (
+	// MinSupportedVersion is the oldest netbox version supported by netbox-ssot.
+	MinSupportedVersion = Version{Major: 3, Minor: 7}
+	// Version4_0 renamed custom field's content_types to object_types.
+	Version4_0 = Version{Major: 4, Minor: 0} //nolint:revive,stylecheck
+	// Version4_1 replaced vlan group's min_vid and max_vid with vid_ranges.
+	Version4_1 = Version{Major: 4, Minor: 1} //nolint:revive,stylecheck
+	// Version4_2 moved mac addresses into standalone objects and replaced
+	// site of prefixes and clusters with scope.
+	Version4_2 = Version{Major: 4, Minor: 2} //nolint:revive,stylecheck
+)
+
+// ParseVersion parses version string returned by netbox's status endpoint.
+// It accepts strings like "3.7.8", "v4.0.3" and "4.2.1-Docker-3.1.0".
+func ParseVersion(versionStr string) (Version, error) {
+	versionStr = strings.TrimPrefix(strings.TrimSpace(versionStr), "v")
+	// Strip suffixes like -dev or -Docker-3.1.0
+	versionStr = strings.SplitN(versionStr, "-", 2)[0] //nolint:gomnd
+	parts := strings.Split(versionStr, ".")
+	if len(parts) < 2 || len(parts) > 3 { //nolint:gomnd
+		return Version{}, fmt.Errorf("invalid netbox version: %s", versionStr)
+	}
+	numbers := make([]int, 3) //nolint:gomnd
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid netbox version %s: %s", versionStr, err)
+		}
+		numbers[i] = number
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Status is the response of netbox's /api/status/ endpoint.
+type Status struct {
+	DjangoVersion string `json:"django-version"`
+	NetboxVersion string `json:"netbox-version"`
+}
+
+// GetVersion queries netbox's status endpoint and returns
+// the version of the running netbox instance.
+func (api *NetboxClient) GetVersion(ctx context.Context) (Version, error) {
+	response, err := api.doRequest(MethodGet, constants.StatusAPIPath, nil)
+	if err != nil {
+		return Version{}, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return Version{}, fmt.Errorf("unexpected status code: %d: %s", response.StatusCode, response.Body)
+	}
+	var status Status
+	err = json.Unmarshal(response.Body, &status)
+	if err != nil {
+		return Version{}, err
+	}
+	version, err := ParseVersion(status.NetboxVersion)
+	if err != nil {
+		return Version{}, err
+	}
+	api.Logger.Debugf(ctx, "Netbox is running version %s", version)
+	return version, nil
+}
+
+// HasEndpoint returns true if netbox serves the api endpoint on path.
+// It is used to detect installed plugins (e.g. netbox-bgp).
+func (api *NetboxClient) HasEndpoint(ctx context.Context, path string) (bool, error) {
+	response, err := api.doRequest(MethodGet, path+"?limit=1", nil)
+	if err != nil {
+		return false, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		api.Logger.Debugf(ctx, "Netbox serves endpoint %s", path)
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code: %d: %s", response.StatusCode, response.Body)
+	}
+}
+
+// compatRule describes how the shape of an object changed in a specific
+// netbox version. Objects are internally always kept in the oldest
+// supported shape (3.7), so requests are transformed from the old shape
+// into the new one, and responses from the new shape back to the old one.
+type compatRule struct {
+	// Since is the first netbox version, that uses the new shape.
+	Since Version
+	// Request transforms old request body into the new shape.
+	Request func(body map[string]interface{})
+	// Response transforms new response body into the old shape.
+	Response func(body map[string]interface{})
+}
+
+// compatRules is a map of rules for each object type, that has a
+// different request/response shape across netbox versions.
+var compatRules = map[reflect.Type][]compatRule{
+	reflect.TypeOf((*objects.CustomField)(nil)).Elem(): {
+		{
+			Since:    Version4_0,
+			Request:  renameField("content_types", "object_types"),
+			Response: renameField("object_types", "content_types"),
+		},
+	},
+	reflect.TypeOf((*objects.VlanGroup)(nil)).Elem(): {
+		{
+			Since:    Version4_1,
+			Request:  vidsToVidRanges,
+			Response: vidRangesToVids,
+		},
+	},
+	reflect.TypeOf((*objects.Interface)(nil)).Elem(): {
+		{
+			Since:   Version4_2,
+			Request: dropFields("mac_address"),
+		},
+	},
+	reflect.TypeOf((*objects.VMInterface)(nil)).Elem(): {
+		{
+			Since:   Version4_2,
+			Request: dropFields("mac_address"),
+		},
+	},
+	reflect.TypeOf((*objects.Prefix)(nil)).Elem(): {
+		{
+			Since:    Version4_2,
+			Request:  siteToScope,
+			Response: scopeToSite,
+		},
+	},
+	reflect.TypeOf((*objects.Cluster)(nil)).Elem(): {
+		{
+			Since:    Version4_2,
+			Request:  siteToScope,
+			Response: scopeToSite,
+		},
+	},
+}
+
+// activeCompatRules returns all compat rules for type t that apply
+// to the netbox version the client is connected to.
+func (api *NetboxClient) activeCompatRules(t reflect.Type) []compatRule {
+	rules := make([]compatRule, 0)
+	for _, rule := range compatRules[t] {
+		if api.Version.AtLeast(rule.Since) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// adaptRequest transforms request body of the object with type t,
+// so it matches the shape expected by the connected netbox version.
+func (api *NetboxClient) adaptRequest(t reflect.Type, body map[string]interface{}) {
+	for _, rule := range api.activeCompatRules(t) {
+		if rule.Request != nil {
+			rule.Request(body)
+		}
+	}
+}
+
+// adaptResponse transforms raw json of the object with type t
+// returned by the connected netbox version back into the shape
+// of the objects package.
+func (api *NetboxClient) adaptResponse(t reflect.Type, rawObject []byte) ([]byte, error) {
+	rules := api.activeCompatRules(t)
+	if len(rules) == 0 {
+		return rawObject, nil
+	}
+	var body map[string]interface{}
+	err := json.Unmarshal(rawObject, &body)
+	if err != nil {
+		return nil, err
+	}
+	// Rules are applied in reverse order, so the newest shape is undone first
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Response != nil {
+			rules[i].Response(body)
+		}
+	}
+	return json.Marshal(body)
+}
+
+// renameField returns a transform function, that renames field oldName to newName.
+func renameField(oldName string, newName string) func(map[string]interface{}) {
+	return func(body map[string]interface{}) {
+		if value, ok := body[oldName]; ok {
+			body[newName] = value
+			delete(body, oldName)
+		}
+	}
+}
+
+// dropFields returns a transform function, that removes given fields from the body.
+func dropFields(fields ...string) func(map[string]interface{}) {
+	return func(body map[string]interface{}) {
+		for _, field := range fields {
+			delete(body, field)
+		}
+	}
+}
+
+// vidsToVidRanges converts min_vid and max_vid into vid_ranges.
+func vidsToVidRanges(body map[string]interface{}) {
+	minVid, hasMin := body["min_vid"]
+	maxVid, hasMax := body["max_vid"]
+	if !hasMin && !hasMax {
+		return
+	}
+	if !hasMin {
+		minVid = 1
+	}
+	if !hasMax {
+		maxVid = constants.MaxVID
+	}
+	body["vid_ranges"] = [][]interface{}{{minVid, maxVid}}
+	delete(body, "min_vid")
+	delete(body, "max_vid")
+}
+
+// vidRangesToVids converts vid_ranges into min_vid and max_vid,
+// where min_vid is the lowest and max_vid the highest vid of all ranges.
+func vidRangesToVids(body map[string]interface{}) {
+	vidRanges, ok := body["vid_ranges"].([]interface{})
+	if !ok {
+		return
+	}
+	delete(body, "vid_ranges")
+	var minVid, maxVid float64
+	for _, vidRange := range vidRanges {
+		bounds, ok := vidRange.([]interface{})
+		if !ok || len(bounds) != 2 { //nolint:gomnd
+			continue
+		}
+		lower, lowerOk := bounds[0].(float64)
+		upper, upperOk := bounds[1].(float64)
+		if !lowerOk || !upperOk {
+			continue
+		}
+		if minVid == 0 || lower < minVid {
+			minVid = lower
+		}
+		if upper > maxVid {
+			maxVid = upper
+		}
+	}
+	if minVid != 0 {
+		body["min_vid"] = minVid
+		body["max_vid"] = maxVid
+	}
+}
+
+// siteToScope converts site field into scope_type and scope_id.
+func siteToScope(body map[string]interface{}) {
+	site, ok := body["site"]
+	if !ok {
+		return
+	}
+	delete(body, "site")
+	if site == nil {
+		body["scope_type"] = nil
+		body["scope_id"] = nil
+		return
+	}
+	body["scope_type"] = constants.ContentTypeDcimSite
+	body["scope_id"] = site
+}
+
+// scopeToSite converts scope of type dcim.site back into site field.
+func scopeToSite(body map[string]interface{}) {
+	if scopeType, ok := body["scope_type"].(string); ok && scopeType == constants.ContentTypeDcimSite {
+		body["site"] = body["scope"]
+	}
+	delete(body, "scope_type")
+	delete(body, "scope_id")
+	delete(body, "scope")
+}
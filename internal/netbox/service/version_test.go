@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		versionStr string
+		want       Version
+		wantErr    bool
+	}{
+		{
+			name:       "Full version",
+			versionStr: "3.7.8",
+			want:       Version{Major: 3, Minor: 7, Patch: 8},
+		},
+		{
+			name:       "Version with v prefix",
+			versionStr: "v4.0.3",
+			want:       Version{Major: 4, Minor: 0, Patch: 3},
+		},
+		{
+			name:       "Version with docker suffix",
+			versionStr: "4.2.1-Docker-3.1.0",
+			want:       Version{Major: 4, Minor: 2, Patch: 1},
+		},
+		{
+			name:       "Version without patch",
+			versionStr: "4.1",
+			want:       Version{Major: 4, Minor: 1},
+		},
+		{
+			name:       "Empty version",
+			versionStr: "",
+			wantErr:    true,
+		},
+		{
+			name:       "Invalid version",
+			versionStr: "4.x.1",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.versionStr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	tests := []struct {
+		name    string
+		version Version
+		other   Version
+		want    bool
+	}{
+		{
+			name:    "Same version",
+			version: Version{Major: 4, Minor: 0, Patch: 3},
+			other:   Version{Major: 4, Minor: 0, Patch: 3},
+			want:    true,
+		},
+		{
+			name:    "Newer major version",
+			version: Version{Major: 4, Minor: 0},
+			other:   Version{Major: 3, Minor: 7, Patch: 8},
+			want:    true,
+		},
+		{
+			name:    "Older minor version",
+			version: Version{Major: 4, Minor: 1, Patch: 5},
+			other:   Version4_2,
+			want:    false,
+		},
+		{
+			name:    "Older patch version",
+			version: Version{Major: 3, Minor: 7, Patch: 1},
+			other:   Version{Major: 3, Minor: 7, Patch: 2},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.version.AtLeast(tt.other); got != tt.want {
+				t.Errorf("Version.AtLeast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxClient_GetVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		netboxClient *NetboxClient
+		want         Version
+		wantErr      bool
+	}{
+		{
+			name:         "Get version from mock server",
+			netboxClient: MockNetboxClient,
+			want:         Version{Major: 3, Minor: 7, Patch: 8},
+		},
+		{
+			name:         "Client failure",
+			netboxClient: FailingMockNetboxClient,
+			wantErr:      true,
+		},
+	}
+	mockServer := CreateMockServer()
+	defer mockServer.Close()
+	MockNetboxClient.BaseURL = mockServer.URL
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), constants.CtxSourceKey, "test")
+			got, err := tt.netboxClient.GetVersion(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxClient.GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetboxClient.GetVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxClient_adaptRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    Version
+		objectType reflect.Type
+		body       map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			name:       "Custom field on netbox 3.7",
+			version:    Version{Major: 3, Minor: 7},
+			objectType: reflect.TypeOf(objects.CustomField{}),
+			body:       map[string]interface{}{"content_types": []string{constants.ContentTypeDcimDevice}},
+			want:       map[string]interface{}{"content_types": []string{constants.ContentTypeDcimDevice}},
+		},
+		{
+			name:       "Custom field on netbox 4.0",
+			version:    Version4_0,
+			objectType: reflect.TypeOf(objects.CustomField{}),
+			body:       map[string]interface{}{"content_types": []string{constants.ContentTypeDcimDevice}},
+			want:       map[string]interface{}{"object_types": []string{constants.ContentTypeDcimDevice}},
+		},
+		{
+			name:       "Vlan group on netbox 4.1",
+			version:    Version4_1,
+			objectType: reflect.TypeOf(objects.VlanGroup{}),
+			body:       map[string]interface{}{"name": "test", "min_vid": 1, "max_vid": 100},
+			want:       map[string]interface{}{"name": "test", "vid_ranges": [][]interface{}{{1, 100}}},
+		},
+		{
+			name:       "Interface on netbox 4.2",
+			version:    Version4_2,
+			objectType: reflect.TypeOf(objects.Interface{}),
+			body:       map[string]interface{}{"name": "eth0", "mac_address": "00:11:22:33:44:55"},
+			want:       map[string]interface{}{"name": "eth0"},
+		},
+		{
+			name:       "Prefix on netbox 4.2",
+			version:    Version4_2,
+			objectType: reflect.TypeOf(objects.Prefix{}),
+			body:       map[string]interface{}{"prefix": "10.0.0.0/24", "site": 1},
+			want:       map[string]interface{}{"prefix": "10.0.0.0/24", "scope_type": constants.ContentTypeDcimSite, "scope_id": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &NetboxClient{Version: tt.version}
+			api.adaptRequest(tt.objectType, tt.body)
+			if !reflect.DeepEqual(tt.body, tt.want) {
+				t.Errorf("NetboxClient.adaptRequest() = %v, want %v", tt.body, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxClient_adaptResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    Version
+		objectType reflect.Type
+		rawObject  string
+		want       map[string]interface{}
+	}{
+		{
+			name:       "Custom field on netbox 4.0",
+			version:    Version4_0,
+			objectType: reflect.TypeOf(objects.CustomField{}),
+			rawObject:  `{"id": 1, "object_types": ["dcim.device"]}`,
+			want:       map[string]interface{}{"id": 1., "content_types": []interface{}{"dcim.device"}},
+		},
+		{
+			name:       "Vlan group on netbox 4.1",
+			version:    Version4_1,
+			objectType: reflect.TypeOf(objects.VlanGroup{}),
+			rawObject:  `{"id": 1, "vid_ranges": [[10, 20], [1, 5]]}`,
+			want:       map[string]interface{}{"id": 1., "min_vid": 1., "max_vid": 20.},
+		},
+		{
+			name:       "Cluster on netbox 4.2",
+			version:    Version4_2,
+			objectType: reflect.TypeOf(objects.Cluster{}),
+			rawObject:  `{"id": 1, "scope_type": "dcim.site", "scope_id": 2, "scope": {"id": 2, "name": "site"}}`,
+			want:       map[string]interface{}{"id": 1., "site": map[string]interface{}{"id": 2., "name": "site"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &NetboxClient{Version: tt.version}
+			got, err := api.adaptResponse(tt.objectType, []byte(tt.rawObject))
+			if err != nil {
+				t.Errorf("NetboxClient.adaptResponse() error = %v", err)
+				return
+			}
+			var gotMap map[string]interface{}
+			if err := json.Unmarshal(got, &gotMap); err != nil {
+				t.Errorf("unmarshal adapted response: %v", err)
+				return
+			}
+			if !reflect.DeepEqual(gotMap, tt.want) {
+				t.Errorf("NetboxClient.adaptResponse() = %v, want %v", gotMap, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetboxClient_HasEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		netboxClient *NetboxClient
+		path         string
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:         "Installed plugin endpoint",
+			netboxClient: MockNetboxClient,
+			path:         constants.BGPSessionsAPIPath,
+			want:         true,
+		},
+		{
+			name:         "Missing plugin endpoint",
+			netboxClient: MockNetboxClient,
+			path:         "/api/plugins/missing/",
+			want:         false,
+		},
+		{
+			name:         "Client failure",
+			netboxClient: FailingMockNetboxClient,
+			path:         constants.BGPSessionsAPIPath,
+			wantErr:      true,
+		},
+	}
+	mockServer := CreateMockServer()
+	defer mockServer.Close()
+	MockNetboxClient.BaseURL = mockServer.URL
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), constants.CtxSourceKey, "test")
+			got, err := tt.netboxClient.HasEndpoint(ctx, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NetboxClient.HasEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NetboxClient.HasEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
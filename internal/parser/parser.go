@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
@@ -36,6 +37,14 @@ const (
 	HTTPS HTTPScheme = "https"
 )
 
+// Netbox objects, to which hardware modules of devices are synced.
+type ModuleObjectType string
+
+const (
+	ModuleObjectModules        ModuleObjectType = "modules"
+	ModuleObjectInventoryItems ModuleObjectType = "inventoryItems"
+)
+
 type NetboxConfig struct {
 	APIToken string `yaml:"apiToken"`
 	Hostname string `yaml:"hostname"`
@@ -56,21 +65,50 @@ func (n NetboxConfig) String() string {
 }
 
 type SourceConfig struct {
-	Name            string               `yaml:"name"`
-	Type            constants.SourceType `yaml:"type"`
-	HTTPScheme      HTTPScheme           `yaml:"httpScheme"`
-	Hostname        string               `yaml:"hostname"`
-	Port            int                  `yaml:"port"`
-	Username        string               `yaml:"username"`
-	Password        string               `yaml:"password"`
-	APIToken        string               `yaml:"apiToken"`
-	ValidateCert    bool                 `yaml:"validateCert"`
-	Tag             string               `yaml:"tag"`
-	TagColor        string               `yaml:"tagColor"`
-	IgnoredSubnets  []string             `yaml:"ignoredSubnets"`
-	InterfaceFilter string               `yaml:"interfaceFilter"`
-	CollectArpData  bool                 `yaml:"collectArpData"`
-	ArpDataLifeSpan int                  `yaml:"arpDataLifeSpan"`
+	Name               string               `yaml:"name"`
+	Type               constants.SourceType `yaml:"type"`
+	HTTPScheme         HTTPScheme           `yaml:"httpScheme"`
+	Hostname           string               `yaml:"hostname"`
+	FailoverHostnames  []string             `yaml:"failoverHostnames"`
+	Port               int                  `yaml:"port"`
+	Username           string               `yaml:"username"`
+	Password           string               `yaml:"password"`
+	APIToken           string               `yaml:"apiToken"`
+	ValidateCert       bool                 `yaml:"validateCert"`
+	Tag                string               `yaml:"tag"`
+	TagColor           string               `yaml:"tagColor"`
+	IgnoredSubnets     []string             `yaml:"ignoredSubnets"`
+	InterfaceFilter    string               `yaml:"interfaceFilter"`
+	CollectArpData     bool                 `yaml:"collectArpData"`
+	ArpDataLifeSpan    int                  `yaml:"arpDataLifeSpan"`
+	VMTagPrefix        string               `yaml:"vmTagPrefix"`
+	VMTagAllowlist     []string             `yaml:"vmTagAllowlist"`
+	IncrementalSync    bool                 `yaml:"incrementalSync"`
+	FullResyncInterval int                  `yaml:"fullResyncInterval"`
+	SyncTemplates      bool                 `yaml:"syncTemplates"`
+	SyncContentLibrary bool                 `yaml:"syncContentLibrary"`
+
+	// Firewall address objects matching addressObjectFilter (regex on the
+	// object's name) or having one of addressObjectTags are synced to netbox.
+	AddressObjectFilter string   `yaml:"addressObjectFilter"`
+	AddressObjectTags   []string `yaml:"addressObjectTags"`
+	// Compiled addressObjectFilter, set when the config is validated.
+	AddressObjectFilterRegex *regexp.Regexp `yaml:"-"`
+
+	// Connected and static routes of firewall's routing tables are synced as prefixes,
+	// when collectRoutes is enabled. Bgp routes are synced only with collectBgpRoutes,
+	// and if max prefix length of their address family is set, only up to that length.
+	CollectRoutes               bool `yaml:"collectRoutes"`
+	CollectBgpRoutes            bool `yaml:"collectBgpRoutes"`
+	BgpRouteMaxPrefixLengthIPv4 int  `yaml:"bgpRouteMaxPrefixLengthIPv4"`
+	BgpRouteMaxPrefixLengthIPv6 int  `yaml:"bgpRouteMaxPrefixLengthIPv6"`
+
+	// Bgp configuration (local as, peers and sessions) is synced, when collectBgp is enabled.
+	CollectBGP bool `yaml:"collectBgp"`
+
+	// Hardware modules of devices (line cards, power supplies, transceivers) are
+	// synced either as netbox modules or as inventory items. Empty disables the sync.
+	SyncModulesAs ModuleObjectType `yaml:"syncModulesAs"`
 
 	// Relations
 	HostSiteRelations      []string `yaml:"hostSiteRelations"`
@@ -81,12 +119,22 @@ type SourceConfig struct {
 	VlanGroupRelations     []string `yaml:"vlanGroupRelations"`
 	VlanTenantRelations    []string `yaml:"vlanTenantRelations"`
 
-	// Vmware specific relations
-	CustomFieldMappings []string `yaml:"customFieldMappings"`
+	// Vmware specific relations, tag relations are also used by ovirt affinity labels
+	CustomFieldMappings     []string `yaml:"customFieldMappings"`
+	TagCategoryCustomFields []string `yaml:"tagCategoryCustomFields"`
+	TagSiteRelations        []string `yaml:"tagSiteRelations"`
+	TagTenantRelations      []string `yaml:"tagTenantRelations"`
+	TagRoleRelations        []string `yaml:"tagRoleRelations"`
 }
 
+// Firewall source types, that support syncing of address objects and routing tables.
+var firewallSourceTypes = []constants.SourceType{constants.PaloAlto, constants.Panorama, constants.Fortigate, constants.FortiManager}
+
+// Source types, from which bgp configuration can be collected.
+var bgpSourceTypes = append(slices.Clone(firewallSourceTypes), constants.Dnac)
+
 func (s SourceConfig) String() string {
-	return fmt.Sprintf("SourceConfig{Name: %s, Type: %s, HTTPScheme: %s, Hostname: %s, Port: %d, Username: %s, Password: %s, PermittedSubnets: %v, ValidateCert: %t, Tag: %s, TagColor: %s, HostSiteRelations: %v, ClusterSiteRelations: %v, clusterTenantRelations: %v, HostTenantRelations: %v, VmTenantRelations %v, VlanGroupRelations: %v, VlanTenantRelations: %v}", s.Name, s.Type, s.HTTPScheme, s.Hostname, s.Port, s.Username, s.Password, s.IgnoredSubnets, s.ValidateCert, s.Tag, s.TagColor, s.HostSiteRelations, s.ClusterSiteRelations, s.ClusterTenantRelations, s.HostTenantRelations, s.VMTenantRelations, s.VlanGroupRelations, s.VlanTenantRelations)
+	return fmt.Sprintf("SourceConfig{Name: %s, Type: %s, HTTPScheme: %s, Hostname: %s, FailoverHostnames: %v, Port: %d, Username: %s, Password: %s, PermittedSubnets: %v, ValidateCert: %t, Tag: %s, TagColor: %s, HostSiteRelations: %v, ClusterSiteRelations: %v, clusterTenantRelations: %v, HostTenantRelations: %v, VmTenantRelations %v, VlanGroupRelations: %v, VlanTenantRelations: %v}", s.Name, s.Type, s.HTTPScheme, s.Hostname, s.FailoverHostnames, s.Port, s.Username, s.Password, s.IgnoredSubnets, s.ValidateCert, s.Tag, s.TagColor, s.HostSiteRelations, s.ClusterSiteRelations, s.ClusterTenantRelations, s.HostTenantRelations, s.VMTenantRelations, s.VlanGroupRelations, s.VlanTenantRelations)
 }
 
 // Validates the user's config for limits and required fields.
@@ -192,6 +240,8 @@ func validateSourceConfig(config *Config) error {
 		case constants.PaloAlto:
 		case constants.Fortigate:
 		case constants.FMC:
+		case constants.Panorama:
+		case constants.FortiManager:
 		default:
 			return fmt.Errorf("%s.type is not valid", externalSourceStr)
 		}
@@ -203,6 +253,54 @@ func validateSourceConfig(config *Config) error {
 		if externalSource.Hostname == "" {
 			return fmt.Errorf("%s.hostname: cannot be empty", externalSourceStr)
 		}
+		if len(externalSource.FailoverHostnames) > 0 && externalSource.Type != constants.Proxmox {
+			return fmt.Errorf("%s.failoverHostnames: only supported for %s", externalSourceStr, constants.Proxmox)
+		}
+		for _, failoverHostname := range externalSource.FailoverHostnames {
+			if failoverHostname == "" {
+				return fmt.Errorf("%s.failoverHostnames: cannot contain empty hostname", externalSourceStr)
+			}
+		}
+		if externalSource.IncrementalSync && externalSource.Type != constants.Vmware {
+			return fmt.Errorf("%s.incrementalSync: only supported for %s", externalSourceStr, constants.Vmware)
+		}
+		if externalSource.SyncTemplates && externalSource.Type != constants.Vmware {
+			return fmt.Errorf("%s.syncTemplates: only supported for %s", externalSourceStr, constants.Vmware)
+		}
+		if externalSource.SyncContentLibrary && externalSource.Type != constants.Vmware {
+			return fmt.Errorf("%s.syncContentLibrary: only supported for %s", externalSourceStr, constants.Vmware)
+		}
+		usesAddressObjects := externalSource.AddressObjectFilter != "" || len(externalSource.AddressObjectTags) > 0
+		if usesAddressObjects && !slices.Contains(firewallSourceTypes, externalSource.Type) {
+			return fmt.Errorf("%s.addressObjectFilter: only supported for %v", externalSourceStr, firewallSourceTypes)
+		}
+		if externalSource.CollectRoutes && !slices.Contains(firewallSourceTypes, externalSource.Type) {
+			return fmt.Errorf("%s.collectRoutes: only supported for %v", externalSourceStr, firewallSourceTypes)
+		}
+		if externalSource.CollectBGP && !slices.Contains(bgpSourceTypes, externalSource.Type) {
+			return fmt.Errorf("%s.collectBgp: only supported for %v", externalSourceStr, bgpSourceTypes)
+		}
+		if externalSource.CollectBgpRoutes && !externalSource.CollectRoutes {
+			return fmt.Errorf("%s.collectBgpRoutes: requires collectRoutes", externalSourceStr)
+		}
+		if externalSource.BgpRouteMaxPrefixLengthIPv4 < 0 || externalSource.BgpRouteMaxPrefixLengthIPv4 > 32 {
+			return fmt.Errorf("%s.bgpRouteMaxPrefixLengthIPv4: must be between 0 and 32. Is %d", externalSourceStr, externalSource.BgpRouteMaxPrefixLengthIPv4)
+		}
+		if externalSource.BgpRouteMaxPrefixLengthIPv6 < 0 || externalSource.BgpRouteMaxPrefixLengthIPv6 > 128 {
+			return fmt.Errorf("%s.bgpRouteMaxPrefixLengthIPv6: must be between 0 and 128. Is %d", externalSourceStr, externalSource.BgpRouteMaxPrefixLengthIPv6)
+		}
+		if externalSource.SyncModulesAs != "" && externalSource.Type != constants.Dnac {
+			return fmt.Errorf("%s.syncModulesAs: only supported for %s", externalSourceStr, constants.Dnac)
+		}
+		if externalSource.SyncModulesAs != "" && externalSource.SyncModulesAs != ModuleObjectModules && externalSource.SyncModulesAs != ModuleObjectInventoryItems {
+			return fmt.Errorf("%s.syncModulesAs: must be either %s or %s. Is %s", externalSourceStr, ModuleObjectModules, ModuleObjectInventoryItems, externalSource.SyncModulesAs)
+		}
+		if externalSource.FullResyncInterval < 0 {
+			return fmt.Errorf("%s.fullResyncInterval: cannot be negative", externalSourceStr)
+		}
+		if externalSource.IncrementalSync && externalSource.FullResyncInterval == 0 {
+			externalSource.FullResyncInterval = constants.DefaultFullResyncInterval
+		}
 		if externalSource.Port == 0 {
 			externalSource.Port = 443
 		} else if externalSource.Port < 0 || externalSource.Port > 65535 {
@@ -211,10 +309,12 @@ func validateSourceConfig(config *Config) error {
 		if externalSource.APIToken == "" && externalSource.Type == constants.Fortigate {
 			return fmt.Errorf("%s.apiToken is required for %s", externalSourceStr, constants.Fortigate)
 		}
-		if externalSource.Username == "" && externalSource.Type != constants.Fortigate {
+		// Fortimanager can authenticate either with api token or with username and password
+		usesAPIToken := externalSource.Type == constants.Fortigate || (externalSource.Type == constants.FortiManager && externalSource.APIToken != "")
+		if externalSource.Username == "" && !usesAPIToken {
 			return fmt.Errorf("%s.username: cannot be empty", externalSourceStr)
 		}
-		if externalSource.Password == "" && externalSource.Type != constants.Fortigate {
+		if externalSource.Password == "" && !usesAPIToken {
 			return fmt.Errorf("%s.password: cannot be empty", externalSourceStr)
 		}
 		if externalSource.Tag == "" {
@@ -239,6 +339,12 @@ func validateSourceConfig(config *Config) error {
 		if err != nil {
 			return fmt.Errorf("%s.interfaceFilter: wrong format: %s", externalSourceStr, err)
 		}
+		if externalSource.AddressObjectFilter != "" {
+			externalSource.AddressObjectFilterRegex, err = regexp.Compile(externalSource.AddressObjectFilter)
+			if err != nil {
+				return fmt.Errorf("%s.addressObjectFilter: wrong format: %s", externalSourceStr, err)
+			}
+		}
 	}
 	return nil
 }
@@ -286,6 +392,24 @@ func validateSourceConfigRelations(externalSource *SourceConfig, externalSourceS
 			return fmt.Errorf("%s.vlanTenantRelations: %v", externalSourceStr, err)
 		}
 	}
+	if len(externalSource.TagSiteRelations) > 0 {
+		err := utils.ValidateRegexRelations(externalSource.TagSiteRelations)
+		if err != nil {
+			return fmt.Errorf("%s.tagSiteRelations: %v", externalSourceStr, err)
+		}
+	}
+	if len(externalSource.TagTenantRelations) > 0 {
+		err := utils.ValidateRegexRelations(externalSource.TagTenantRelations)
+		if err != nil {
+			return fmt.Errorf("%s.tagTenantRelations: %v", externalSourceStr, err)
+		}
+	}
+	if len(externalSource.TagRoleRelations) > 0 {
+		err := utils.ValidateRegexRelations(externalSource.TagRoleRelations)
+		if err != nil {
+			return fmt.Errorf("%s.tagRoleRelations: %v", externalSourceStr, err)
+		}
+	}
 	return nil
 }
 
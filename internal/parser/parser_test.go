@@ -176,6 +176,18 @@ func TestParseConfigInvalidConfigs(t *testing.T) {
 		{filename: "invalid_config29.yaml", expectedErr: "yaml: unmarshal errors:\n  line 2: cannot unmarshal !!str `2dasf` into int"},
 		{filename: "invalid_config30.yaml", expectedErr: "source[fortigate].apiToken is required for fortigate"},
 		{filename: "invalid_config31.yaml", expectedErr: "netbox.arpDataLifeSpan: cannot be negative"},
+		{filename: "invalid_config32.yaml", expectedErr: "source[ovirt].failoverHostnames: only supported for proxmox"},
+		{filename: "invalid_config33.yaml", expectedErr: "source[ovirt].incrementalSync: only supported for vmware"},
+		{filename: "invalid_config34.yaml", expectedErr: "source[vmware].fullResyncInterval: cannot be negative"},
+		{filename: "invalid_config35.yaml", expectedErr: "source[proxmox].syncTemplates: only supported for vmware"},
+		{filename: "invalid_config36.yaml", expectedErr: "source[fortimanager].username: cannot be empty"},
+		{filename: "invalid_config37.yaml", expectedErr: "source[vmware].addressObjectFilter: only supported for [paloalto panorama fortigate fortimanager]"},
+		{filename: "invalid_config38.yaml", expectedErr: "source[paloalto].collectBgpRoutes: requires collectRoutes"},
+		{filename: "invalid_config39.yaml", expectedErr: "source[vmware].syncModulesAs: only supported for dnac"},
+		{filename: "invalid_config40.yaml", expectedErr: "source[dnac].syncModulesAs: must be either modules or inventoryItems. Is chassis"},
+		{filename: "invalid_config41.yaml", expectedErr: "source[paloalto].addressObjectFilter: wrong format: error parsing regexp: missing closing ): `(srv-`"},
+		{filename: "invalid_config42.yaml", expectedErr: "source[paloalto].bgpRouteMaxPrefixLengthIPv4: must be between 0 and 32. Is 64"},
+		{filename: "invalid_config43.yaml", expectedErr: "source[vmware].collectBgp: only supported for [paloalto panorama fortigate fortimanager dnac]"},
 		{filename: "invalid_config1111.yaml", expectedErr: "open testdata/invalid_config1111.yaml: no such file or directory"},
 	}
 
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// ArpEntry represents an entry of the arp table collected from a device.
+type ArpEntry struct {
+	IP string
+	// MAC is empty, when the device doesn't report mac of the entry.
+	MAC string
+}
+
+// NormalizeMAC returns mac address in uppercase colon separated format
+// (e.g. "0050.56b3.1234" -> "00:50:56:B3:12:34"). For invalid and
+// all zero mac addresses (used for incomplete arp entries) it returns "".
+func NormalizeMAC(mac string) string {
+	hwAddr, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil || bytes.Equal(hwAddr, make(net.HardwareAddr, len(hwAddr))) {
+		return ""
+	}
+	return strings.ToUpper(hwAddr.String())
+}
+
+// validArpEntries returns arp entries with valid ip addresses, with normalized
+// mac addresses. Entries without mac are kept, because their ip is still valid,
+// but incomplete entries (invalid or all zero mac) and duplicated ips are skipped.
+func validArpEntries(entries []ArpEntry) []ArpEntry {
+	validEntries := make([]ArpEntry, 0, len(entries))
+	seenIPs := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		ip := net.ParseIP(strings.TrimSpace(entry.IP))
+		mac := NormalizeMAC(entry.MAC)
+		if ip == nil || (mac == "" && strings.TrimSpace(entry.MAC) != "") || seenIPs[ip.String()] {
+			continue
+		}
+		seenIPs[ip.String()] = true
+		validEntries = append(validEntries, ArpEntry{IP: ip.String(), MAC: mac})
+	}
+	return validEntries
+}
+
+// SyncArpTable syncs ip addresses (and mac addresses, when supported by netbox) from
+// the arp table of a device to the vrf of the arp table. All objects are tagged with arp tag and marked as arp
+// entries, so they are removed only after they are not seen for ArpDataLifeSpan,
+// and they don't override ip addresses collected from interfaces.
+func SyncArpTable(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, entries []ArpEntry, vrf *objects.VRF) error {
+	// We tag it with special tag for arp data.
+	arpTag, err := nbi.AddTag(ctx, &objects.Tag{
+		Name:        constants.DefaultArpTagName,
+		Slug:        utils.Slugify(constants.DefaultArpTagName),
+		Color:       constants.DefaultArpTagColor,
+		Description: "tag created for ip's collected from arp table",
+	})
+	if err != nil {
+		return fmt.Errorf("add tag: %s", err)
+	}
+	// We create custom field for tracking when was arp entry last seen
+	_, err = nbi.AddCustomField(ctx, &objects.CustomField{
+		Name:                  constants.CustomFieldArpIPLastSeenName,
+		Label:                 constants.CustomFieldArpIPLastSeenLabel,
+		Type:                  objects.CustomFieldTypeText,
+		FilterLogic:           objects.FilterLogicLoose,
+		CustomFieldUIVisible:  &objects.CustomFieldUIVisibleAlways,
+		CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
+		DisplayWeight:         objects.DisplayWeightDefault,
+		Description:           constants.CustomFieldArpIPLastSeenDescription,
+		SearchWeight:          objects.SearchWeightDefault,
+		ContentTypes:          nbi.ArpContentTypes(),
+	})
+	if err != nil {
+		return fmt.Errorf("add custom field: %s", err)
+	}
+	// Since netbox 4.2 we can also store mac addresses of arp entries
+	syncArpMACs := nbi.SupportsMACAddressObjects()
+	arpTags := append(append([]*objects.Tag{}, sourceTags...), arpTag)
+	for _, entry := range validArpEntries(entries) {
+		lastSeen := time.Now().Format(constants.ArpLastSeenFormat)
+		defaultMask := 32
+		if strings.Contains(entry.IP, ":") {
+			defaultMask = 128
+		}
+		_, err = nbi.AddIPAddress(ctx, &objects.IPAddress{
+			NetboxObject: objects.NetboxObject{
+				Tags:        arpTags,
+				Description: fmt.Sprintf("IP collected from %s arp table", sourceName),
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldArpIPLastSeenName: lastSeen,
+					constants.CustomFieldArpEntryName:      true,
+				},
+			},
+			Address: fmt.Sprintf("%s/%d", entry.IP, defaultMask),
+			DNSName: utils.ReverseLookup(entry.IP),
+			Status:  &objects.IPAddressStatusActive,
+			Vrf:     vrf,
+		})
+		if err != nil {
+			return fmt.Errorf("add arp ip address: %s", err)
+		}
+		if syncArpMACs && entry.MAC != "" {
+			_, err = nbi.AddMACAddress(ctx, &objects.MACAddress{
+				NetboxObject: objects.NetboxObject{
+					Tags:        arpTags,
+					Description: fmt.Sprintf("MAC of %s collected from %s arp table", entry.IP, sourceName),
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldArpIPLastSeenName: lastSeen,
+						constants.CustomFieldArpEntryName:      true,
+					},
+				},
+				MAC: entry.MAC,
+			})
+			if err != nil {
+				return fmt.Errorf("add arp mac address: %s", err)
+			}
+		}
+	}
+	return nil
+}
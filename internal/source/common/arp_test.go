@@ -0,0 +1,48 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := []struct {
+		name string
+		mac  string
+		want string
+	}{
+		{name: "Colon separated", mac: "00:50:56:b3:12:34", want: "00:50:56:B3:12:34"},
+		{name: "Cisco dotted format", mac: "0050.56b3.1234", want: "00:50:56:B3:12:34"},
+		{name: "Hyphen separated", mac: "00-50-56-B3-12-34", want: "00:50:56:B3:12:34"},
+		{name: "Incomplete entry", mac: "(incomplete)", want: ""},
+		{name: "All zero mac", mac: "00:00:00:00:00:00", want: ""},
+		{name: "Empty mac", mac: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMAC(tt.mac); got != tt.want {
+				t.Errorf("NormalizeMAC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidArpEntries(t *testing.T) {
+	entries := []ArpEntry{
+		{IP: "10.0.0.1", MAC: "0050.56b3.1234"},
+		{IP: "10.0.0.1", MAC: "00:50:56:b3:12:35"},
+		{IP: "10.0.0.2", MAC: "(incomplete)"},
+		{IP: "10.0.0.3", MAC: "00:00:00:00:00:00"},
+		{IP: "10.0.0.4", MAC: ""},
+		{IP: "invalid", MAC: "00:50:56:b3:12:36"},
+		{IP: "2001:db8::1", MAC: "00:50:56:b3:12:37"},
+	}
+	want := []ArpEntry{
+		{IP: "10.0.0.1", MAC: "00:50:56:B3:12:34"},
+		{IP: "10.0.0.4", MAC: ""},
+		{IP: "2001:db8::1", MAC: "00:50:56:B3:12:37"},
+	}
+	if got := validArpEntries(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("validArpEntries() = %v, want %v", got, want)
+	}
+}
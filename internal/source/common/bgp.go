@@ -0,0 +1,292 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// BGPPeer represents a configured neighbor of the bgp process.
+type BGPPeer struct {
+	// Ip address of the peer.
+	RemoteAddress string
+	// Autonomous system number of the peer.
+	RemoteAS int64
+	// Local ip address used for the session. If empty, router id of the
+	// bgp process is used instead.
+	LocalAddress string
+}
+
+// BGPConfig represents configuration of a bgp process running on the device.
+type BGPConfig struct {
+	// Local autonomous system number of the bgp process.
+	LocalAS int64
+	// Router id of the bgp process.
+	RouterID string
+	// Peers configured within the bgp process.
+	Peers []BGPPeer
+	// Vrf in which bgp process is running, nil for the global routing table.
+	VRF *objects.VRF
+}
+
+// Bounds of asn ranges reserved for private use (RFC 6996).
+const (
+	privateASNMin   = 64512
+	privateASNMax   = 65534
+	privateASN4Min  = 4200000000
+	privateASN4Max  = 4294967294
+	maxASN          = 4294967295
+	asdotMultiplier = 65536
+)
+
+// ParseASN parses autonomous system number in asplain (e.g. "65546")
+// or asdot (e.g. "1.10") notation.
+func ParseASN(asn string) (int64, error) {
+	asn = strings.TrimSpace(asn)
+	var asnNumber int64
+	if high, low, ok := strings.Cut(asn, "."); ok {
+		highNumber, err := strconv.ParseInt(high, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse asdot asn %s: %s", asn, err)
+		}
+		lowNumber, err := strconv.ParseInt(low, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse asdot asn %s: %s", asn, err)
+		}
+		if highNumber >= asdotMultiplier || lowNumber >= asdotMultiplier {
+			return 0, fmt.Errorf("asdot asn %s is out of range", asn)
+		}
+		asnNumber = highNumber*asdotMultiplier + lowNumber
+	} else {
+		var err error
+		asnNumber, err = strconv.ParseInt(asn, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse asn %s: %s", asn, err)
+		}
+	}
+	if asnNumber <= 0 || asnNumber > maxASN {
+		return 0, fmt.Errorf("asn %s is out of range", asn)
+	}
+	return asnNumber, nil
+}
+
+// isPrivateASN returns true if asn is reserved for private use (RFC 6996).
+func isPrivateASN(asn int64) bool {
+	return (asn >= privateASNMin && asn <= privateASNMax) || (asn >= privateASN4Min && asn <= privateASN4Max)
+}
+
+// bgpCustomFieldValues returns values of the bgp local as and bgp peers
+// custom fields for the given bgp configs (e.g. "65001" and "10.0.0.2 (AS65002)").
+// Values are sorted, so they don't depend on the order reported by the source.
+func bgpCustomFieldValues(bgpConfigs []BGPConfig) (string, string) {
+	localASNs := make([]string, 0, len(bgpConfigs))
+	peers := make([]string, 0)
+	for _, bgpConfig := range bgpConfigs {
+		localASN := strconv.FormatInt(bgpConfig.LocalAS, 10)
+		if !slices.Contains(localASNs, localASN) {
+			localASNs = append(localASNs, localASN)
+		}
+		for _, peer := range bgpConfig.Peers {
+			peerDescription := fmt.Sprintf("%s (AS%d)", peer.RemoteAddress, peer.RemoteAS)
+			if bgpConfig.VRF != nil {
+				peerDescription = fmt.Sprintf("%s (AS%d, %s)", peer.RemoteAddress, peer.RemoteAS, bgpConfig.VRF.Name)
+			}
+			peers = append(peers, peerDescription)
+		}
+	}
+	slices.Sort(localASNs)
+	slices.Sort(peers)
+	return strings.Join(localASNs, ", "), strings.Join(peers, ", ")
+}
+
+// SyncBGP syncs bgp configuration of the device. Local and peer autonomous systems
+// are synced as asns, and local asns are assigned to the device's site. Local as and
+// list of peers are stored in custom fields of the device, which are cleared if bgp
+// is not configured. If netbox-bgp plugin is installed, bgp session is also created
+// for each peer.
+func SyncBGP(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, device *objects.Device, bgpConfigs []BGPConfig) error {
+	bgpConfigs = slices.DeleteFunc(slices.Clone(bgpConfigs), func(bgpConfig BGPConfig) bool { return bgpConfig.LocalAS == 0 })
+	if err := addBGPCustomFields(ctx, nbi); err != nil {
+		return err
+	}
+
+	for _, bgpConfig := range bgpConfigs {
+		localASN, err := addASN(ctx, nbi, sourceTags, bgpConfig.LocalAS, device.Tenant)
+		if err != nil {
+			return err
+		}
+		if _, err := nbi.AddSiteASN(ctx, device.Site, localASN); err != nil {
+			return fmt.Errorf("assign asn %d to site %s: %s", localASN.ASN, device.Site.Name, err)
+		}
+		var host2IPAddress map[string]*objects.IPAddress
+		if nbi.BGPPluginInstalled {
+			host2IPAddress = nbi.GetIPAddressesByHosts(bgpConfig.VRF, bgpSessionHosts(bgpConfig))
+		}
+		for _, peer := range bgpConfig.Peers {
+			remoteASN, err := addASN(ctx, nbi, sourceTags, peer.RemoteAS, nil)
+			if err != nil {
+				return err
+			}
+			if !nbi.BGPPluginInstalled {
+				continue
+			}
+			if err := syncBGPSession(ctx, nbi, sourceTags, device, bgpConfig, peer, localASN, remoteASN, host2IPAddress); err != nil {
+				return err
+			}
+		}
+	}
+
+	var localASNs, peers interface{}
+	if len(bgpConfigs) > 0 {
+		localASNs, peers = bgpCustomFieldValues(bgpConfigs)
+	}
+	_, err := nbi.AddDeviceCustomFields(ctx, device, map[string]interface{}{
+		constants.CustomFieldBGPLocalASName: localASNs,
+		constants.CustomFieldBGPPeersName:   peers,
+	})
+	if err != nil {
+		return fmt.Errorf("add bgp custom fields of device %s: %s", device.Name, err)
+	}
+	return nil
+}
+
+// addBGPCustomFields adds custom fields for storing bgp configuration of devices.
+func addBGPCustomFields(ctx context.Context, nbi *inventory.NetboxInventory) error {
+	customFields := []*objects.CustomField{
+		{
+			Name:        constants.CustomFieldBGPLocalASName,
+			Label:       constants.CustomFieldBGPLocalASLabel,
+			Description: constants.CustomFieldBGPLocalASDescription,
+		},
+		{
+			Name:        constants.CustomFieldBGPPeersName,
+			Label:       constants.CustomFieldBGPPeersLabel,
+			Description: constants.CustomFieldBGPPeersDescription,
+		},
+	}
+	for _, customField := range customFields {
+		customField.Type = objects.CustomFieldTypeText
+		customField.FilterLogic = objects.FilterLogicLoose
+		customField.CustomFieldUIVisible = &objects.CustomFieldUIVisibleAlways
+		customField.CustomFieldUIEditable = &objects.CustomFieldUIEditableYes
+		customField.DisplayWeight = objects.DisplayWeightDefault
+		customField.SearchWeight = objects.SearchWeightDefault
+		customField.ContentTypes = []string{constants.ContentTypeDcimDevice}
+		if _, err := nbi.AddCustomField(ctx, customField); err != nil {
+			return fmt.Errorf("add custom field %s: %s", customField.Name, err)
+		}
+	}
+	return nil
+}
+
+// addASN adds asn to netbox. Since rir of the asn is unknown, private
+// asns are assigned to the RFC 6996 rir and the rest to the public asns rir.
+func addASN(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, asn int64, tenant *objects.Tenant) (*objects.ASN, error) {
+	rir := &objects.RIR{
+		NetboxObject: objects.NetboxObject{Tags: sourceTags},
+		Name:         constants.DefaultPublicASNRIRName,
+		Slug:         utils.Slugify(constants.DefaultPublicASNRIRName),
+	}
+	if isPrivateASN(asn) {
+		rir.Name = constants.DefaultPrivateASNRIRName
+		rir.Slug = utils.Slugify(constants.DefaultPrivateASNRIRName)
+		rir.IsPrivate = true
+	}
+	nbRIR, err := nbi.AddRIR(ctx, rir)
+	if err != nil {
+		return nil, fmt.Errorf("add rir %s: %s", rir.Name, err)
+	}
+	nbASN, err := nbi.AddASN(ctx, &objects.ASN{
+		NetboxObject: objects.NetboxObject{Tags: sourceTags},
+		ASN:          asn,
+		RIR:          nbRIR,
+		Tenant:       tenant,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add asn %d: %s", asn, err)
+	}
+	return nbASN, nil
+}
+
+// bgpSessionHosts returns local and remote addresses of all peers of the bgp config.
+func bgpSessionHosts(bgpConfig BGPConfig) []string {
+	hosts := make([]string, 0, 2*len(bgpConfig.Peers)) //nolint:gomnd
+	for _, peer := range bgpConfig.Peers {
+		hosts = append(hosts, peerLocalAddress(bgpConfig, peer), peer.RemoteAddress)
+	}
+	return hosts
+}
+
+// peerLocalAddress returns local address of the session with the peer.
+func peerLocalAddress(bgpConfig BGPConfig, peer BGPPeer) string {
+	if peer.LocalAddress == "" {
+		return bgpConfig.RouterID
+	}
+	return peer.LocalAddress
+}
+
+// syncBGPSession creates bgp session between the device and the peer.
+// Local address of the session has to be already synced (e.g. as interface ip),
+// otherwise the session is skipped. Remote address is created if it doesn't exist.
+// Already synced addresses are looked up in host2IPAddress.
+func syncBGPSession(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, device *objects.Device, bgpConfig BGPConfig, peer BGPPeer, localASN, remoteASN *objects.ASN, host2IPAddress map[string]*objects.IPAddress) error {
+	localAddress := peerLocalAddress(bgpConfig, peer)
+	nbLocalAddress, ok := host2IPAddress[localAddress]
+	if !ok {
+		nbi.Logger.Debugf(ctx, "local address %s of bgp peer %s on device %s is not synced. Skipping bgp session...", localAddress, peer.RemoteAddress, device.Name)
+		return nil
+	}
+
+	nbRemoteAddress, ok := host2IPAddress[peer.RemoteAddress]
+	if !ok {
+		remoteAddr, err := netip.ParseAddr(peer.RemoteAddress)
+		if err != nil {
+			return fmt.Errorf("parse address of bgp peer %s: %s", peer.RemoteAddress, err)
+		}
+		nbRemoteAddress, err = nbi.AddIPAddress(ctx, &objects.IPAddress{
+			NetboxObject: objects.NetboxObject{
+				Tags:        sourceTags,
+				Description: fmt.Sprintf("BGP peer of %s", device.Name),
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldArpEntryName: false,
+				},
+			},
+			Address: netip.PrefixFrom(remoteAddr, remoteAddr.BitLen()).String(),
+			Status:  &objects.IPAddressStatusActive,
+			Vrf:     bgpConfig.VRF,
+		})
+		if err != nil {
+			return fmt.Errorf("add address of bgp peer %s: %s", peer.RemoteAddress, err)
+		}
+		host2IPAddress[peer.RemoteAddress] = nbRemoteAddress
+	}
+
+	sessionName := fmt.Sprintf("%s - %s", device.Name, peer.RemoteAddress)
+	if bgpConfig.VRF != nil {
+		sessionName = fmt.Sprintf("%s - %s - %s", device.Name, bgpConfig.VRF.Name, peer.RemoteAddress)
+	}
+	_, err := nbi.AddBGPSession(ctx, &objects.BGPSession{
+		NetboxObject:  objects.NetboxObject{Tags: sourceTags},
+		Name:          sessionName,
+		Site:          device.Site,
+		Device:        device,
+		LocalAddress:  nbLocalAddress,
+		RemoteAddress: nbRemoteAddress,
+		LocalAS:       localASN,
+		RemoteAS:      remoteASN,
+		Status:        &objects.BGPSessionStatusActive,
+		Tenant:        device.Tenant,
+	})
+	if err != nil {
+		return fmt.Errorf("add bgp session %s: %s", sessionName, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+func TestParseASN(t *testing.T) {
+	tests := []struct {
+		name    string
+		asn     string
+		want    int64
+		wantErr bool
+	}{
+		{name: "Asplain", asn: "65001", want: 65001},
+		{name: "Asplain 4 byte", asn: " 4200000001 ", want: 4200000001},
+		{name: "Asdot", asn: "1.10", want: 65546},
+		{name: "Asdot out of range", asn: "65536.1", wantErr: true},
+		{name: "Zero", asn: "0", wantErr: true},
+		{name: "Out of range", asn: "4294967296", wantErr: true},
+		{name: "Invalid", asn: "as65001", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseASN(tt.asn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseASN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseASN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPrivateASN(t *testing.T) {
+	tests := []struct {
+		asn  int64
+		want bool
+	}{
+		{asn: 13335, want: false},
+		{asn: 64511, want: false},
+		{asn: 64512, want: true},
+		{asn: 65534, want: true},
+		{asn: 65535, want: false},
+		{asn: 4200000000, want: true},
+		{asn: 4294967295, want: false},
+	}
+	for _, tt := range tests {
+		if got := isPrivateASN(tt.asn); got != tt.want {
+			t.Errorf("isPrivateASN(%d) = %v, want %v", tt.asn, got, tt.want)
+		}
+	}
+}
+
+func TestBGPCustomFieldValues(t *testing.T) {
+	bgpConfigs := []BGPConfig{
+		{
+			LocalAS: 65001,
+			Peers: []BGPPeer{
+				{RemoteAddress: "10.0.0.6", RemoteAS: 65003},
+				{RemoteAddress: "10.0.0.2", RemoteAS: 65002},
+			},
+		},
+		{
+			LocalAS: 65001,
+			VRF:     &objects.VRF{Name: "customers"},
+			Peers:   []BGPPeer{{RemoteAddress: "172.16.0.1", RemoteAS: 64999}},
+		},
+	}
+	gotLocalAS, gotPeers := bgpCustomFieldValues(bgpConfigs)
+	if wantLocalAS := "65001"; gotLocalAS != wantLocalAS {
+		t.Errorf("bgpCustomFieldValues() localAS = %v, want %v", gotLocalAS, wantLocalAS)
+	}
+	if wantPeers := "10.0.0.2 (AS65002), 10.0.0.6 (AS65003), 172.16.0.1 (AS64999, customers)"; gotPeers != wantPeers {
+		t.Errorf("bgpCustomFieldValues() peers = %v, want %v", gotPeers, wantPeers)
+	}
+}
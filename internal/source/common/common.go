@@ -24,3 +24,10 @@ type Config struct {
 	SourceTags   []*objects.Tag
 	Ctx          context.Context //nolint:containedctx
 }
+
+// IncrementalSource is a source, that can sync only objects changed since the last sync.
+type IncrementalSource interface {
+	Source
+	// SyncUpdates syncs changes to Netbox inventory until ctx is done
+	SyncUpdates(ctx context.Context, nbi *inventory.NetboxInventory) error
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+// FHRPGroupMember represents an interface, that participates in the FHRP group.
+type FHRPGroupMember struct {
+	// Interface is already synced netbox interface, that is member of the group.
+	Interface *objects.Interface
+	// Priority of the interface in the group (0-255).
+	Priority int
+}
+
+// SyncFHRPGroup syncs FHRP group with the given protocol and group id to netbox.
+// Virtual ips (in CIDR notation) are assigned to the group with the role matching
+// the protocol and all members are assigned to the group.
+//
+// Name of the group is generated from its protocol, group id and first virtual ip,
+// so the same group reported by different devices (or sources) is synced only once.
+// If vrf is set, virtual ips are assigned to it and its name is also part of the
+// group name, because the same group can exist in multiple vrfs.
+// Virtual ips that are already assigned to an interface are not reassigned to the group.
+func SyncFHRPGroup(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, protocol *objects.FHRPGroupProtocol, groupID int, virtualIPs []string, tenant *objects.Tenant, vrf *objects.VRF, members []FHRPGroupMember) (*objects.FHRPGroup, error) {
+	if len(virtualIPs) == 0 {
+		return nil, fmt.Errorf("fhrp group %s %d has no virtual ips", protocol, groupID)
+	}
+	groupName := fmt.Sprintf("%s %d (%s)", protocol.Label, groupID, strings.Split(virtualIPs[0], "/")[0])
+	if vrf != nil {
+		groupName = fmt.Sprintf("%s %d (%s, %s)", protocol.Label, groupID, strings.Split(virtualIPs[0], "/")[0], vrf.Name)
+	}
+	nbFHRPGroup, err := nbi.AddFHRPGroup(ctx, &objects.FHRPGroup{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:     groupName,
+		Protocol: protocol,
+		GroupID:  groupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add fhrp group %s: %s", groupName, err)
+	}
+
+	ipRole, ok := objects.FHRPGroupProtocol2IPAddressRole[*protocol]
+	if !ok {
+		ipRole = &objects.IPAddressRoleVIP
+	}
+	for _, virtualIP := range virtualIPs {
+		// Virtual ip can equal the ip of the interface (e.g. VRRP owner), in that
+		// case the ip stays assigned to the interface, otherwise it would flap
+		// between the interface and the group on each run
+		host := strings.Split(virtualIP, "/")[0]
+		if ifaceIP, ok := nbi.GetIPAddressByHost(vrf, host); ok && (ifaceIP.AssignedObjectType == objects.AssignedObjectTypeDeviceInterface || ifaceIP.AssignedObjectType == objects.AssignedObjectTypeVMInterface) {
+			continue
+		}
+		_, err := nbi.AddIPAddress(ctx, &objects.IPAddress{
+			NetboxObject: objects.NetboxObject{
+				Tags: sourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName:   sourceName,
+					constants.CustomFieldArpEntryName: false,
+				},
+			},
+			Address:            virtualIP,
+			Status:             &objects.IPAddressStatusActive,
+			Role:               ipRole,
+			Tenant:             tenant,
+			Vrf:                vrf,
+			AssignedObjectType: objects.AssignedObjectTypeFHRPGroup,
+			AssignedObjectID:   nbFHRPGroup.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add virtual ip %s of fhrp group %s: %s", virtualIP, groupName, err)
+		}
+	}
+
+	for _, member := range members {
+		if member.Interface == nil {
+			continue
+		}
+		_, err := nbi.AddFHRPGroupAssignment(ctx, &objects.FHRPGroupAssignment{
+			Group:         nbFHRPGroup,
+			InterfaceType: objects.AssignedObjectTypeDeviceInterface,
+			InterfaceID:   member.Interface.ID,
+			Priority:      member.Priority,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("assign interface %s to fhrp group %s: %s", member.Interface.Name, groupName, err)
+		}
+	}
+	return nbFHRPGroup, nil
+}
@@ -0,0 +1,212 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// AddressObject represents an address object defined on the firewall.
+type AddressObject struct {
+	Name string
+	// Value is either an address with optional mask (e.g. "10.0.0.0/24")
+	// or a range of addresses (e.g. "10.0.0.10-10.0.0.20").
+	Value string
+	Tags  []string
+}
+
+// DhcpPool represents a pool of addresses leased by the firewall's dhcp server.
+type DhcpPool struct {
+	// Interface on which the dhcp server is running.
+	Interface    string
+	StartAddress string
+	EndAddress   string
+	// MaskBits of the subnet that the pool belongs to.
+	MaskBits int
+}
+
+// MatchAddressObject returns true if address object's name matches nameFilter,
+// or if the address object has one of the tags. Empty filters match nothing.
+func MatchAddressObject(addressObject AddressObject, nameFilter *regexp.Regexp, tags []string) bool {
+	if nameFilter != nil && nameFilter.MatchString(addressObject.Name) {
+		return true
+	}
+	for _, tag := range addressObject.Tags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAddressRange parses range of addresses in format "start-end".
+func parseAddressRange(value string) (netip.Addr, netip.Addr, error) {
+	start, end, _ := strings.Cut(value, "-")
+	startAddr, err := netip.ParseAddr(strings.TrimSpace(start))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("parse range start: %s", err)
+	}
+	endAddr, err := netip.ParseAddr(strings.TrimSpace(end))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("parse range end: %s", err)
+	}
+	if startAddr.BitLen() != endAddr.BitLen() || endAddr.Less(startAddr) {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid range %s", value)
+	}
+	return startAddr, endAddr, nil
+}
+
+// parseAddressPrefix parses address with optional mask. Addresses
+// without mask are treated as single hosts.
+func parseAddressPrefix(value string) (netip.Prefix, error) {
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	return netip.ParsePrefix(value)
+}
+
+// SyncAddressObjects syncs address objects within the vrf to netbox. Networks (e.g.
+// "10.0.0.0/24") are synced as prefixes, ranges as ip ranges and single hosts (e.g.
+// "10.0.0.1/32" or "10.0.0.1/24") as ip addresses. Hosts and ranges that were already
+// synced in this run (e.g. from interfaces, arp table or dhcp pools) are skipped, so
+// the data collected from devices is not overwritten with the address objects.
+func SyncAddressObjects(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, addressObjects []AddressObject, vrf *objects.VRF) error {
+	// Synced hosts are collected at once, because each lookup scans all ip addresses
+	hosts := make([]string, 0, len(addressObjects))
+	for _, addressObject := range addressObjects {
+		if prefix, err := parseAddressPrefix(strings.TrimSpace(addressObject.Value)); err == nil {
+			hosts = append(hosts, prefix.Addr().String())
+		}
+	}
+	syncedHosts := nbi.GetSyncedIPAddressHosts(vrf, hosts)
+	for _, addressObject := range addressObjects {
+		if err := syncAddressObject(ctx, nbi, sourceTags, addressObject, vrf, syncedHosts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncAddressObject syncs address object to netbox (see SyncAddressObjects).
+// Synced hosts are added to syncedHosts.
+func syncAddressObject(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, addressObject AddressObject, vrf *objects.VRF, syncedHosts map[string]bool) error {
+	description := fmt.Sprintf("Address object %s", addressObject.Name)
+	if strings.Contains(addressObject.Value, "-") {
+		startAddr, endAddr, err := parseAddressRange(addressObject.Value)
+		if err != nil {
+			return fmt.Errorf("address object %s: %s", addressObject.Name, err)
+		}
+		// Dhcp pools are synced first, so they take precedence over address objects
+		if nbi.IsIPRangeSynced(vrf, startAddr.String(), endAddr.String()) {
+			return nil
+		}
+		_, err = nbi.AddIPRange(ctx, &objects.IPRange{
+			NetboxObject: objects.NetboxObject{
+				Tags:        sourceTags,
+				Description: description,
+			},
+			StartAddress: fmt.Sprintf("%s/%d", startAddr, startAddr.BitLen()),
+			EndAddress:   fmt.Sprintf("%s/%d", endAddr, endAddr.BitLen()),
+			Status:       &objects.IPRangeStatusActive,
+			Vrf:          vrf,
+		})
+		if err != nil {
+			return fmt.Errorf("add ip range: %s", err)
+		}
+		return nil
+	}
+	prefix, err := parseAddressPrefix(strings.TrimSpace(addressObject.Value))
+	if err != nil {
+		return fmt.Errorf("address object %s: %s", addressObject.Name, err)
+	}
+	if prefix.Bits() == prefix.Addr().BitLen() || prefix.Masked().Addr() != prefix.Addr() {
+		if syncedHosts[prefix.Addr().String()] {
+			return nil
+		}
+		_, err = nbi.AddIPAddress(ctx, &objects.IPAddress{
+			NetboxObject: objects.NetboxObject{
+				Tags:        sourceTags,
+				Description: description,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldArpEntryName: false,
+				},
+			},
+			Address: prefix.String(),
+			Status:  &objects.IPAddressStatusActive,
+			DNSName: utils.ReverseLookup(prefix.Addr().String()),
+			Vrf:     vrf,
+		})
+		if err != nil {
+			return fmt.Errorf("add ip address: %s", err)
+		}
+		syncedHosts[prefix.Addr().String()] = true
+		return nil
+	}
+	_, err = nbi.AddPrefix(ctx, &objects.Prefix{
+		NetboxObject: objects.NetboxObject{
+			Tags:        sourceTags,
+			Description: description,
+		},
+		Prefix: prefix.String(),
+		Status: &objects.PrefixStatusActive,
+		Vrf:    vrf,
+	})
+	if err != nil {
+		return fmt.Errorf("add prefix: %s", err)
+	}
+	return nil
+}
+
+// SyncDhcpPools syncs dhcp pools as ip ranges. Netbox doesn't have dhcp status
+// for ip ranges, so they are synced as active and tagged with dhcp pool tag.
+func SyncDhcpPools(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, deviceName string, pools []DhcpPool, vrf *objects.VRF) error {
+	if len(pools) == 0 {
+		return nil
+	}
+	dhcpPoolTag, err := nbi.AddTag(ctx, &objects.Tag{
+		Name:        constants.DefaultDhcpPoolTagName,
+		Slug:        utils.Slugify(constants.DefaultDhcpPoolTagName),
+		Color:       constants.DefaultDhcpPoolTagColor,
+		Description: "tag created for ip ranges leased by dhcp servers",
+	})
+	if err != nil {
+		return fmt.Errorf("add tag: %s", err)
+	}
+	dhcpPoolTags := append(append([]*objects.Tag{}, sourceTags...), dhcpPoolTag)
+	for _, pool := range pools {
+		startAddr, endAddr, err := parseAddressRange(fmt.Sprintf("%s-%s", pool.StartAddress, pool.EndAddress))
+		if err != nil {
+			return fmt.Errorf("dhcp pool on %s: %s", pool.Interface, err)
+		}
+		maskBits := pool.MaskBits
+		if maskBits <= 0 || maskBits > startAddr.BitLen() {
+			maskBits = startAddr.BitLen()
+		}
+		_, err = nbi.AddIPRange(ctx, &objects.IPRange{
+			NetboxObject: objects.NetboxObject{
+				Tags:        dhcpPoolTags,
+				Description: fmt.Sprintf("DHCP pool on %s interface %s", deviceName, pool.Interface),
+			},
+			StartAddress: fmt.Sprintf("%s/%d", startAddr, maskBits),
+			EndAddress:   fmt.Sprintf("%s/%d", endAddr, maskBits),
+			Status:       &objects.IPRangeStatusActive,
+			Vrf:          vrf,
+		})
+		if err != nil {
+			return fmt.Errorf("add dhcp ip range: %s", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchAddressObject(t *testing.T) {
+	addressObject := AddressObject{Name: "srv-web-01", Value: "10.0.0.10/32", Tags: []string{"servers"}}
+	tests := []struct {
+		name       string
+		nameFilter *regexp.Regexp
+		tags       []string
+		want       bool
+	}{
+		{name: "Empty filters", want: false},
+		{name: "Matching name filter", nameFilter: regexp.MustCompile("^srv-"), want: true},
+		{name: "Non matching name filter", nameFilter: regexp.MustCompile("^net-"), want: false},
+		{name: "Matching tag", tags: []string{"dmz", "servers"}, want: true},
+		{name: "Non matching tag", tags: []string{"dmz"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchAddressObject(addressObject, tt.nameFilter, tt.tags); got != tt.want {
+				t.Errorf("MatchAddressObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAddressRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{name: "IPv4 range", value: "10.0.0.10-10.0.0.20", wantStart: "10.0.0.10", wantEnd: "10.0.0.20"},
+		{name: "IPv6 range", value: "2001:db8::10-2001:db8::20", wantStart: "2001:db8::10", wantEnd: "2001:db8::20"},
+		{name: "Reversed range", value: "10.0.0.20-10.0.0.10", wantErr: true},
+		{name: "Mixed ip versions", value: "10.0.0.10-2001:db8::20", wantErr: true},
+		{name: "Invalid address", value: "10.0.0.10-invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseAddressRange(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAddressRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if start.String() != tt.wantStart || end.String() != tt.wantEnd {
+				t.Errorf("parseAddressRange() = %s-%s, want %s-%s", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseAddressPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Network", value: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{name: "Host with mask", value: "10.0.0.1/24", want: "10.0.0.1/24"},
+		{name: "IPv4 host without mask", value: "10.0.0.1", want: "10.0.0.1/32"},
+		{name: "IPv6 host without mask", value: "2001:db8::1", want: "2001:db8::1/128"},
+		{name: "Invalid address", value: "srv.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAddressPrefix(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAddressPrefix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("parseAddressPrefix() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// RouteType represents the origin of the route.
+type RouteType string
+
+const (
+	RouteTypeConnected RouteType = "connected"
+	RouteTypeStatic    RouteType = "static"
+	RouteTypeBGP       RouteType = "bgp"
+)
+
+// Descriptions of prefixes created from routes of each type.
+var routeTypeDescriptions = map[RouteType]string{
+	RouteTypeConnected: "Connected route",
+	RouteTypeStatic:    "Static route",
+	RouteTypeBGP:       "BGP route",
+}
+
+// Route represents an entry of the device's routing table.
+type Route struct {
+	Type RouteType
+	// Destination network of the route (e.g. "10.0.0.0/24").
+	Prefix string
+	// Ip address of the next hop. Empty for connected routes.
+	NextHop string
+	// Outgoing interface of the route.
+	Interface string
+}
+
+// nextHopDescription returns next hop of the route in format "nextHop (interface)".
+// For routes without next hop address (e.g. connected routes) only the interface is returned.
+func (r Route) nextHopDescription() string {
+	nextHop := strings.TrimSpace(r.NextHop)
+	if addr, err := netip.ParseAddr(nextHop); err != nil || addr.IsUnspecified() {
+		nextHop = ""
+	}
+	switch {
+	case nextHop != "" && r.Interface != "":
+		return fmt.Sprintf("%s (%s)", nextHop, r.Interface)
+	case nextHop != "":
+		return nextHop
+	default:
+		return r.Interface
+	}
+}
+
+// FilterRoutes returns routes, that should be synced according to the source config.
+// Connected and static routes are always kept, bgp routes only if collectBgpRoutes
+// is enabled and their prefix length doesn't exceed bgpRouteMaxPrefixLength of their
+// address family. Default routes, host routes, routes to ignored subnets and duplicated
+// prefixes (e.g. ecmp) are skipped. Prefixes of returned routes are normalized to their
+// network address.
+func FilterRoutes(routes []Route, sourceConfig *parser.SourceConfig) []Route {
+	filteredRoutes := make([]Route, 0, len(routes))
+	seenPrefixes := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		switch route.Type {
+		case RouteTypeConnected, RouteTypeStatic:
+		case RouteTypeBGP:
+			if !sourceConfig.CollectBgpRoutes {
+				continue
+			}
+		default:
+			continue
+		}
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(route.Prefix))
+		if err != nil {
+			continue
+		}
+		prefix = prefix.Masked()
+		if prefix.Bits() == 0 || prefix.Bits() == prefix.Addr().BitLen() {
+			continue
+		}
+		if route.Type == RouteTypeBGP {
+			maxPrefixLength := sourceConfig.BgpRouteMaxPrefixLengthIPv4
+			if prefix.Addr().Is6() {
+				maxPrefixLength = sourceConfig.BgpRouteMaxPrefixLengthIPv6
+			}
+			if maxPrefixLength > 0 && prefix.Bits() > maxPrefixLength {
+				continue
+			}
+		}
+		if seenPrefixes[prefix.String()] || utils.SubnetsContainIPAddress(prefix.Addr().String(), sourceConfig.IgnoredSubnets) {
+			continue
+		}
+		seenPrefixes[prefix.String()] = true
+		route.Prefix = prefix.String()
+		filteredRoutes = append(filteredRoutes, route)
+	}
+	return filteredRoutes
+}
+
+// SyncRoutes syncs destination networks of routes as prefixes within the vrf.
+// Next hop of each route is stored in the next hop custom field of the prefix,
+// unless the prefix was already synced with next hop of another device in this run.
+// Routes should already be filtered with FilterRoutes.
+func SyncRoutes(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, routes []Route, vrf *objects.VRF) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	_, err := nbi.AddCustomField(ctx, &objects.CustomField{
+		Name:                  constants.CustomFieldPrefixNextHopName,
+		Label:                 constants.CustomFieldPrefixNextHopLabel,
+		Type:                  objects.CustomFieldTypeText,
+		FilterLogic:           objects.FilterLogicLoose,
+		CustomFieldUIVisible:  &objects.CustomFieldUIVisibleAlways,
+		CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
+		DisplayWeight:         objects.DisplayWeightDefault,
+		Description:           constants.CustomFieldPrefixNextHopDescription,
+		SearchWeight:          objects.SearchWeightDefault,
+		ContentTypes:          []string{constants.ContentTypeIpamPrefix},
+	})
+	if err != nil {
+		return fmt.Errorf("add custom field: %s", err)
+	}
+	for _, route := range routes {
+		customFields := map[string]interface{}{}
+		if nbi.ClaimPrefixNextHop(vrf, route.Prefix) {
+			customFields[constants.CustomFieldPrefixNextHopName] = route.nextHopDescription()
+		}
+		_, err := nbi.AddPrefix(ctx, &objects.Prefix{
+			NetboxObject: objects.NetboxObject{
+				Tags:         sourceTags,
+				Description:  routeTypeDescriptions[route.Type],
+				CustomFields: customFields,
+			},
+			Prefix: route.Prefix,
+			Status: &objects.PrefixStatusActive,
+			Vrf:    vrf,
+		})
+		if err != nil {
+			return fmt.Errorf("add prefix %s: %s", route.Prefix, err)
+		}
+	}
+	return nil
+}
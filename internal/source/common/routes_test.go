@@ -0,0 +1,77 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+)
+
+func TestFilterRoutes(t *testing.T) {
+	routes := []Route{
+		{Type: RouteTypeConnected, Prefix: "10.0.0.1/24", Interface: "port1"},
+		{Type: RouteTypeStatic, Prefix: "0.0.0.0/0", NextHop: "10.0.0.254"},
+		{Type: RouteTypeStatic, Prefix: "10.0.0.1/32", NextHop: "10.0.0.254"},
+		{Type: RouteTypeStatic, Prefix: "172.16.0.0/16", NextHop: "10.0.0.253"},
+		{Type: RouteTypeStatic, Prefix: "172.16.0.0/16", NextHop: "10.0.0.252"},
+		{Type: RouteTypeStatic, Prefix: "192.168.100.0/24", NextHop: "10.0.0.254"},
+		{Type: RouteTypeBGP, Prefix: "10.10.0.0/16", NextHop: "10.0.0.250"},
+		{Type: RouteTypeBGP, Prefix: "10.20.30.0/28", NextHop: "10.0.0.250"},
+		{Type: RouteTypeBGP, Prefix: "2001:db8:1::/48", NextHop: "2001:db8::1"},
+		{Type: RouteTypeBGP, Prefix: "2001:db8:2:1::/64", NextHop: "2001:db8::1"},
+		{Prefix: "10.30.0.0/16", NextHop: "10.0.0.250"},
+		{Type: RouteTypeStatic, Prefix: "invalid", NextHop: "10.0.0.250"},
+	}
+	tests := []struct {
+		name         string
+		sourceConfig *parser.SourceConfig
+		want         []Route
+	}{
+		{
+			name:         "Connected and static routes",
+			sourceConfig: &parser.SourceConfig{CollectRoutes: true, IgnoredSubnets: []string{"192.168.0.0/16"}},
+			want: []Route{
+				{Type: RouteTypeConnected, Prefix: "10.0.0.0/24", Interface: "port1"},
+				{Type: RouteTypeStatic, Prefix: "172.16.0.0/16", NextHop: "10.0.0.253"},
+			},
+		},
+		{
+			name:         "With bgp routes",
+			sourceConfig: &parser.SourceConfig{CollectRoutes: true, CollectBgpRoutes: true, BgpRouteMaxPrefixLengthIPv4: 24, BgpRouteMaxPrefixLengthIPv6: 48},
+			want: []Route{
+				{Type: RouteTypeConnected, Prefix: "10.0.0.0/24", Interface: "port1"},
+				{Type: RouteTypeStatic, Prefix: "172.16.0.0/16", NextHop: "10.0.0.253"},
+				{Type: RouteTypeStatic, Prefix: "192.168.100.0/24", NextHop: "10.0.0.254"},
+				{Type: RouteTypeBGP, Prefix: "10.10.0.0/16", NextHop: "10.0.0.250"},
+				{Type: RouteTypeBGP, Prefix: "2001:db8:1::/48", NextHop: "2001:db8::1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterRoutes(routes, tt.sourceConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterRoutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteNextHopDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		route Route
+		want  string
+	}{
+		{name: "Next hop and interface", route: Route{NextHop: "10.0.0.1", Interface: "port1"}, want: "10.0.0.1 (port1)"},
+		{name: "Only next hop", route: Route{NextHop: "2001:db8::1"}, want: "2001:db8::1"},
+		{name: "Unspecified next hop", route: Route{NextHop: "0.0.0.0", Interface: "port1"}, want: "port1"},
+		{name: "Only interface", route: Route{Interface: "ethernet1/1"}, want: "ethernet1/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.route.nextHopDescription(); got != tt.want {
+				t.Errorf("nextHopDescription() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
@@ -209,3 +210,127 @@ func MatchVMToTenant(ctx context.Context, nbi *inventory.NetboxInventory, vmName
 	}
 	return nil, nil
 }
+
+// Function that matches object's tags (e.g. "category/tag") to Site using tagSiteRelations.
+// Tags are matched in order, and the first match is returned.
+//
+// In case that there is no match or tagSiteRelations is nil, it will return nil.
+func MatchTagsToSite(ctx context.Context, nbi *inventory.NetboxInventory, tags []string, tagSiteRelations map[string]string) (*objects.Site, error) {
+	for _, tag := range tags {
+		site, err := MatchClusterToSite(ctx, nbi, tag, tagSiteRelations)
+		if err != nil {
+			return nil, fmt.Errorf("matching tag %s to site: %s", tag, err)
+		}
+		if site != nil {
+			return site, nil
+		}
+	}
+	return nil, nil
+}
+
+// Function that matches object's tags (e.g. "category/tag") to Tenant using tagTenantRelations.
+// Tags are matched in order, and the first match is returned.
+//
+// In case that there is no match or tagTenantRelations is nil, it will return nil.
+func MatchTagsToTenant(ctx context.Context, nbi *inventory.NetboxInventory, tags []string, tagTenantRelations map[string]string) (*objects.Tenant, error) {
+	for _, tag := range tags {
+		tenant, err := MatchClusterToTenant(ctx, nbi, tag, tagTenantRelations)
+		if err != nil {
+			return nil, fmt.Errorf("matching tag %s to tenant: %s", tag, err)
+		}
+		if tenant != nil {
+			return tenant, nil
+		}
+	}
+	return nil, nil
+}
+
+// Function that matches vm's tags (e.g. "category/tag") to DeviceRole using tagRoleRelations.
+// Tags are matched in order, and the first match is returned.
+//
+// In case that there is no match or tagRoleRelations is nil, it will return nil.
+func MatchTagsToVMRole(ctx context.Context, nbi *inventory.NetboxInventory, tags []string, tagRoleRelations map[string]string) (*objects.DeviceRole, error) {
+	if tagRoleRelations == nil {
+		return nil, nil
+	}
+	for _, tag := range tags {
+		roleName, err := utils.MatchStringToValue(tag, tagRoleRelations)
+		if err != nil {
+			return nil, fmt.Errorf("matching tag %s to role: %s", tag, err)
+		}
+		if roleName != "" {
+			if role, ok := nbi.DeviceRolesIndexByName[roleName]; ok {
+				return role, nil
+			}
+			role, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
+				Name:   roleName,
+				Slug:   utils.Slugify(roleName),
+				Color:  constants.ColorGrey,
+				VMRole: true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("add new role: %s", err)
+			}
+			return role, nil
+		}
+	}
+	return nil, nil
+}
+
+// VMCustomField describes a text custom field of virtual machines.
+type VMCustomField struct {
+	Name        string
+	Label       string
+	Description string
+}
+
+// AddVMCustomFields adds text custom fields of virtual machines, if they don't exist yet.
+func AddVMCustomFields(ctx context.Context, nbi *inventory.NetboxInventory, fields []VMCustomField) error {
+	for _, field := range fields {
+		if _, ok := nbi.CustomFieldsIndexByName[field.Name]; ok {
+			continue
+		}
+		_, err := nbi.AddCustomField(ctx, &objects.CustomField{
+			Name:                  field.Name,
+			Label:                 field.Label,
+			Type:                  objects.CustomFieldTypeText,
+			FilterLogic:           objects.FilterLogicLoose,
+			CustomFieldUIVisible:  &objects.CustomFieldUIVisibleIfSet,
+			CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
+			DisplayWeight:         objects.DisplayWeightDefault,
+			Description:           field.Description,
+			SearchWeight:          objects.SearchWeightDefault,
+			ContentTypes:          []string{constants.ContentTypeVirtualizationVirtualMachine},
+		})
+		if err != nil {
+			return fmt.Errorf("add custom field %s: %s", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// IsAttributeRelation returns true if the regex of the relation matches object's
+// attributes (e.g. "deviceGroup:Branches"), that start with one of attributePrefixes,
+// instead of object's name.
+func IsAttributeRelation(regex string, attributePrefixes []string) bool {
+	regex = strings.TrimPrefix(regex, "^")
+	for _, prefix := range attributePrefixes {
+		if strings.HasPrefix(regex, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitAttributeRelations removes relations matching object's attributes
+// (see IsAttributeRelation) from relations, and returns them.
+func SplitAttributeRelations(relations map[string]string, attributePrefixes []string) map[string]string {
+	attributeRelations := make(map[string]string)
+	for regex, value := range relations {
+		if IsAttributeRelation(regex, attributePrefixes) {
+			attributeRelations[regex] = value
+			delete(relations, regex)
+		}
+	}
+	return attributeRelations
+}
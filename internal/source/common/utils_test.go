@@ -0,0 +1,28 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitAttributeRelations(t *testing.T) {
+	relations := map[string]string{
+		"deviceGroup:Branch.*": "Branches",
+		"template:Ljubljana":   "Ljubljana",
+		".*":                   "MyTenant",
+	}
+	wantAttributeRelations := map[string]string{
+		"deviceGroup:Branch.*": "Branches",
+		"template:Ljubljana":   "Ljubljana",
+	}
+	wantRelations := map[string]string{
+		".*": "MyTenant",
+	}
+	gotAttributeRelations := SplitAttributeRelations(relations, []string{"deviceGroup:", "template:"})
+	if !reflect.DeepEqual(gotAttributeRelations, wantAttributeRelations) {
+		t.Errorf("SplitAttributeRelations() = %v, want %v", gotAttributeRelations, wantAttributeRelations)
+	}
+	if !reflect.DeepEqual(relations, wantRelations) {
+		t.Errorf("relations after SplitAttributeRelations() = %v, want %v", relations, wantRelations)
+	}
+}
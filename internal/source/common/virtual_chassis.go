@@ -0,0 +1,108 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+// VirtualChassisMember represents a device, that is a member of a virtual
+// chassis (e.g. member of a switch stack or a firewall in HA pair).
+type VirtualChassisMember struct {
+	// Device is already synced netbox device representing the member.
+	Device *objects.Device
+	// Position of the member in the virtual chassis. It must be unique within the chassis.
+	Position int
+	// Priority of the member in the virtual chassis.
+	Priority int
+	// Master is true if this member controls the virtual chassis (e.g. active firewall).
+	Master bool
+}
+
+// SyncVirtualChassis groups members into the virtual chassis with the given name and domain.
+// Each member is assigned its position and priority, and the master member is set as the
+// master of the virtual chassis. It returns synced members, indexed by their position.
+func SyncVirtualChassis(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, vcName string, vcDomain string, members []VirtualChassisMember) (map[int]*objects.Device, error) {
+	nbVirtualChassis, err := nbi.AddVirtualChassis(ctx, &objects.VirtualChassis{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:   vcName,
+		Domain: vcDomain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add virtual chassis %s: %s", vcName, err)
+	}
+
+	syncedMembers, master, err := syncVirtualChassisMembers(ctx, nbi, nbVirtualChassis, members)
+	if err != nil {
+		return nil, err
+	}
+
+	// Master can only be set after it is assigned to the virtual chassis
+	if master != nil {
+		vcCopy := *nbVirtualChassis
+		vcCopy.Master = master
+		_, err = nbi.AddVirtualChassis(ctx, &vcCopy)
+		if err != nil {
+			return nil, fmt.Errorf("set master of virtual chassis %s: %s", vcName, err)
+		}
+	}
+	return syncedMembers, nil
+}
+
+// JoinVirtualChassis assigns members to the virtual chassis, that is owned by another
+// source (e.g. the peer firewall in HA pair). If the virtual chassis already exists,
+// only the members and the master are synced, so attributes of the virtual chassis
+// don't flap between sources. Otherwise it falls back to SyncVirtualChassis.
+func JoinVirtualChassis(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, vcName string, vcDomain string, members []VirtualChassisMember) (map[int]*objects.Device, error) {
+	nbVirtualChassis, ok := nbi.GetVirtualChassis(vcName)
+	if !ok {
+		return SyncVirtualChassis(ctx, nbi, sourceTags, sourceName, vcName, vcDomain, members)
+	}
+	syncedMembers, master, err := syncVirtualChassisMembers(ctx, nbi, nbVirtualChassis, members)
+	if err != nil {
+		return nil, err
+	}
+	if master != nil {
+		_, err = nbi.SetVirtualChassisMaster(ctx, nbVirtualChassis, master)
+		if err != nil {
+			return nil, fmt.Errorf("set master of virtual chassis %s: %s", vcName, err)
+		}
+	}
+	return syncedMembers, nil
+}
+
+// syncVirtualChassisMembers assigns each member to the virtual chassis with its position
+// and priority. It returns synced members indexed by their position and the master member.
+func syncVirtualChassisMembers(ctx context.Context, nbi *inventory.NetboxInventory, nbVirtualChassis *objects.VirtualChassis, members []VirtualChassisMember) (map[int]*objects.Device, *objects.Device, error) {
+	syncedMembers := make(map[int]*objects.Device, len(members))
+	var master *objects.Device
+	for _, member := range members {
+		if member.Device == nil {
+			continue
+		}
+		if _, ok := syncedMembers[member.Position]; ok {
+			return nil, nil, fmt.Errorf("virtual chassis %s has multiple members on position %d", nbVirtualChassis.Name, member.Position)
+		}
+		memberCopy := *member.Device
+		memberCopy.VirtualChassis = nbVirtualChassis
+		memberCopy.VCPosition = member.Position
+		memberCopy.VCPriority = member.Priority
+		nbMember, err := nbi.AddDevice(ctx, &memberCopy)
+		if err != nil {
+			return nil, nil, fmt.Errorf("add virtual chassis member %s: %s", member.Device.Name, err)
+		}
+		syncedMembers[member.Position] = nbMember
+		if member.Master {
+			master = nbMember
+		}
+	}
+	return syncedMembers, master, nil
+}
@@ -0,0 +1,255 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+)
+
+// IPSecTunnel represents an IPSec tunnel terminated on the device, together with
+// the crypto parameters used in both phases of its negotiation.
+type IPSecTunnel struct {
+	// Name of the tunnel on the device.
+	Name string
+	// Status of the tunnel. Defaults to active.
+	Status *objects.TunnelStatus
+	// Tenant of the tunnel.
+	Tenant *objects.Tenant
+	// Comments about the tunnel (e.g. peer address).
+	Comments string
+
+	// Phase 1 parameters.
+	IKEVersion *objects.IKEVersion
+	// IKEMode is only used for IKEv1, main mode is used if it is not set.
+	IKEMode              *objects.IKEMode
+	AuthenticationMethod *objects.AuthenticationMethod
+	IKEEncryption        *objects.EncryptionAlgorithm
+	IKEAuthentication    *objects.AuthenticationAlgorithm
+	IKEGroup             *objects.DHGroup
+	IKELifetime          int
+
+	// Phase 2 parameters.
+	IPSecMode            *objects.IPSecMode
+	IPSecEncryption      *objects.EncryptionAlgorithm
+	IPSecAuthentication  *objects.AuthenticationAlgorithm
+	PFSGroup             *objects.DHGroup
+	IPSecLifetimeSeconds int
+	IPSecLifetimeData    int
+
+	// Interface is already synced tunnel interface of the device, that terminates the tunnel.
+	Interface *objects.Interface
+	// OutsideIP is already synced ip address used as local endpoint of the tunnel.
+	OutsideIP *objects.IPAddress
+}
+
+// SyncIPSecTunnel syncs tunnel and its termination on the tunnel interface to netbox.
+//
+// Proposals and policies are named after their parameters, so tunnels with the same
+// crypto settings share them. IPSec profile and the tunnel are named after the tunnel
+// and its device, because tunnel names are only unique within the device.
+func SyncIPSecTunnel(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, tunnel IPSecTunnel) (*objects.Tunnel, error) {
+	if tunnel.Interface == nil || tunnel.Interface.Device == nil {
+		return nil, fmt.Errorf("tunnel %s is not terminated on device interface", tunnel.Name)
+	}
+	tunnelName := fmt.Sprintf("%s (%s)", tunnel.Name, tunnel.Interface.Device.Name)
+	ipsecProfile, err := syncIPSecProfile(ctx, nbi, sourceTags, sourceName, tunnelName, tunnel)
+	if err != nil {
+		return nil, fmt.Errorf("sync ipsec profile of tunnel %s: %s", tunnelName, err)
+	}
+	tunnelStatus := tunnel.Status
+	if tunnelStatus == nil {
+		tunnelStatus = &objects.TunnelStatusActive
+	}
+	nbTunnel, err := nbi.AddTunnel(ctx, &objects.Tunnel{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:          tunnelName,
+		Status:        tunnelStatus,
+		Encapsulation: &objects.TunnelEncapsulationIPSecTunnel,
+		IPSecProfile:  ipsecProfile,
+		Tenant:        tunnel.Tenant,
+		Comments:      tunnel.Comments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add tunnel %s: %s", tunnelName, err)
+	}
+	_, err = nbi.AddTunnelTermination(ctx, &objects.TunnelTermination{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Tunnel:          nbTunnel,
+		Role:            &objects.TunnelTerminationRolePeer,
+		TerminationType: objects.AssignedObjectTypeDeviceInterface,
+		TerminationID:   tunnel.Interface.ID,
+		OutsideIP:       tunnel.OutsideIP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add termination of tunnel %s: %s", tunnelName, err)
+	}
+	return nbTunnel, nil
+}
+
+// syncIPSecProfile syncs proposals, policies and profile used by the tunnel.
+// It returns nil profile, if the tunnel is missing parameters required by netbox.
+func syncIPSecProfile(ctx context.Context, nbi *inventory.NetboxInventory, sourceTags []*objects.Tag, sourceName string, profileName string, tunnel IPSecTunnel) (*objects.IPSecProfile, error) {
+	if tunnel.IKEVersion == nil || tunnel.IKEEncryption == nil || tunnel.IKEGroup == nil {
+		return nil, nil
+	}
+	authenticationMethod := tunnel.AuthenticationMethod
+	if authenticationMethod == nil {
+		authenticationMethod = &objects.AuthenticationMethodPresharedKeys
+	}
+	ikeProposalName := ikeProposalName(tunnel)
+	ikeProposal, err := nbi.AddIKEProposal(ctx, &objects.IKEProposal{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:                    ikeProposalName,
+		AuthenticationMethod:    authenticationMethod,
+		EncryptionAlgorithm:     tunnel.IKEEncryption,
+		AuthenticationAlgorithm: tunnel.IKEAuthentication,
+		Group:                   tunnel.IKEGroup,
+		SALifetime:              tunnel.IKELifetime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ike proposal %s: %s", ikeProposalName, err)
+	}
+	var ikeMode *objects.IKEMode
+	ikePolicyName := fmt.Sprintf("%s %s", tunnel.IKEVersion.Label, ikeProposalName)
+	if *tunnel.IKEVersion == objects.IKEVersion1 {
+		// Netbox requires mode for IKEv1 policies, unknown modes (e.g. auto) start in main mode
+		ikeMode = &objects.IKEModeMain
+		if tunnel.IKEMode != nil {
+			ikeMode = tunnel.IKEMode
+		}
+		ikePolicyName = fmt.Sprintf("%s %s %s", tunnel.IKEVersion.Label, ikeMode.Value, ikeProposalName)
+	}
+	ikePolicy, err := nbi.AddIKEPolicy(ctx, &objects.IKEPolicy{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:      ikePolicyName,
+		Version:   tunnel.IKEVersion,
+		Mode:      ikeMode,
+		Proposals: []*objects.IKEProposal{ikeProposal},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ike policy %s: %s", ikePolicyName, err)
+	}
+
+	var ipsecProposals []*objects.IPSecProposal
+	ipsecPolicyName := "no-pfs"
+	if tunnel.PFSGroup != nil {
+		ipsecPolicyName = fmt.Sprintf("pfs group%d", tunnel.PFSGroup.Value)
+	}
+	if tunnel.IPSecEncryption != nil || tunnel.IPSecAuthentication != nil {
+		ipsecProposalName := ipsecProposalName(tunnel)
+		ipsecProposal, err := nbi.AddIPSecProposal(ctx, &objects.IPSecProposal{
+			NetboxObject: objects.NetboxObject{
+				Tags: sourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName: sourceName,
+				},
+			},
+			Name:                    ipsecProposalName,
+			EncryptionAlgorithm:     tunnel.IPSecEncryption,
+			AuthenticationAlgorithm: tunnel.IPSecAuthentication,
+			SALifetimeSeconds:       tunnel.IPSecLifetimeSeconds,
+			SALifetimeData:          tunnel.IPSecLifetimeData,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add ipsec proposal %s: %s", ipsecProposalName, err)
+		}
+		ipsecProposals = append(ipsecProposals, ipsecProposal)
+		ipsecPolicyName = fmt.Sprintf("%s %s", ipsecProposalName, ipsecPolicyName)
+	}
+	ipsecPolicy, err := nbi.AddIPSecPolicy(ctx, &objects.IPSecPolicy{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:      ipsecPolicyName,
+		Proposals: ipsecProposals,
+		PFSGroup:  tunnel.PFSGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ipsec policy %s: %s", ipsecPolicyName, err)
+	}
+
+	ipsecMode := tunnel.IPSecMode
+	if ipsecMode == nil {
+		ipsecMode = &objects.IPSecModeESP
+	}
+	ipsecProfile, err := nbi.AddIPSecProfile(ctx, &objects.IPSecProfile{
+		NetboxObject: objects.NetboxObject{
+			Tags: sourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldSourceName: sourceName,
+			},
+		},
+		Name:        profileName,
+		Mode:        ipsecMode,
+		IKEPolicy:   ikePolicy,
+		IPSecPolicy: ipsecPolicy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ipsec profile %s: %s", profileName, err)
+	}
+	return ipsecProfile, nil
+}
+
+// ikeProposalName generates name of the IKE proposal from phase 1 parameters
+// of the tunnel, e.g. "aes-256-cbc/hmac-sha256/group14/28800s".
+func ikeProposalName(tunnel IPSecTunnel) string {
+	parts := []string{tunnel.IKEEncryption.Value}
+	if tunnel.IKEAuthentication != nil {
+		parts = append(parts, tunnel.IKEAuthentication.Value)
+	}
+	parts = append(parts, fmt.Sprintf("group%d", tunnel.IKEGroup.Value))
+	if tunnel.IKELifetime > 0 {
+		parts = append(parts, fmt.Sprintf("%ds", tunnel.IKELifetime))
+	}
+	name := strings.Join(parts, "/")
+	if tunnel.AuthenticationMethod != nil && *tunnel.AuthenticationMethod != objects.AuthenticationMethodPresharedKeys {
+		name = fmt.Sprintf("%s %s", tunnel.AuthenticationMethod.Value, name)
+	}
+	return name
+}
+
+// ipsecProposalName generates name of the IPSec proposal from phase 2 parameters
+// of the tunnel, e.g. "aes-256-gcm/3600s".
+func ipsecProposalName(tunnel IPSecTunnel) string {
+	parts := make([]string, 0)
+	if tunnel.IPSecEncryption != nil {
+		parts = append(parts, tunnel.IPSecEncryption.Value)
+	}
+	if tunnel.IPSecAuthentication != nil {
+		parts = append(parts, tunnel.IPSecAuthentication.Value)
+	}
+	if tunnel.IPSecLifetimeSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("%ds", tunnel.IPSecLifetimeSeconds))
+	}
+	if tunnel.IPSecLifetimeData > 0 {
+		parts = append(parts, fmt.Sprintf("%dkb", tunnel.IPSecLifetimeData))
+	}
+	return strings.Join(parts, "/")
+}
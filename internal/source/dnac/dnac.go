@@ -21,6 +21,22 @@ type DnacSource struct {
 	Devices    map[string]dnac.ResponseDevicesGetDeviceListResponse        // DeviceID -> Device
 	Interfaces map[string]dnac.ResponseDevicesGetAllInterfacesResponse     // InterfaceID -> Interface
 	Vlans      map[int]dnac.ResponseDevicesGetDeviceInterfaceVLANsResponse // VlanID -> Vlan
+	// DeviceID -> Stack members of the device
+	StackMembers map[string][]dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo
+	// DeviceID -> Interface name -> HSRP and VRRP groups configured on the interface
+	DeviceID2FHRPGroups map[string]map[string][]*FHRPGroupConfig
+	// DeviceID -> Bgp configuration of the device, nil if bgp is not configured
+	DeviceID2BGPConfig map[string]*common.BGPConfig
+	// DeviceID -> Hardware modules of the device. Collected only if syncModulesAs is set.
+	DeviceID2Modules map[string][]dnac.ResponseDevicesGetModulesResponse
+	// Enterprise ssids configured on wireless controllers
+	SSIDs []dnac.ResponseItemWirelessGetEnterpriseSSIDSSIDDetails
+	// SSID name -> Vlan id of ssid's clients
+	SSID2VID map[string]int
+	// DeviceID of access point -> Switch port to which access point is connected
+	AccessPointID2Uplink map[string]string
+	// Hosts (clients) connected to network devices. Collected only if collectArpData is enabled.
+	Hosts []HostResponse
 	// Relations between dnac data. Initialized in init functions.
 	Site2Devices          map[string]map[string]bool // Site ID - > set of device IDs
 	Device2Site           map[string]string          // Device ID -> Site ID
@@ -28,9 +44,13 @@ type DnacSource struct {
 
 	// Netbox related data for easier access. Initialized in sync functions.
 	VID2nbVlan              map[int]*objects.Vlan         // VlanID -> nbVlan
-	SiteID2nbSite           map[string]*objects.Site      // SiteID -> nbSite
+	SiteID2nbRegion         map[string]*objects.Region    // Area SiteID -> nbRegion
+	SiteID2nbSite           map[string]*objects.Site      // Building or floor SiteID -> nbSite
+	SiteID2nbLocation       map[string]*objects.Location  // Floor SiteID -> nbLocation
 	DeviceID2nbDevice       map[string]*objects.Device    // DeviceID -> nbDevice
 	InterfaceID2nbInterface map[string]*objects.Interface // InterfaceID -> nbInterface
+	// DeviceID -> StackMemberNumber -> nbDevice
+	DeviceID2nbStackMembers map[string]map[int]*objects.Device
 
 	// User defined relations
 	HostTenantRelations map[string]string
@@ -59,6 +79,10 @@ func (ds *DnacSource) Init() error {
 		ds.InitMemberships,
 		ds.InitDevices,
 		ds.InitInterfaces,
+		ds.InitDeviceConfigs,
+		ds.InitWireless,
+		ds.InitModules,
+		ds.InitHosts,
 	}
 
 	for _, initFunc := range initFunctions {
@@ -75,15 +99,24 @@ func (ds *DnacSource) Init() error {
 func (ds *DnacSource) Sync(nbi *inventory.NetboxInventory) error {
 	// initialize variables, that are shared between sync functions
 	ds.VID2nbVlan = make(map[int]*objects.Vlan)
+	ds.SiteID2nbRegion = make(map[string]*objects.Region)
 	ds.SiteID2nbSite = make(map[string]*objects.Site)
+	ds.SiteID2nbLocation = make(map[string]*objects.Location)
 	ds.DeviceID2nbDevice = make(map[string]*objects.Device)
 	ds.InterfaceID2nbInterface = make(map[string]*objects.Interface)
+	ds.DeviceID2nbStackMembers = make(map[string]map[int]*objects.Device)
 
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		ds.SyncSites,
 		ds.SyncVlans,
 		ds.SyncDevices,
+		ds.SyncStacks,
 		ds.SyncDeviceInterfaces,
+		ds.SyncModules,
+		ds.SyncFHRPGroups,
+		ds.SyncBGP,
+		ds.SyncWireless,
+		ds.SyncArpTable,
 	}
 
 	for _, syncFunc := range syncFunctions {
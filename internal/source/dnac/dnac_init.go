@@ -3,7 +3,13 @@ package dnac
 import (
 	"fmt"
 	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
 	dnac "github.com/cisco-en-programmability/dnacenter-go-sdk/v5/sdk"
 )
 
@@ -53,9 +59,15 @@ func (ds *DnacSource) InitDevices(c *dnac.Client) error {
 
 	ds.Devices = make(map[string]dnac.ResponseDevicesGetDeviceListResponse, len(allDevices))
 	ds.Vlans = make(map[int]dnac.ResponseDevicesGetDeviceInterfaceVLANsResponse)
+	ds.StackMembers = make(map[string][]dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo)
 	for _, device := range allDevices {
 		ds.Devices[device.ID] = device
 		ds.initVlansForDevice(c, device.ID)
+		// Stacks are reported as a single device with comma separated serial numbers
+		// of all members, so we only query stack details for those devices.
+		if strings.Contains(device.SerialNumber, ",") {
+			ds.initStackForDevice(c, device.ID)
+		}
 	}
 	return nil
 }
@@ -72,6 +84,18 @@ func (ds *DnacSource) initVlansForDevice(c *dnac.Client, deviceID string) {
 	}
 }
 
+// Function that gets all stack members for device id.
+func (ds *DnacSource) initStackForDevice(c *dnac.Client, deviceID string) {
+	stack, _, err := c.Devices.GetStackDetailsForDevice(deviceID)
+	if err != nil {
+		ds.Logger.Warningf(ds.Ctx, "get stack details for device %s: %s", deviceID, err)
+		return
+	}
+	if stack != nil && stack.Response != nil && stack.Response.StackSwitchInfo != nil {
+		ds.StackMembers[deviceID] = *stack.Response.StackSwitchInfo
+	}
+}
+
 func (ds *DnacSource) InitInterfaces(c *dnac.Client) error {
 	offset := 0
 	limit := 100
@@ -141,3 +165,432 @@ func (ds *DnacSource) InitMemberships(c *dnac.Client) error {
 	}
 	return nil
 }
+
+// Default priority of HSRP and VRRP groups, if it is not configured.
+const defaultFHRPPriority = 100
+
+// FHRPGroupConfig represents HSRP or VRRP group configured on the device interface.
+type FHRPGroupConfig struct {
+	Protocol   *objects.FHRPGroupProtocol
+	GroupID    int
+	VirtualIPs []string
+	Priority   int
+}
+
+// InitDeviceConfigs collects HSRP and VRRP groups and bgp configuration (when
+// collectBgp is enabled) from running configs of routers and switches.
+//
+// This function has to run after InitDevices and InitInterfaces.
+func (ds *DnacSource) InitDeviceConfigs(c *dnac.Client) error {
+	ds.DeviceID2FHRPGroups = make(map[string]map[string][]*FHRPGroupConfig)
+	ds.DeviceID2BGPConfig = make(map[string]*common.BGPConfig)
+	for deviceID, device := range ds.Devices {
+		if device.Family != "Routers" && device.Family != "Switches and Hubs" {
+			continue
+		}
+		deviceConfig, _, err := c.Devices.GetDeviceConfigByID(deviceID)
+		if err != nil {
+			return fmt.Errorf("get config for device %s: %s", device.Hostname, err)
+		}
+		if config, ok := deviceConfig.Response.(string); ok {
+			ds.DeviceID2FHRPGroups[deviceID] = parseFHRPGroups(config)
+			if ds.SourceConfig.CollectBGP {
+				// Nil config is also stored, so bgp custom fields of the device are cleared
+				ds.DeviceID2BGPConfig[deviceID] = parseBGPConfig(config, ds.interfaceIPLookup(deviceID))
+			}
+		}
+	}
+	return nil
+}
+
+// interfaceIPLookup returns function, that returns ipv4 address of the
+// device's interface with the given name.
+func (ds *DnacSource) interfaceIPLookup(deviceID string) func(string) string {
+	return func(ifaceName string) string {
+		for _, ifaceID := range ds.DeviceID2InterfaceIDs[deviceID] {
+			if iface := ds.Interfaces[ifaceID]; iface.PortName == ifaceName {
+				return iface.IPv4Address
+			}
+		}
+		return ""
+	}
+}
+
+// parseFHRPGroups parses cisco running config and returns all HSRP and
+// VRRP groups, indexed by the name of the interface they are configured on.
+func parseFHRPGroups(config string) map[string][]*FHRPGroupConfig {
+	iface2Groups := make(map[string][]*FHRPGroupConfig)
+	var ifaceName string
+	var vrrp3Group *FHRPGroupConfig
+	// getGroup returns existing group of the current interface, or creates a new one
+	getGroup := func(protocol *objects.FHRPGroupProtocol, groupID int) *FHRPGroupConfig {
+		for _, group := range iface2Groups[ifaceName] {
+			if group.Protocol == protocol && group.GroupID == groupID {
+				return group
+			}
+		}
+		group := &FHRPGroupConfig{Protocol: protocol, GroupID: groupID, Priority: defaultFHRPPriority}
+		iface2Groups[ifaceName] = append(iface2Groups[ifaceName], group)
+		return group
+	}
+
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, " ") {
+			// New section of the config
+			ifaceName = ""
+			vrrp3Group = nil
+			if strings.HasPrefix(line, "interface ") {
+				ifaceName = strings.TrimSpace(strings.TrimPrefix(line, "interface "))
+			}
+			continue
+		}
+		if ifaceName == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Lines inside of vrrp address-family block (VRRPv3)
+		if vrrp3Group != nil && strings.HasPrefix(line, "  ") {
+			switch {
+			case fields[0] == "address" && len(fields) > 1:
+				vrrp3Group.VirtualIPs = append(vrrp3Group.VirtualIPs, fields[1])
+			case fields[0] == "priority" && len(fields) > 1:
+				if priority, err := strconv.Atoi(fields[1]); err == nil {
+					vrrp3Group.Priority = priority
+				}
+			}
+			continue
+		}
+		vrrp3Group = nil
+
+		switch fields[0] {
+		case "standby":
+			// standby [group] ip|priority value, where group defaults to 0
+			groupID := 0
+			args := fields[1:]
+			if len(args) > 0 {
+				if id, err := strconv.Atoi(args[0]); err == nil {
+					groupID = id
+					args = args[1:]
+				}
+			}
+			if len(args) < 2 { //nolint:gomnd
+				continue
+			}
+			switch args[0] {
+			case "ip":
+				group := getGroup(&objects.FHRPGroupProtocolHSRP, groupID)
+				group.VirtualIPs = append(group.VirtualIPs, args[1])
+			case "priority":
+				if priority, err := strconv.Atoi(args[1]); err == nil {
+					getGroup(&objects.FHRPGroupProtocolHSRP, groupID).Priority = priority
+				}
+			}
+		case "vrrp":
+			// vrrp group ip|priority value or vrrp group address-family ipv4
+			if len(fields) < 4 { //nolint:gomnd
+				continue
+			}
+			groupID, err := strconv.Atoi(fields[1])
+			if err != nil {
+				continue
+			}
+			switch fields[2] {
+			case "ip":
+				group := getGroup(&objects.FHRPGroupProtocolVRRP2, groupID)
+				group.VirtualIPs = append(group.VirtualIPs, fields[3])
+			case "priority":
+				if priority, err := strconv.Atoi(fields[3]); err == nil {
+					getGroup(&objects.FHRPGroupProtocolVRRP2, groupID).Priority = priority
+				}
+			case "address-family":
+				if fields[3] == "ipv4" {
+					vrrp3Group = getGroup(&objects.FHRPGroupProtocolVRRP3, groupID)
+				}
+			}
+		}
+	}
+	return iface2Groups
+}
+
+// parseBGPConfig parses "router bgp" section of cisco running config. Neighbors
+// inherit remote as of their peer group, and their local address is the ip of
+// the update source interface, resolved with ifaceIP. Neighbors of all address
+// families are collected. Nil is returned, if bgp is not configured.
+func parseBGPConfig(config string, ifaceIP func(string) string) *common.BGPConfig {
+	var bgpConfig *common.BGPConfig
+	inBGPSection := false
+	neighbors := make([]string, 0)
+	neighbor2AS := make(map[string]int64)
+	neighbor2PeerGroup := make(map[string]string)
+	neighbor2UpdateSource := make(map[string]string)
+	peerGroup2AS := make(map[string]int64)
+	addNeighbor := func(neighbor string) {
+		if !slices.Contains(neighbors, neighbor) {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimRight(line, "\r")
+		fields := strings.Fields(line)
+		if !strings.HasPrefix(line, " ") {
+			// New section of the config
+			inBGPSection = false
+			if len(fields) == 3 && fields[0] == "router" && fields[1] == "bgp" { //nolint:gomnd
+				if localAS, err := common.ParseASN(fields[2]); err == nil {
+					bgpConfig = &common.BGPConfig{LocalAS: localAS}
+					inBGPSection = true
+				}
+			}
+			continue
+		}
+		if !inBGPSection || len(fields) < 3 { //nolint:gomnd
+			continue
+		}
+
+		switch {
+		case fields[0] == "bgp" && fields[1] == "router-id":
+			bgpConfig.RouterID = fields[2]
+		case fields[0] == "neighbor" && len(fields) > 3:
+			// neighbor ip|peer-group remote-as|peer-group|update-source value
+			neighbor := fields[1]
+			_, err := netip.ParseAddr(neighbor)
+			isPeerGroup := err != nil
+			switch fields[2] {
+			case "remote-as":
+				remoteAS, err := common.ParseASN(fields[3])
+				if err != nil {
+					continue
+				}
+				if isPeerGroup {
+					peerGroup2AS[neighbor] = remoteAS
+				} else {
+					neighbor2AS[neighbor] = remoteAS
+					addNeighbor(neighbor)
+				}
+			case "peer-group":
+				if !isPeerGroup {
+					neighbor2PeerGroup[neighbor] = fields[3]
+					addNeighbor(neighbor)
+				}
+			case "update-source":
+				neighbor2UpdateSource[neighbor] = fields[3]
+			}
+		}
+	}
+	if bgpConfig == nil {
+		return nil
+	}
+
+	for _, neighbor := range neighbors {
+		remoteAS, ok := neighbor2AS[neighbor]
+		if !ok {
+			remoteAS, ok = peerGroup2AS[neighbor2PeerGroup[neighbor]]
+		}
+		if !ok {
+			continue
+		}
+		peer := common.BGPPeer{RemoteAddress: neighbor, RemoteAS: remoteAS}
+		updateSource, ok := neighbor2UpdateSource[neighbor]
+		if !ok {
+			updateSource = neighbor2UpdateSource[neighbor2PeerGroup[neighbor]]
+		}
+		if updateSource != "" && ifaceIP != nil {
+			peer.LocalAddress = ifaceIP(updateSource)
+		}
+		bgpConfig.Peers = append(bgpConfig.Peers, peer)
+	}
+	return bgpConfig
+}
+
+// HostResponse represents a host (client) from dnac host inventory.
+type HostResponse struct {
+	ID                       string `json:"id"`
+	HostIP                   string `json:"hostIp"`
+	HostMAC                  string `json:"hostMac"`
+	HostType                 string `json:"hostType"`
+	ConnectedNetworkDeviceID string `json:"connectedNetworkDeviceId"`
+	ConnectedInterfaceName   string `json:"connectedInterfaceName"`
+}
+
+type HostsResponse struct {
+	Response []HostResponse `json:"response"`
+}
+
+// InitHosts collects ip and mac addresses of hosts connected to network devices
+// from dnac host inventory, if collectArpData is enabled. Host inventory is
+// not part of the sdk, so it is requested with sdk's rest client.
+func (ds *DnacSource) InitHosts(c *dnac.Client) error {
+	if !ds.SourceConfig.CollectArpData {
+		return nil
+	}
+	// Host inventory is indexed from 1
+	offset := 1
+	limit := 500
+	ds.Hosts = make([]HostResponse, 0)
+	for {
+		hostsResponse := &HostsResponse{}
+		response, err := c.RestyClient().R().
+			SetQueryParams(map[string]string{
+				"offset": strconv.Itoa(offset),
+				"limit":  strconv.Itoa(limit),
+			}).
+			SetResult(hostsResponse).
+			Get("/api/v1/host")
+		if err != nil {
+			return fmt.Errorf("init hosts: %s", err)
+		}
+		if response.StatusCode() != http.StatusOK {
+			return fmt.Errorf("init hosts response code: %s", response.String())
+		}
+		ds.Hosts = append(ds.Hosts, hostsResponse.Response...)
+		if len(hostsResponse.Response) < limit {
+			break
+		}
+		offset += limit
+	}
+	return nil
+}
+
+// Device families of wireless devices in dnac.
+const (
+	familyAccessPoint        = "Unified AP"
+	familyWirelessController = "Wireless Controller"
+)
+
+// InitWireless collects enterprise ssids with vlans of their clients, and uplinks
+// of access points.
+func (ds *DnacSource) InitWireless(c *dnac.Client) error {
+	ds.SSIDs = make([]dnac.ResponseItemWirelessGetEnterpriseSSIDSSIDDetails, 0)
+	ds.SSID2VID = make(map[string]int)
+	ds.AccessPointID2Uplink = make(map[string]string)
+
+	enterpriseSSIDs, _, err := c.Wireless.GetEnterpriseSSID(nil)
+	if err != nil {
+		return fmt.Errorf("get enterprise ssids: %s", err)
+	}
+	if enterpriseSSIDs != nil {
+		for _, enterpriseSSID := range *enterpriseSSIDs {
+			if enterpriseSSID.SSIDDetails != nil {
+				ds.SSIDs = append(ds.SSIDs, *enterpriseSSID.SSIDDetails...)
+			}
+		}
+	}
+
+	dynamicInterfaces := make(map[string]int)
+	interfaces, _, err := c.Wireless.GetDynamicInterface(nil)
+	if err != nil {
+		return fmt.Errorf("get dynamic interfaces: %s", err)
+	}
+	if interfaces != nil {
+		for _, iface := range *interfaces {
+			if iface.VLANID != nil {
+				dynamicInterfaces[iface.InterfaceName] = int(*iface.VLANID)
+			}
+		}
+	}
+
+	profiles, _, err := c.Wireless.GetWirelessProfile(nil)
+	if err != nil {
+		return fmt.Errorf("get wireless profiles: %s", err)
+	}
+	if profiles != nil {
+		ds.SSID2VID = ssidVlanIDs(*profiles, dynamicInterfaces)
+	}
+
+	topology, _, err := c.Topology.GetPhysicalTopology(nil)
+	if err != nil {
+		return fmt.Errorf("get physical topology: %s", err)
+	}
+	if topology != nil && topology.Response != nil && topology.Response.Links != nil {
+		ds.AccessPointID2Uplink = accessPointUplinks(ds.Devices, *topology.Response.Links)
+	}
+	return nil
+}
+
+// ssidVlanIDs returns vlan ids of ssids' clients, indexed by ssid name. Vlan id is
+// taken from the flexconnect local vlan, or from the wlc dynamic interface the ssid is
+// mapped to in the wireless profile.
+func ssidVlanIDs(profiles []dnac.ResponseItemWirelessGetWirelessProfile, dynamicInterfaces map[string]int) map[string]int {
+	ssid2VID := make(map[string]int)
+	for _, profile := range profiles {
+		if profile.ProfileDetails == nil || profile.ProfileDetails.SSIDDetails == nil {
+			continue
+		}
+		for _, ssid := range *profile.ProfileDetails.SSIDDetails {
+			if _, ok := ssid2VID[ssid.Name]; ok {
+				continue
+			}
+			flexConnect := ssid.FlexConnect
+			if flexConnect != nil && flexConnect.EnableFlexConnect != nil && *flexConnect.EnableFlexConnect && flexConnect.LocalToVLAN != nil {
+				ssid2VID[ssid.Name] = *flexConnect.LocalToVLAN
+			} else if vid, ok := dynamicInterfaces[ssid.InterfaceName]; ok {
+				ssid2VID[ssid.Name] = vid
+			}
+		}
+	}
+	return ssid2VID
+}
+
+// accessPointUplinks returns switch ports to which access points are connected
+// (e.g. "sw01 (GigabitEthernet1/0/5)"), indexed by access point's device id.
+func accessPointUplinks(devices map[string]dnac.ResponseDevicesGetDeviceListResponse, links []dnac.ResponseTopologyGetPhysicalTopologyResponseLinks) map[string]string {
+	apUplinks := make(map[string]string)
+	for _, link := range links {
+		apID, neighborID, neighborPort := link.Source, link.Target, link.EndPortName
+		if devices[apID].Family != familyAccessPoint {
+			apID, neighborID, neighborPort = link.Target, link.Source, link.StartPortName
+		}
+		if devices[apID].Family != familyAccessPoint {
+			continue
+		}
+		neighbor, ok := devices[neighborID]
+		if !ok || neighbor.Family == familyAccessPoint {
+			continue
+		}
+		apUplinks[apID] = fmt.Sprintf("%s (%s)", neighbor.Hostname, neighborPort)
+	}
+	return apUplinks
+}
+
+// InitModules collects hardware modules (line cards, power supplies, transceivers)
+// of network devices, if syncModulesAs is set.
+func (ds *DnacSource) InitModules(c *dnac.Client) error {
+	ds.DeviceID2Modules = make(map[string][]dnac.ResponseDevicesGetModulesResponse)
+	if ds.SourceConfig.SyncModulesAs == "" {
+		return nil
+	}
+	for deviceID, device := range ds.Devices {
+		if device.Family == familyAccessPoint {
+			continue
+		}
+		modules, _, err := c.Devices.GetModules(&dnac.GetModulesQueryParams{DeviceID: deviceID})
+		if err != nil {
+			return fmt.Errorf("get modules for device %s: %s", device.Hostname, err)
+		}
+		if modules == nil || modules.Response == nil {
+			continue
+		}
+		for _, module := range *modules.Response {
+			if isHardwareModule(module) {
+				ds.DeviceID2Modules[deviceID] = append(ds.DeviceID2Modules[deviceID], module)
+			}
+		}
+	}
+	return nil
+}
+
+// isHardwareModule returns true if the module is a replaceable part of the device.
+// Chassis and empty containers (slots) are also reported as modules, so they are skipped.
+func isHardwareModule(module dnac.ResponseDevicesGetModulesResponse) bool {
+	equipmentType := strings.ToLower(module.VendorEquipmentType)
+	if strings.Contains(equipmentType, "chassis") || strings.Contains(equipmentType, "container") {
+		return false
+	}
+	return module.Name != "" && (module.SerialNumber != "" || module.PartNumber != "")
+}
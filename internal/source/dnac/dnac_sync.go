@@ -1,48 +1,142 @@
 package dnac
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 	"github.com/bl4ko/netbox-ssot/internal/source/common"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
+	dnac "github.com/cisco-en-programmability/dnacenter-go-sdk/v5/sdk"
 )
 
-// Syncs dnac sites to netbox inventory.
+// Types of dnac sites within the site hierarchy (Global/Area/Building/Floor).
+const (
+	siteTypeArea     = "area"
+	siteTypeBuilding = "building"
+	siteTypeFloor    = "floor"
+)
+
+// siteLocationAttributes returns attributes from the "Location" namespace of the
+// site's additional info, which contain site's type, address and coordinates.
+func siteLocationAttributes(site dnac.ResponseSitesGetSiteResponse) dnac.ResponseSitesGetSiteResponseAdditionalInfoAttributes {
+	for _, additionalInfo := range site.AdditionalInfo {
+		if additionalInfo.Namespace == "Location" {
+			return additionalInfo.Attributes
+		}
+	}
+	return dnac.ResponseSitesGetSiteResponseAdditionalInfoAttributes{}
+}
+
+// sortSitesByHierarchy returns sites sorted by their depth in the site
+// hierarchy, so parents are always synced before their children.
+func sortSitesByHierarchy(sites map[string]dnac.ResponseSitesGetSiteResponse) []dnac.ResponseSitesGetSiteResponse {
+	sortedSites := make([]dnac.ResponseSitesGetSiteResponse, 0, len(sites))
+	for _, site := range sites {
+		sortedSites = append(sortedSites, site)
+	}
+	slices.SortFunc(sortedSites, func(a, b dnac.ResponseSitesGetSiteResponse) int {
+		if depthDiff := strings.Count(a.SiteNameHierarchy, "/") - strings.Count(b.SiteNameHierarchy, "/"); depthDiff != 0 {
+			return depthDiff
+		}
+		return strings.Compare(a.SiteNameHierarchy, b.SiteNameHierarchy)
+	})
+	return sortedSites
+}
+
+// legacyFloorSite returns netbox site of the floor with siteID, that was created
+// when floors were synced as sites instead of locations, or nil if it doesn't exist.
+func (ds *DnacSource) legacyFloorSite(nbi *inventory.NetboxInventory, siteID string) *objects.Site {
+	site, ok := ds.Sites[siteID]
+	if !ok || siteLocationAttributes(site).Type != siteTypeFloor {
+		return nil
+	}
+	return nbi.SitesIndexByName[site.Name]
+}
+
+// SyncSites syncs dnac site hierarchy to netbox. Areas are synced as nested regions,
+// buildings as sites within region of their area and floors as locations within
+// their building. Global area is the root of the hierarchy, so it is not synced.
 func (ds *DnacSource) SyncSites(nbi *inventory.NetboxInventory) error {
-	for _, site := range ds.Sites {
-		dnacSite := &objects.Site{
-			NetboxObject: objects.NetboxObject{
-				Tags: ds.Config.SourceTags,
-				CustomFields: map[string]interface{}{
-					constants.CustomFieldSourceName: ds.SourceConfig.Name,
+	for _, site := range sortSitesByHierarchy(ds.Sites) {
+		attributes := siteLocationAttributes(site)
+		switch attributes.Type {
+		case siteTypeBuilding:
+			dnacSite := &objects.Site{
+				NetboxObject: objects.NetboxObject{
+					Tags: ds.Config.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ds.SourceConfig.Name,
+					},
 				},
-			},
-			Name: site.Name,
-			Slug: utils.Slugify(site.Name),
-		}
-		for _, additionalInfo := range site.AdditionalInfo {
-			if additionalInfo.Namespace == "Location" {
-				dnacSite.PhysicalAddress = additionalInfo.Attributes.Address
-				longitude, err := strconv.ParseFloat(additionalInfo.Attributes.Longitude, 64)
-				if err != nil {
-					dnacSite.Longitude = longitude
-				}
-				latitude, err := strconv.ParseFloat(additionalInfo.Attributes.Latitude, 64)
-				if err != nil {
-					dnacSite.Latitude = latitude
-				}
+				Name:            site.Name,
+				Slug:            utils.Slugify(site.Name),
+				Region:          ds.SiteID2nbRegion[site.ParentID],
+				PhysicalAddress: attributes.Address,
 			}
+			if latitude, err := strconv.ParseFloat(attributes.Latitude, 64); err == nil {
+				dnacSite.Latitude = latitude
+			}
+			if longitude, err := strconv.ParseFloat(attributes.Longitude, 64); err == nil {
+				dnacSite.Longitude = longitude
+			}
+			nbSite, err := nbi.AddSite(ds.Ctx, dnacSite)
+			if err != nil {
+				return fmt.Errorf("adding site: %s", err)
+			}
+			ds.SiteID2nbSite[site.ID] = nbSite
+
+		case siteTypeFloor:
+			nbSite, ok := ds.SiteID2nbSite[site.ParentID]
+			if !ok {
+				ds.Logger.Warningf(ds.Ctx, "building of floor %s is not synced. Skipping...", site.SiteNameHierarchy)
+				continue
+			}
+			nbLocation, err := nbi.AddLocation(ds.Ctx, &objects.Location{
+				NetboxObject: objects.NetboxObject{
+					Tags: ds.Config.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ds.SourceConfig.Name,
+					},
+				},
+				Site:   nbSite,
+				Name:   site.Name,
+				Slug:   utils.Slugify(site.Name),
+				Status: &objects.SiteStatusActive,
+			})
+			if err != nil {
+				return fmt.Errorf("adding location: %s", err)
+			}
+			ds.SiteID2nbSite[site.ID] = nbSite
+			ds.SiteID2nbLocation[site.ID] = nbLocation
+
+		default:
+			if site.ParentID == "" || site.SiteNameHierarchy == "Global" {
+				continue
+			}
+			nbRegion, err := nbi.AddRegion(ds.Ctx, &objects.Region{
+				NetboxObject: objects.NetboxObject{
+					Tags: ds.Config.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ds.SourceConfig.Name,
+					},
+				},
+				Name:   site.Name,
+				Slug:   utils.Slugify(site.Name),
+				Parent: ds.SiteID2nbRegion[site.ParentID],
+			})
+			if err != nil {
+				return fmt.Errorf("adding region: %s", err)
+			}
+			ds.SiteID2nbRegion[site.ID] = nbRegion
 		}
-		nbSite, err := nbi.AddSite(ds.Ctx, dnacSite)
-		if err != nil {
-			return fmt.Errorf("adding site: %s", err)
-		}
-		ds.SiteID2nbSite[site.ID] = nbSite
 	}
 	return nil
 }
@@ -171,6 +265,14 @@ func (ds *DnacSource) SyncDevices(nbi *inventory.NetboxInventory) error {
 			deviceStatus = &objects.DeviceStatusOffline
 		}
 
+		// Devices on floors were previously synced to the floor sites, so we move
+		// them to their building, instead of creating duplicates
+		deviceLocation := ds.SiteID2nbLocation[ds.Device2Site[device.ID]]
+		err = nbi.MoveDevice(ds.Ctx, device.Hostname, ds.legacyFloorSite(nbi, ds.Device2Site[device.ID]), deviceSite, deviceLocation)
+		if err != nil {
+			return fmt.Errorf("moving dnac device: %s", err)
+		}
+
 		nbDevice, err := nbi.AddDevice(ds.Ctx, &objects.Device{
 			NetboxObject: objects.NetboxObject{
 				Tags:        ds.Config.SourceTags,
@@ -183,10 +285,11 @@ func (ds *DnacSource) SyncDevices(nbi *inventory.NetboxInventory) error {
 			Status:       deviceStatus,
 			Tenant:       deviceTenant,
 			DeviceRole:   deviceRole,
-			SerialNumber: device.SerialNumber,
+			SerialNumber: deviceSerialNumber(device, ds.StackMembers[device.ID]),
 			Platform:     platform,
 			Comments:     comments,
 			Site:         deviceSite,
+			Location:     deviceLocation,
 			DeviceType:   deviceType,
 		})
 
@@ -199,10 +302,187 @@ func (ds *DnacSource) SyncDevices(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// SyncStacks groups members of each switch stack into a virtual chassis.
+// Master member of the stack (see stackMasterNumber) keeps the device created
+// in SyncDevices, while a new device is created for every other member.
+func (ds *DnacSource) SyncStacks(nbi *inventory.NetboxInventory) error {
+	for deviceID, stackMembers := range ds.StackMembers {
+		if len(stackMembers) < 2 { //nolint:gomnd
+			continue
+		}
+		nbDevice, ok := ds.DeviceID2nbDevice[deviceID]
+		if !ok {
+			continue
+		}
+		masterNumber, ok := stackMasterNumber(stackMembers)
+		if !ok {
+			ds.Logger.Warningf(ds.Ctx, "stack members of device %s have no member numbers. Skipping...", nbDevice.Name)
+			continue
+		}
+		vcMembers := make([]common.VirtualChassisMember, 0, len(stackMembers))
+		for _, stackMember := range stackMembers {
+			if stackMember.StackMemberNumber == nil {
+				ds.Logger.Warningf(ds.Ctx, "stack member %s of device %s has no member number. Skipping...", stackMember.SerialNumber, nbDevice.Name)
+				continue
+			}
+			memberNumber := *stackMember.StackMemberNumber
+			var memberPriority int
+			if stackMember.SwitchPriority != nil {
+				memberPriority = *stackMember.SwitchPriority
+			}
+			isActive := memberNumber == masterNumber
+
+			memberDevice := &objects.Device{
+				NetboxObject: objects.NetboxObject{
+					Tags:        ds.Config.SourceTags,
+					Description: nbDevice.Description,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ds.SourceConfig.Name,
+					},
+				},
+				Name:         fmt.Sprintf("%s-%d", nbDevice.Name, memberNumber),
+				Status:       nbDevice.Status,
+				Tenant:       nbDevice.Tenant,
+				DeviceRole:   nbDevice.DeviceRole,
+				SerialNumber: stackMember.SerialNumber,
+				Platform:     nbDevice.Platform,
+				Site:         nbDevice.Site,
+				Location:     nbDevice.Location,
+				DeviceType:   nbDevice.DeviceType,
+			}
+			if isActive {
+				// Active member represents the whole stack
+				memberDeviceCopy := *nbDevice
+				memberDeviceCopy.SerialNumber = stackMember.SerialNumber
+				memberDevice = &memberDeviceCopy
+			} else if stackMember.PlatformID != "" {
+				memberDeviceType, err := nbi.AddDeviceType(ds.Ctx, &objects.DeviceType{
+					Manufacturer: nbDevice.DeviceType.Manufacturer,
+					Model:        stackMember.PlatformID,
+					Slug:         utils.Slugify(stackMember.PlatformID),
+				})
+				if err != nil {
+					return fmt.Errorf("add device type: %s", err)
+				}
+				memberDevice.DeviceType = memberDeviceType
+			}
+			if !isActive {
+				err := nbi.MoveDevice(ds.Ctx, memberDevice.Name, ds.legacyFloorSite(nbi, ds.Device2Site[deviceID]), memberDevice.Site, memberDevice.Location)
+				if err != nil {
+					return fmt.Errorf("moving stack member %d of %s: %s", memberNumber, nbDevice.Name, err)
+				}
+				memberDevice, err = nbi.AddDevice(ds.Ctx, memberDevice)
+				if err != nil {
+					return fmt.Errorf("adding stack member %d of %s: %s", memberNumber, nbDevice.Name, err)
+				}
+			}
+			vcMembers = append(vcMembers, common.VirtualChassisMember{
+				Device:   memberDevice,
+				Position: memberNumber,
+				Priority: memberPriority,
+				Master:   isActive,
+			})
+		}
+
+		syncedMembers, err := common.SyncVirtualChassis(ds.Ctx, nbi, ds.Config.SourceTags, ds.SourceConfig.Name, nbDevice.Name, "", vcMembers)
+		if err != nil {
+			return fmt.Errorf("sync stack %s: %s", nbDevice.Name, err)
+		}
+		ds.DeviceID2nbStackMembers[deviceID] = syncedMembers
+		for _, vcMember := range vcMembers {
+			if vcMember.Master {
+				ds.DeviceID2nbDevice[deviceID] = syncedMembers[vcMember.Position]
+			}
+		}
+	}
+	return nil
+}
+
+// stackMasterNumber returns member number of the stack member, which represents the
+// whole stack. That is the active member, or the member with the lowest number if
+// no member is active, so the master is deterministic.
+func stackMasterNumber(stackMembers []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo) (int, bool) {
+	masterNumber, ok := 0, false
+	for _, stackMember := range stackMembers {
+		if stackMember.StackMemberNumber == nil {
+			continue
+		}
+		if strings.EqualFold(stackMember.Role, "active") {
+			return *stackMember.StackMemberNumber, true
+		}
+		if !ok || *stackMember.StackMemberNumber < masterNumber {
+			masterNumber, ok = *stackMember.StackMemberNumber, true
+		}
+	}
+	return masterNumber, ok
+}
+
+// deviceSerialNumber returns serial number of the device. Stacks are reported
+// with comma separated serial numbers of all members, so serial number of the
+// stack master is used instead, which is also set in SyncStacks.
+func deviceSerialNumber(device dnac.ResponseDevicesGetDeviceListResponse, stackMembers []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo) string {
+	if len(stackMembers) < 2 { //nolint:gomnd
+		return device.SerialNumber
+	}
+	masterNumber, ok := stackMasterNumber(stackMembers)
+	if !ok {
+		return device.SerialNumber
+	}
+	for _, stackMember := range stackMembers {
+		if stackMember.StackMemberNumber != nil && *stackMember.StackMemberNumber == masterNumber {
+			return stackMember.SerialNumber
+		}
+	}
+	return device.SerialNumber
+}
+
+// stackMemberRegex matches the member number of stacked switch interfaces, e.g. 2 in GigabitEthernet2/0/1.
+var stackMemberRegex = regexp.MustCompile(`^\D+(\d+)/\d+/\d+`)
+
+// stackMemberNumber returns stack member number from the interface name.
+// If the name doesn't contain member number, false is returned.
+func stackMemberNumber(ifaceName string) (int, bool) {
+	match := stackMemberRegex.FindStringSubmatch(ifaceName)
+	if len(match) < 2 { //nolint:gomnd
+		return 0, false
+	}
+	memberNumber, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return memberNumber, true
+}
+
+// stackSwitchRegex matches the member number of stacked switch modules, e.g. 2 in "Switch 2 - Power Supply A".
+var stackSwitchRegex = regexp.MustCompile(`^Switch (\d+)\b`)
+
+// moduleStackMemberNumber returns stack member number from the module name,
+// which is either prefixed with the switch number or named after the interface
+// (transceivers). If the name doesn't contain member number, false is returned.
+func moduleStackMemberNumber(moduleName string) (int, bool) {
+	match := stackSwitchRegex.FindStringSubmatch(moduleName)
+	if len(match) < 2 { //nolint:gomnd
+		return stackMemberNumber(moduleName)
+	}
+	memberNumber, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return memberNumber, true
+}
+
 func (ds *DnacSource) SyncDeviceInterfaces(nbi *inventory.NetboxInventory) error {
 	for ifaceID, iface := range ds.Interfaces {
 		ifaceDescription := iface.Description
 		ifaceDevice := ds.DeviceID2nbDevice[iface.DeviceID]
+		// Interfaces of switch stacks are assigned to the member they belong to
+		if stackMembers, ok := ds.DeviceID2nbStackMembers[iface.DeviceID]; ok {
+			if memberNumber, ok := stackMemberNumber(iface.PortName); ok {
+				if memberDevice, ok := stackMembers[memberNumber]; ok {
+					ifaceDevice = memberDevice
+				}
+			}
+		}
 		var ifaceDuplex *objects.InterfaceDuplex
 		switch iface.Duplex {
 		case "FullDuplex":
@@ -364,3 +644,303 @@ func (ds *DnacSource) SyncDeviceInterfaces(nbi *inventory.NetboxInventory) error
 	}
 	return nil
 }
+
+// SyncModules syncs hardware modules of devices either as modules (with module
+// bays and module types) or as inventory items, depending on syncModulesAs.
+// Modules of switch stacks are assigned to the member they belong to.
+func (ds *DnacSource) SyncModules(nbi *inventory.NetboxInventory) error {
+	for deviceID, modules := range ds.DeviceID2Modules {
+		nbDevice, ok := ds.DeviceID2nbDevice[deviceID]
+		if !ok {
+			continue
+		}
+		for _, module := range modules {
+			moduleDevice := nbDevice
+			if stackMembers, ok := ds.DeviceID2nbStackMembers[deviceID]; ok {
+				if memberNumber, ok := moduleStackMemberNumber(module.Name); ok {
+					if memberDevice, ok := stackMembers[memberNumber]; ok {
+						moduleDevice = memberDevice
+					}
+				}
+			}
+			var err error
+			switch ds.SourceConfig.SyncModulesAs {
+			case parser.ModuleObjectModules:
+				err = ds.syncModule(nbi, moduleDevice, module)
+			case parser.ModuleObjectInventoryItems:
+				err = ds.syncInventoryItem(nbi, moduleDevice, module)
+			}
+			if err != nil {
+				return fmt.Errorf("sync module %s of device %s: %s", module.Name, moduleDevice.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// syncModule syncs hardware module as a module installed in the module bay
+// of the same name. Modules without part number are skipped, because part
+// number is used as the model of the module type.
+func (ds *DnacSource) syncModule(nbi *inventory.NetboxInventory, nbDevice *objects.Device, module dnac.ResponseDevicesGetModulesResponse) error {
+	if module.PartNumber == "" {
+		ds.Logger.Debugf(ds.Ctx, "module %s of device %s has no part number. Skipping...", module.Name, nbDevice.Name)
+		return nil
+	}
+	moduleType, err := nbi.AddModuleType(ds.Ctx, &objects.ModuleType{
+		NetboxObject: objects.NetboxObject{Tags: ds.SourceTags},
+		Manufacturer: nbDevice.DeviceType.Manufacturer,
+		Model:        module.PartNumber,
+		PartNumber:   module.PartNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("add module type %s: %s", module.PartNumber, err)
+	}
+	moduleBay, err := nbi.AddModuleBay(ds.Ctx, &objects.ModuleBay{
+		NetboxObject: objects.NetboxObject{Tags: ds.SourceTags},
+		Device:       nbDevice,
+		Name:         module.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("add module bay: %s", err)
+	}
+	_, err = nbi.AddModule(ds.Ctx, &objects.Module{
+		NetboxObject: objects.NetboxObject{
+			Tags:        ds.SourceTags,
+			Description: moduleDescription(module),
+		},
+		Device:     nbDevice,
+		ModuleBay:  moduleBay,
+		ModuleType: moduleType,
+		Status:     &objects.ModuleStatusActive,
+		Serial:     module.SerialNumber,
+	})
+	return err
+}
+
+// syncInventoryItem syncs hardware module as an inventory item of the device.
+func (ds *DnacSource) syncInventoryItem(nbi *inventory.NetboxInventory, nbDevice *objects.Device, module dnac.ResponseDevicesGetModulesResponse) error {
+	_, err := nbi.AddInventoryItem(ds.Ctx, &objects.InventoryItem{
+		NetboxObject: objects.NetboxObject{
+			Tags:        ds.SourceTags,
+			Description: moduleDescription(module),
+		},
+		Device:       nbDevice,
+		Name:         module.Name,
+		Manufacturer: nbDevice.DeviceType.Manufacturer,
+		PartID:       module.PartNumber,
+		Serial:       module.SerialNumber,
+		Discovered:   true,
+	})
+	return err
+}
+
+// moduleDescription returns description of the module, if it fits into netbox's description.
+func moduleDescription(module dnac.ResponseDevicesGetModulesResponse) string {
+	if len(module.Description) > objects.MaxDescriptionLength {
+		return ""
+	}
+	return module.Description
+}
+
+// SyncFHRPGroups syncs HSRP and VRRP groups configured on device interfaces.
+// Virtual ips inherit the mask of the interface's ip address.
+func (ds *DnacSource) SyncFHRPGroups(nbi *inventory.NetboxInventory) error {
+	for ifaceID, nbIface := range ds.InterfaceID2nbInterface {
+		iface := ds.Interfaces[ifaceID]
+		fhrpGroups := ds.DeviceID2FHRPGroups[iface.DeviceID][iface.PortName]
+		if len(fhrpGroups) == 0 {
+			continue
+		}
+		maskBits := 32 //nolint:gomnd
+		if iface.IPv4Mask != "" {
+			ifaceMaskBits, err := utils.MaskToBits(iface.IPv4Mask)
+			if err != nil {
+				return fmt.Errorf("wrong mask: %s", err)
+			}
+			maskBits = ifaceMaskBits
+		}
+		for _, fhrpGroup := range fhrpGroups {
+			virtualIPs := make([]string, 0, len(fhrpGroup.VirtualIPs))
+			for _, virtualIP := range fhrpGroup.VirtualIPs {
+				if utils.SubnetsContainIPAddress(virtualIP, ds.SourceConfig.IgnoredSubnets) {
+					continue
+				}
+				if !strings.Contains(virtualIP, "/") {
+					virtualIP = fmt.Sprintf("%s/%d", virtualIP, maskBits)
+				}
+				virtualIPs = append(virtualIPs, virtualIP)
+			}
+			if len(virtualIPs) == 0 {
+				continue
+			}
+			var tenant *objects.Tenant
+			if nbIface.Device != nil {
+				tenant = nbIface.Device.Tenant
+			}
+			_, err := common.SyncFHRPGroup(ds.Ctx, nbi, ds.Config.SourceTags, ds.SourceConfig.Name, fhrpGroup.Protocol, fhrpGroup.GroupID, virtualIPs, tenant, nil, []common.FHRPGroupMember{
+				{Interface: nbIface, Priority: fhrpGroup.Priority},
+			})
+			if err != nil {
+				return fmt.Errorf("sync fhrp group %s %d on %s: %s", fhrpGroup.Protocol, fhrpGroup.GroupID, iface.PortName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SyncBGP syncs bgp configuration of devices parsed from their running configs.
+func (ds *DnacSource) SyncBGP(nbi *inventory.NetboxInventory) error {
+	for deviceID, bgpConfig := range ds.DeviceID2BGPConfig {
+		nbDevice, ok := ds.DeviceID2nbDevice[deviceID]
+		if !ok {
+			continue
+		}
+		bgpConfigs := make([]common.BGPConfig, 0, 1)
+		if bgpConfig != nil {
+			bgpConfigs = append(bgpConfigs, *bgpConfig)
+		}
+		err := common.SyncBGP(ds.Ctx, nbi, ds.SourceTags, nbDevice, bgpConfigs)
+		if err != nil {
+			return fmt.Errorf("sync bgp of device %s: %s", nbDevice.Name, err)
+		}
+	}
+	return nil
+}
+
+// SyncWireless stores uplink switch port and associated wireless controller of
+// access points in their custom fields, and syncs enterprise ssids as wireless lans.
+func (ds *DnacSource) SyncWireless(nbi *inventory.NetboxInventory) error {
+	if err := ds.syncAccessPoints(nbi); err != nil {
+		return err
+	}
+	for _, ssid := range ds.SSIDs {
+		authType, authCipher := wirelessAuth(ssid.SecurityLevel)
+		var vlan *objects.Vlan
+		if vid, ok := ds.SSID2VID[ssid.Name]; ok {
+			vlan = ds.VID2nbVlan[vid]
+		}
+		var description string
+		if ssid.WLANType != "" {
+			description = fmt.Sprintf("%s ssid", ssid.WLANType)
+		}
+		_, err := nbi.AddWirelessLAN(ds.Ctx, &objects.WirelessLAN{
+			NetboxObject: objects.NetboxObject{
+				Tags:        ds.SourceTags,
+				Description: description,
+			},
+			SSID:       ssid.Name,
+			Vlan:       vlan,
+			AuthType:   authType,
+			AuthCipher: authCipher,
+		})
+		if err != nil {
+			return fmt.Errorf("sync ssid %s: %s", ssid.Name, err)
+		}
+	}
+	return nil
+}
+
+// syncAccessPoints stores uplink switch port and associated wireless controller
+// of access points in their custom fields. Custom fields are cleared, when
+// dnac no longer reports them.
+func (ds *DnacSource) syncAccessPoints(nbi *inventory.NetboxInventory) error {
+	managementIP2Hostname := make(map[string]string, len(ds.Devices))
+	for _, device := range ds.Devices {
+		if device.ManagementIPAddress != "" {
+			managementIP2Hostname[device.ManagementIPAddress] = device.Hostname
+		}
+	}
+	customFieldsAdded := false
+	for deviceID, device := range ds.Devices {
+		if device.Family != familyAccessPoint {
+			continue
+		}
+		nbDevice, ok := ds.DeviceID2nbDevice[deviceID]
+		if !ok {
+			continue
+		}
+		customFields := map[string]interface{}{
+			constants.CustomFieldAPUplinkName:           nil,
+			constants.CustomFieldWirelessControllerName: nil,
+		}
+		if uplink, ok := ds.AccessPointID2Uplink[deviceID]; ok {
+			customFields[constants.CustomFieldAPUplinkName] = uplink
+		}
+		if device.AssociatedWlcIP != "" {
+			wirelessController := device.AssociatedWlcIP
+			if hostname, ok := managementIP2Hostname[device.AssociatedWlcIP]; ok {
+				wirelessController = hostname
+			}
+			customFields[constants.CustomFieldWirelessControllerName] = wirelessController
+		}
+		if !customFieldsAdded {
+			if err := addAccessPointCustomFields(ds.Ctx, nbi); err != nil {
+				return err
+			}
+			customFieldsAdded = true
+		}
+		if _, err := nbi.AddDeviceCustomFields(ds.Ctx, nbDevice, customFields); err != nil {
+			return fmt.Errorf("add custom fields of access point %s: %s", nbDevice.Name, err)
+		}
+	}
+	return nil
+}
+
+// addAccessPointCustomFields adds custom fields for storing uplink and wireless
+// controller of access points.
+func addAccessPointCustomFields(ctx context.Context, nbi *inventory.NetboxInventory) error {
+	customFields := []*objects.CustomField{
+		{
+			Name:        constants.CustomFieldAPUplinkName,
+			Label:       constants.CustomFieldAPUplinkLabel,
+			Description: constants.CustomFieldAPUplinkDescription,
+		},
+		{
+			Name:        constants.CustomFieldWirelessControllerName,
+			Label:       constants.CustomFieldWirelessControllerLabel,
+			Description: constants.CustomFieldWirelessControllerDescription,
+		},
+	}
+	for _, customField := range customFields {
+		customField.Type = objects.CustomFieldTypeText
+		customField.FilterLogic = objects.FilterLogicLoose
+		customField.CustomFieldUIVisible = &objects.CustomFieldUIVisibleAlways
+		customField.CustomFieldUIEditable = &objects.CustomFieldUIEditableYes
+		customField.DisplayWeight = objects.DisplayWeightDefault
+		customField.SearchWeight = objects.SearchWeightDefault
+		customField.ContentTypes = []string{constants.ContentTypeDcimDevice}
+		if _, err := nbi.AddCustomField(ctx, customField); err != nil {
+			return fmt.Errorf("add custom field %s: %s", customField.Name, err)
+		}
+	}
+	return nil
+}
+
+// wirelessAuth maps dnac ssid security level (e.g. "WPA2_ENTERPRISE")
+// to netbox's wireless authentication type and cipher.
+func wirelessAuth(securityLevel string) (*objects.WirelessAuthType, *objects.WirelessAuthCipher) {
+	securityLevel = strings.ToUpper(securityLevel)
+	switch {
+	case strings.Contains(securityLevel, "ENTERPRISE"):
+		return &objects.WirelessAuthTypeWPAEnterprise, &objects.WirelessAuthCipherAES
+	case strings.Contains(securityLevel, "PERSONAL"):
+		return &objects.WirelessAuthTypeWPAPersonal, &objects.WirelessAuthCipherAES
+	case strings.Contains(securityLevel, "OPEN"):
+		return &objects.WirelessAuthTypeOpen, nil
+	default:
+		return nil, nil
+	}
+}
+
+// SyncArpTable syncs ip and mac addresses of hosts from dnac host inventory.
+func (ds *DnacSource) SyncArpTable(nbi *inventory.NetboxInventory) error {
+	if !ds.SourceConfig.CollectArpData {
+		ds.Logger.Info(ds.Ctx, "skipping collecting of arp data")
+		return nil
+	}
+	arpEntries := make([]common.ArpEntry, 0, len(ds.Hosts))
+	for _, host := range ds.Hosts {
+		arpEntries = append(arpEntries, common.ArpEntry{IP: host.HostIP, MAC: host.HostMAC})
+	}
+	return common.SyncArpTable(ds.Ctx, nbi, ds.SourceTags, ds.SourceConfig.Name, arpEntries, nil)
+}
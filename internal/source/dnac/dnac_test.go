@@ -1 +1,401 @@
 package dnac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	dnac "github.com/cisco-en-programmability/dnacenter-go-sdk/v5/sdk"
+)
+
+func TestStackMemberNumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifaceName  string
+		want       int
+		wantExists bool
+	}{
+		{
+			name:       "Interface on second member",
+			ifaceName:  "GigabitEthernet2/0/1",
+			want:       2,
+			wantExists: true,
+		},
+		{
+			name:       "Uplink interface on first member",
+			ifaceName:  "TenGigabitEthernet1/1/4",
+			want:       1,
+			wantExists: true,
+		},
+		{
+			name:       "Interface without member number",
+			ifaceName:  "GigabitEthernet0/1",
+			wantExists: false,
+		},
+		{
+			name:       "Virtual interface",
+			ifaceName:  "Vlan10",
+			wantExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exists := stackMemberNumber(tt.ifaceName)
+			if exists != tt.wantExists {
+				t.Errorf("stackMemberNumber() exists = %v, want %v", exists, tt.wantExists)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("stackMemberNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFHRPGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   map[string][]*FHRPGroupConfig
+	}{
+		{
+			name: "HSRP and VRRP groups",
+			config: `hostname test
+!
+interface Vlan10
+ ip address 10.0.10.2 255.255.255.0
+ standby version 2
+ standby 10 ip 10.0.10.1
+ standby 10 priority 110
+ standby 10 preempt
+!
+interface Vlan20
+ ip address 10.0.20.2 255.255.255.0
+ standby ip 10.0.20.1
+ vrrp 20 ip 10.0.20.254
+!
+interface Vlan30
+ ip address 10.0.30.2 255.255.255.0
+ vrrp 30 address-family ipv4
+  address 10.0.30.1 primary
+  priority 120
+ exit-vrrp
+ no shutdown
+!
+interface GigabitEthernet1/0/1
+ switchport mode access
+!
+router ospf 1
+ standby 1 ip 10.0.0.1
+`,
+			want: map[string][]*FHRPGroupConfig{
+				"Vlan10": {
+					{Protocol: &objects.FHRPGroupProtocolHSRP, GroupID: 10, VirtualIPs: []string{"10.0.10.1"}, Priority: 110},
+				},
+				"Vlan20": {
+					{Protocol: &objects.FHRPGroupProtocolHSRP, GroupID: 0, VirtualIPs: []string{"10.0.20.1"}, Priority: defaultFHRPPriority},
+					{Protocol: &objects.FHRPGroupProtocolVRRP2, GroupID: 20, VirtualIPs: []string{"10.0.20.254"}, Priority: defaultFHRPPriority},
+				},
+				"Vlan30": {
+					{Protocol: &objects.FHRPGroupProtocolVRRP3, GroupID: 30, VirtualIPs: []string{"10.0.30.1"}, Priority: 120},
+				},
+			},
+		},
+		{
+			name:   "Config without fhrp groups",
+			config: "interface Vlan10\n ip address 10.0.10.2 255.255.255.0\n!\n",
+			want:   map[string][]*FHRPGroupConfig{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFHRPGroups(tt.config); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFHRPGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBGPConfig(t *testing.T) {
+	ifaceIPs := map[string]string{"Loopback0": "10.255.0.1"}
+	ifaceIP := func(ifaceName string) string { return ifaceIPs[ifaceName] }
+	tests := []struct {
+		name   string
+		config string
+		want   *common.BGPConfig
+	}{
+		{
+			name: "Neighbors with peer groups and address families",
+			config: `hostname test
+!
+router bgp 65001
+ bgp router-id 10.255.0.1
+ bgp log-neighbor-changes
+ neighbor IBGP peer-group
+ neighbor IBGP remote-as 65001
+ neighbor IBGP update-source Loopback0
+ neighbor 10.255.0.2 peer-group IBGP
+ neighbor 10.0.0.2 remote-as 1.10
+ neighbor 10.0.0.2 description isp1
+ !
+ address-family ipv4 vrf CUST
+  neighbor 172.16.0.1 remote-as 65100
+  neighbor 172.16.0.1 activate
+ exit-address-family
+!
+line vty 0 4
+ neighbor 10.0.0.9 remote-as 65009
+`,
+			want: &common.BGPConfig{
+				LocalAS:  65001,
+				RouterID: "10.255.0.1",
+				Peers: []common.BGPPeer{
+					{RemoteAddress: "10.255.0.2", RemoteAS: 65001, LocalAddress: "10.255.0.1"},
+					{RemoteAddress: "10.0.0.2", RemoteAS: 65546},
+					{RemoteAddress: "172.16.0.1", RemoteAS: 65100},
+				},
+			},
+		},
+		{
+			name:   "Config without bgp",
+			config: "interface Vlan10\n ip address 10.0.10.2 255.255.255.0\n!\n",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseBGPConfig(tt.config, ifaceIP); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBGPConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortSitesByHierarchy(t *testing.T) {
+	sites := map[string]dnac.ResponseSitesGetSiteResponse{
+		"floor":    {ID: "floor", Name: "Floor 1", SiteNameHierarchy: "Global/EU/Ljubljana/HQ/Floor 1"},
+		"global":   {ID: "global", Name: "Global", SiteNameHierarchy: "Global"},
+		"building": {ID: "building", Name: "HQ", SiteNameHierarchy: "Global/EU/Ljubljana/HQ"},
+		"eu":       {ID: "eu", Name: "EU", SiteNameHierarchy: "Global/EU"},
+		"city":     {ID: "city", Name: "Ljubljana", SiteNameHierarchy: "Global/EU/Ljubljana"},
+		"us":       {ID: "us", Name: "US", SiteNameHierarchy: "Global/US"},
+	}
+	want := []string{"global", "eu", "us", "city", "building", "floor"}
+	got := make([]string, 0, len(sites))
+	for _, site := range sortSitesByHierarchy(sites) {
+		got = append(got, site.ID)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortSitesByHierarchy() = %v, want %v", got, want)
+	}
+}
+
+func TestSiteLocationAttributes(t *testing.T) {
+	attributes := dnac.ResponseSitesGetSiteResponseAdditionalInfoAttributes{Type: siteTypeBuilding, Address: "Main street 1", Latitude: "46.05", Longitude: "14.50"}
+	site := dnac.ResponseSitesGetSiteResponse{
+		Name: "HQ",
+		AdditionalInfo: []dnac.ResponseSitesGetSiteResponseAdditionalInfo{
+			{Namespace: "wlan"},
+			{Namespace: "Location", Attributes: attributes},
+		},
+	}
+	if got := siteLocationAttributes(site); !reflect.DeepEqual(got, attributes) {
+		t.Errorf("siteLocationAttributes() = %+v, want %+v", got, attributes)
+	}
+}
+
+func TestLegacyFloorSite(t *testing.T) {
+	floorAttributes := dnac.ResponseSitesGetSiteResponseAdditionalInfoAttributes{Type: siteTypeFloor}
+	buildingAttributes := dnac.ResponseSitesGetSiteResponseAdditionalInfoAttributes{Type: siteTypeBuilding}
+	floorSite := &objects.Site{NetboxObject: objects.NetboxObject{ID: 1}, Name: "Floor 1"}
+	buildingSite := &objects.Site{NetboxObject: objects.NetboxObject{ID: 2}, Name: "HQ"}
+	ds := &DnacSource{
+		Sites: map[string]dnac.ResponseSitesGetSiteResponse{
+			"floor1":   {ID: "floor1", Name: "Floor 1", AdditionalInfo: []dnac.ResponseSitesGetSiteResponseAdditionalInfo{{Namespace: "Location", Attributes: floorAttributes}}},
+			"floor2":   {ID: "floor2", Name: "Floor 2", AdditionalInfo: []dnac.ResponseSitesGetSiteResponseAdditionalInfo{{Namespace: "Location", Attributes: floorAttributes}}},
+			"building": {ID: "building", Name: "HQ", AdditionalInfo: []dnac.ResponseSitesGetSiteResponseAdditionalInfo{{Namespace: "Location", Attributes: buildingAttributes}}},
+		},
+	}
+	nbi := &inventory.NetboxInventory{
+		SitesIndexByName: map[string]*objects.Site{floorSite.Name: floorSite, buildingSite.Name: buildingSite},
+	}
+	tests := []struct {
+		name   string
+		siteID string
+		want   *objects.Site
+	}{
+		{name: "Floor synced as site", siteID: "floor1", want: floorSite},
+		{name: "Floor not synced as site", siteID: "floor2", want: nil},
+		{name: "Building", siteID: "building", want: nil},
+		{name: "Unknown site", siteID: "unknown", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ds.legacyFloorSite(nbi, tt.siteID); got != tt.want {
+				t.Errorf("legacyFloorSite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSIDVlanIDs(t *testing.T) {
+	enabled, disabled := true, false
+	localVID := 30
+	profiles := []dnac.ResponseItemWirelessGetWirelessProfile{
+		{ProfileDetails: &dnac.ResponseItemWirelessGetWirelessProfileProfileDetails{
+			Name: "Campus",
+			SSIDDetails: &[]dnac.ResponseItemWirelessGetWirelessProfileProfileDetailsSSIDDetails{
+				{Name: "corp", InterfaceName: "corp-clients"},
+				{Name: "guest", InterfaceName: "management", FlexConnect: &dnac.ResponseItemWirelessGetWirelessProfileProfileDetailsSSIDDetailsFlexConnect{EnableFlexConnect: &disabled, LocalToVLAN: &localVID}},
+				{Name: "iot", InterfaceName: "unknown"},
+			},
+		}},
+		{ProfileDetails: &dnac.ResponseItemWirelessGetWirelessProfileProfileDetails{
+			Name: "Branch",
+			SSIDDetails: &[]dnac.ResponseItemWirelessGetWirelessProfileProfileDetailsSSIDDetails{
+				{Name: "corp", InterfaceName: "management"},
+				{Name: "branch", FlexConnect: &dnac.ResponseItemWirelessGetWirelessProfileProfileDetailsSSIDDetailsFlexConnect{EnableFlexConnect: &enabled, LocalToVLAN: &localVID}},
+			},
+		}},
+		{},
+	}
+	dynamicInterfaces := map[string]int{"corp-clients": 10, "management": 20}
+	want := map[string]int{"corp": 10, "guest": 20, "branch": 30}
+	if got := ssidVlanIDs(profiles, dynamicInterfaces); !reflect.DeepEqual(got, want) {
+		t.Errorf("ssidVlanIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestAccessPointUplinks(t *testing.T) {
+	devices := map[string]dnac.ResponseDevicesGetDeviceListResponse{
+		"ap1": {Hostname: "ap01", Family: familyAccessPoint},
+		"ap2": {Hostname: "ap02", Family: familyAccessPoint},
+		"sw1": {Hostname: "sw01", Family: "Switches and Hubs"},
+		"wlc": {Hostname: "wlc01", Family: familyWirelessController},
+	}
+	links := []dnac.ResponseTopologyGetPhysicalTopologyResponseLinks{
+		{Source: "ap1", Target: "sw1", StartPortName: "GigabitEthernet0", EndPortName: "GigabitEthernet1/0/5"},
+		{Source: "sw1", Target: "ap2", StartPortName: "GigabitEthernet1/0/6", EndPortName: "GigabitEthernet0"},
+		{Source: "sw1", Target: "wlc", StartPortName: "TenGigabitEthernet1/1/1", EndPortName: "TenGigabitEthernet0/0/0"},
+		{Source: "ap1", Target: "unknown", StartPortName: "GigabitEthernet1", EndPortName: "eth0"},
+	}
+	want := map[string]string{
+		"ap1": "sw01 (GigabitEthernet1/0/5)",
+		"ap2": "sw01 (GigabitEthernet1/0/6)",
+	}
+	if got := accessPointUplinks(devices, links); !reflect.DeepEqual(got, want) {
+		t.Errorf("accessPointUplinks() = %v, want %v", got, want)
+	}
+}
+
+func TestWirelessAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		securityLevel string
+		wantType      *objects.WirelessAuthType
+		wantCipher    *objects.WirelessAuthCipher
+	}{
+		{name: "WPA2 enterprise", securityLevel: "WPA2_ENTERPRISE", wantType: &objects.WirelessAuthTypeWPAEnterprise, wantCipher: &objects.WirelessAuthCipherAES},
+		{name: "WPA2 and WPA3 personal", securityLevel: "wpa2_wpa3_personal", wantType: &objects.WirelessAuthTypeWPAPersonal, wantCipher: &objects.WirelessAuthCipherAES},
+		{name: "Open", securityLevel: "OPEN", wantType: &objects.WirelessAuthTypeOpen},
+		{name: "Unknown", securityLevel: "WEB_AUTH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotCipher := wirelessAuth(tt.securityLevel)
+			if !reflect.DeepEqual(gotType, tt.wantType) || !reflect.DeepEqual(gotCipher, tt.wantCipher) {
+				t.Errorf("wirelessAuth() = %v, %v, want %v, %v", gotType, gotCipher, tt.wantType, tt.wantCipher)
+			}
+		})
+	}
+}
+
+func TestModuleStackMemberNumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		moduleName string
+		want       int
+		wantExists bool
+	}{
+		{name: "Power supply of second member", moduleName: "Switch 2 - Power Supply A", want: 2, wantExists: true},
+		{name: "Network module of first member", moduleName: "Switch 1 FRU Uplink Module 1", want: 1, wantExists: true},
+		{name: "Transceiver on third member", moduleName: "TenGigabitEthernet3/1/1", want: 3, wantExists: true},
+		{name: "Module without member number", moduleName: "Power Supply Module 0", wantExists: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exists := moduleStackMemberNumber(tt.moduleName)
+			if got != tt.want || exists != tt.wantExists {
+				t.Errorf("moduleStackMemberNumber() = %d, %t, want %d, %t", got, exists, tt.want, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestIsHardwareModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		module dnac.ResponseDevicesGetModulesResponse
+		want   bool
+	}{
+		{name: "Power supply", module: dnac.ResponseDevicesGetModulesResponse{Name: "Switch 1 - Power Supply A", PartNumber: "PWR-C1-715WAC-P", SerialNumber: "ART1234ABCD", VendorEquipmentType: "cevPowerSupplyAC715W"}, want: true},
+		{name: "Transceiver without part number", module: dnac.ResponseDevicesGetModulesResponse{Name: "TenGigabitEthernet1/1/1", SerialNumber: "FNS1234ABCD", VendorEquipmentType: "cevSfp10GBaseSR"}, want: true},
+		{name: "Chassis", module: dnac.ResponseDevicesGetModulesResponse{Name: "Switch 1", PartNumber: "C9300-48P", SerialNumber: "FOC1234ABCD", VendorEquipmentType: "cevChassisC930048P"}, want: false},
+		{name: "Empty slot", module: dnac.ResponseDevicesGetModulesResponse{Name: "Switch 1 - Slot 1", VendorEquipmentType: "cevContainerSlot"}, want: false},
+		{name: "Module without serial and part number", module: dnac.ResponseDevicesGetModulesResponse{Name: "Switch 1 - Fan 1", VendorEquipmentType: "cevFanTray"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHardwareModule(tt.module); got != tt.want {
+				t.Errorf("isHardwareModule() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStackMasterNumber(t *testing.T) {
+	one, two, three := 1, 2, 3
+	tests := []struct {
+		name         string
+		stackMembers []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo
+		want         int
+		wantExists   bool
+	}{
+		{
+			name: "Active member is master",
+			stackMembers: []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo{
+				{StackMemberNumber: &one, Role: "STANDBY"},
+				{StackMemberNumber: &two, Role: "ACTIVE"},
+				{StackMemberNumber: &three, Role: "MEMBER"},
+			},
+			want:       2,
+			wantExists: true,
+		},
+		{
+			name: "Lowest member is master without active member",
+			stackMembers: []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo{
+				{StackMemberNumber: &three, Role: "MEMBER"},
+				{StackMemberNumber: &two, Role: "STANDBY"},
+			},
+			want:       2,
+			wantExists: true,
+		},
+		{
+			name: "Members without member numbers",
+			stackMembers: []dnac.ResponseDevicesGetStackDetailsForDeviceResponseStackSwitchInfo{
+				{Role: "ACTIVE"},
+			},
+			wantExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exists := stackMasterNumber(tt.stackMembers)
+			if got != tt.want || exists != tt.wantExists {
+				t.Errorf("stackMasterNumber() = %d, %t, want %d, %t", got, exists, tt.want, tt.wantExists)
+			}
+		})
+	}
+}
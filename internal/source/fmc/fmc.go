@@ -19,10 +19,17 @@ type FMCSource struct {
 	common.Config
 
 	// FMC data. Initialized in init functions.
-	Domains              map[string]*Domain
-	Devices              map[string]*DeviceInfo
-	DevicePhysicalIfaces map[string][]*PhysicalInterfaceInfo
-	DeviceVlanIfaces     map[string][]*VLANInterfaceInfo
+	Domains                  map[string]*Domain
+	Devices                  map[string]*DeviceInfo
+	DevicePhysicalIfaces     map[string][]*PhysicalInterfaceInfo
+	DeviceVlanIfaces         map[string][]*VLANInterfaceInfo
+	DeviceEtherChannelIfaces map[string][]*EtherChannelInterfaceInfo
+	DeviceSubIfaces          map[string][]*SubInterfaceInfo
+	DeviceRedundantIfaces    map[string][]*RedundantInterfaceInfo
+	SecurityZones            map[string]*SecurityZone // security zone id -> security zone
+	HAPairs                  map[string]*DeviceHAPair
+	// Device id -> arp entries of the device. Collected only if collectArpData is enabled.
+	DeviceArpEntries map[string][]common.ArpEntry
 
 	// Netbox devices representing firewalls.
 	NBDevices map[string]*objects.Device
@@ -60,6 +67,8 @@ func (fmcs *FMCSource) Init() error {
 
 	initFunctions := []func(*fmcClient) error{
 		fmcs.initDevices,
+		fmcs.initSecurityZones,
+		fmcs.initHAPairs,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -75,6 +84,7 @@ func (fmcs *FMCSource) Init() error {
 func (fmcs *FMCSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		fmcs.syncDevices,
+		fmcs.syncHAPairs,
 	}
 
 	for _, syncFunc := range syncFunctions {
@@ -1,17 +1,32 @@
 package fmc
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 )
 
+// FMC allows only 3 refreshes of the access token, after
+// that client has to authenticate again.
+const maxTokenRefreshes = 3
+
+// FMC rate limits api to 120 requests per minute. Requests that hit
+// the rate limit are retried with exponential backoff.
+const (
+	maxRateLimitRetries     = 5
+	defaultRateLimitBackoff = 2 * time.Second
+)
+
 type fmcClient struct {
 	HTTPClient     *http.Client
 	BaseURL        string
@@ -20,16 +35,21 @@ type fmcClient struct {
 	AccessToken    string
 	RefreshToken   string
 	DefaultTimeout time.Duration
+	// Number of times access token was refreshed since the last authentication.
+	RefreshCount int
+	// Initial wait time before retrying rate limited request.
+	RateLimitBackoff time.Duration
 }
 
 func newFMCClient(username string, password string, httpScheme string, hostname string, port int, httpClient *http.Client) (*fmcClient, error) {
 	// First we obtain access and refresh token
 	c := &fmcClient{
-		HTTPClient:     httpClient,
-		BaseURL:        fmt.Sprintf("%s://%s:%d/api", httpScheme, hostname, port),
-		Username:       username,
-		Password:       password,
-		DefaultTimeout: time.Second * constants.DefaultAPITimeout,
+		HTTPClient:       httpClient,
+		BaseURL:          fmt.Sprintf("%s://%s:%d/api", httpScheme, hostname, port),
+		Username:         username,
+		Password:         password,
+		DefaultTimeout:   time.Second * constants.DefaultAPITimeout,
+		RateLimitBackoff: defaultRateLimitBackoff,
 	}
 
 	aToken, rToken, err := c.Authenticate()
@@ -57,6 +77,25 @@ func (fmcc fmcClient) Authenticate() (string, string, error) {
 	auth = base64.StdEncoding.EncodeToString([]byte(auth))
 	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", auth))
 
+	return fmcc.requestTokens(req)
+}
+
+// RefreshAccessToken obtains new access and refresh tokens using the current ones.
+func (fmcc fmcClient) RefreshAccessToken() (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), fmcc.DefaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/fmc_platform/v1/auth/refreshtoken", fmcc.BaseURL), nil)
+	if err != nil {
+		return "", "", fmt.Errorf("new request with context: %w", err)
+	}
+	req.Header.Add("X-auth-access-token", fmcc.AccessToken)
+	req.Header.Add("X-auth-refresh-token", fmcc.RefreshToken)
+
+	return fmcc.requestTokens(req)
+}
+
+// requestTokens sends token request and extracts access and refresh tokens from the response headers.
+func (fmcc fmcClient) requestTokens(req *http.Request) (string, string, error) {
 	res, err := fmcc.HTTPClient.Do(req)
 	if err != nil {
 		return "", "", fmt.Errorf("req err: %w", err)
@@ -72,6 +111,28 @@ func (fmcc fmcClient) Authenticate() (string, string, error) {
 	return accessToken, refreshToken, nil
 }
 
+// reauthenticate obtains new tokens after access token expires. Tokens are refreshed
+// while FMC allows it, otherwise (or if refresh fails) client authenticates again.
+func (fmcc *fmcClient) reauthenticate() error {
+	if fmcc.RefreshCount < maxTokenRefreshes {
+		aToken, rToken, err := fmcc.RefreshAccessToken()
+		if err == nil {
+			fmcc.AccessToken = aToken
+			fmcc.RefreshToken = rToken
+			fmcc.RefreshCount++
+			return nil
+		}
+	}
+	aToken, rToken, err := fmcc.Authenticate()
+	if err != nil {
+		return fmt.Errorf("authentication: %w", err)
+	}
+	fmcc.AccessToken = aToken
+	fmcc.RefreshToken = rToken
+	fmcc.RefreshCount = 0
+	return nil
+}
+
 type PagingResponse struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
@@ -101,70 +162,110 @@ type Device struct {
 	Name string `json:"name"`
 }
 
+// MakeRequest sends request to the FMC api. Expired access token is refreshed and
+// the request is repeated once. Rate limited requests are retried with exponential
+// backoff (or after the time FMC suggests in Retry-After header).
 func (fmcc *fmcClient) MakeRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	// Body is stored, so the request can be repeated
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+	}
+	reauthenticated := false
+	rateLimitRetries := 0
+	for {
+		res, err := fmcc.doRequest(ctx, method, path, bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case res.StatusCode == http.StatusUnauthorized && !reauthenticated:
+			res.Body.Close()
+			if err := fmcc.reauthenticate(); err != nil {
+				return nil, fmt.Errorf("reauthenticate: %w", err)
+			}
+			reauthenticated = true
+		case res.StatusCode == http.StatusTooManyRequests && rateLimitRetries < maxRateLimitRetries:
+			res.Body.Close()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(fmcc.rateLimitBackoff(res, rateLimitRetries)):
+			}
+			rateLimitRetries++
+		default:
+			return res, nil
+		}
+	}
+}
+
+// doRequest sends single request to the FMC api. Request is canceled
+// after default timeout or when the response body is closed.
+func (fmcc *fmcClient) doRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
 	ctx, cancel := context.WithTimeout(ctx, fmcc.DefaultTimeout)
-	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", fmcc.BaseURL, path), body)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", fmcc.BaseURL, path), bodyReader)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 	// Set the Authorization header.
 	req.Header.Set("X-auth-access-token", fmcc.AccessToken)
-	return fmcc.HTTPClient.Do(req)
+	res, err := fmcc.HTTPClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
 }
 
-func (fmcc *fmcClient) GetDomains() ([]Domain, error) {
-	offset := 0
-	limit := 25
-	domains := []Domain{}
-	ctx := context.Background()
-	for {
-		apiResponse, err := fmcc.MakeRequest(ctx, http.MethodGet, fmt.Sprintf("fmc_platform/v1/info/domain?offset=%d&limit=%d", offset, limit), nil)
-		if err != nil {
-			return nil, fmt.Errorf("make request for domains: %w", err)
-		}
-		defer apiResponse.Body.Close()
-		if apiResponse.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("wrong status code: %d", apiResponse.StatusCode)
-		}
-		var marshaledResponse APIResponse[Domain]
-		bodyBytes, err := io.ReadAll(apiResponse.Body)
-		if err != nil {
-			return nil, fmt.Errorf("response body readAll: %w", err)
-		}
-		err = json.Unmarshal(bodyBytes, &marshaledResponse)
-		if err != nil {
-			return nil, fmt.Errorf("json unmarshal response body: %w", err)
-		}
+// cancelOnClose cancels request's context when response body is closed,
+// so the body can still be read after doRequest returns.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
 
-		if len(marshaledResponse.Items) > 0 {
-			domains = append(domains, marshaledResponse.Items...)
-		}
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
 
-		if len(marshaledResponse.Items) < limit {
-			break
-		}
-		offset += limit
+// rateLimitBackoff returns how long to wait before retrying rate limited request.
+func (fmcc *fmcClient) rateLimitBackoff(res *http.Response, retry int) time.Duration {
+	if retryAfter, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+		return time.Duration(retryAfter) * time.Second
 	}
-	return domains, nil
+	return fmcc.RateLimitBackoff << retry
 }
 
-func (fmcc *fmcClient) GetDevices(domainUUID string) ([]Device, error) {
+// getAllItems collects items of all pages, that are returned by FMC api on path.
+func getAllItems[T any](ctx context.Context, fmcc *fmcClient, path string) ([]T, error) {
 	offset := 0
 	limit := 25
-	devices := []Device{}
-	ctx := context.Background()
-	devicesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords?offset=%d&limit=%d", domainUUID, offset, limit)
+	items := []T{}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
 	for {
-		apiResponse, err := fmcc.MakeRequest(ctx, http.MethodGet, devicesURL, nil)
+		apiResponse, err := fmcc.MakeRequest(ctx, http.MethodGet, fmt.Sprintf("%s%soffset=%d&limit=%d", path, separator, offset, limit), nil)
 		if err != nil {
-			return nil, fmt.Errorf("make request for domains: %w", err)
+			return nil, fmt.Errorf("make request: %w", err)
 		}
 		defer apiResponse.Body.Close()
 		if apiResponse.StatusCode != http.StatusOK {
 			return nil, fmt.Errorf("wrong status code: %d", apiResponse.StatusCode)
 		}
-		var marshaledResponse APIResponse[Device]
+		var marshaledResponse APIResponse[T]
 		bodyBytes, err := io.ReadAll(apiResponse.Body)
 		if err != nil {
 			return nil, fmt.Errorf("response body readAll: %w", err)
@@ -175,7 +276,7 @@ func (fmcc *fmcClient) GetDevices(domainUUID string) ([]Device, error) {
 		}
 
 		if len(marshaledResponse.Items) > 0 {
-			devices = append(devices, marshaledResponse.Items...)
+			items = append(items, marshaledResponse.Items...)
 		}
 
 		if len(marshaledResponse.Items) < limit {
@@ -183,6 +284,23 @@ func (fmcc *fmcClient) GetDevices(domainUUID string) ([]Device, error) {
 		}
 		offset += limit
 	}
+	return items, nil
+}
+
+func (fmcc *fmcClient) GetDomains() ([]Domain, error) {
+	domains, err := getAllItems[Domain](context.Background(), fmcc, "fmc_platform/v1/info/domain")
+	if err != nil {
+		return nil, fmt.Errorf("get domains: %w", err)
+	}
+	return domains, nil
+}
+
+func (fmcc *fmcClient) GetDevices(domainUUID string) ([]Device, error) {
+	devicesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords", domainUUID)
+	devices, err := getAllItems[Device](context.Background(), fmcc, devicesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get devices: %w", err)
+	}
 	return devices, nil
 }
 
@@ -193,38 +311,10 @@ type PhysicalInterface struct {
 }
 
 func (fmcc *fmcClient) GetDevicePhysicalInterfaces(domainUUID string, deviceID string) ([]PhysicalInterface, error) {
-	offset := 0
-	limit := 25
-	pIfaces := []PhysicalInterface{}
-	ctx := context.Background()
-	pInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/physicalinterfaces?offset=%d&limit=%d", domainUUID, deviceID, offset, limit)
-	for {
-		apiResponse, err := fmcc.MakeRequest(ctx, http.MethodGet, pInterfacesURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("make request for domains: %w", err)
-		}
-		defer apiResponse.Body.Close()
-		if apiResponse.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("wrong status code: %d", apiResponse.StatusCode)
-		}
-		var marshaledResponse APIResponse[PhysicalInterface]
-		bodyBytes, err := io.ReadAll(apiResponse.Body)
-		if err != nil {
-			return nil, fmt.Errorf("response body readAll: %w", err)
-		}
-		err = json.Unmarshal(bodyBytes, &marshaledResponse)
-		if err != nil {
-			return nil, fmt.Errorf("json unmarshal response body: %w", err)
-		}
-
-		if len(marshaledResponse.Items) > 0 {
-			pIfaces = append(pIfaces, marshaledResponse.Items...)
-		}
-
-		if len(marshaledResponse.Items) < limit {
-			break
-		}
-		offset += limit
+	pInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/physicalinterfaces", domainUUID, deviceID)
+	pIfaces, err := getAllItems[PhysicalInterface](context.Background(), fmcc, pInterfacesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get physical interfaces: %w", err)
 	}
 	return pIfaces, nil
 }
@@ -236,67 +326,52 @@ type VlanInterface struct {
 }
 
 func (fmcc *fmcClient) GetDeviceVLANInterfaces(domainUUID string, deviceID string) ([]VlanInterface, error) {
-	offset := 0
-	limit := 25
-	vlanIfaces := []VlanInterface{}
-	ctx := context.Background()
-	pInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/vlaninterfaces?offset=%d&limit=%d", domainUUID, deviceID, offset, limit)
-	for {
-		apiResponse, err := fmcc.MakeRequest(ctx, http.MethodGet, pInterfacesURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("make request for domains: %w", err)
-		}
-		defer apiResponse.Body.Close()
-		if apiResponse.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("wrong status code: %d", apiResponse.StatusCode)
-		}
-		var marshaledResponse APIResponse[VlanInterface]
-		bodyBytes, err := io.ReadAll(apiResponse.Body)
-		if err != nil {
-			return nil, fmt.Errorf("response body readAll: %w", err)
-		}
-		err = json.Unmarshal(bodyBytes, &marshaledResponse)
-		if err != nil {
-			return nil, fmt.Errorf("json unmarshal response body: %w", err)
-		}
-
-		if len(marshaledResponse.Items) > 0 {
-			vlanIfaces = append(vlanIfaces, marshaledResponse.Items...)
-		}
-
-		if len(marshaledResponse.Items) < limit {
-			break
-		}
-		offset += limit
+	vlanInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/vlaninterfaces", domainUUID, deviceID)
+	vlanIfaces, err := getAllItems[VlanInterface](context.Background(), fmcc, vlanInterfacesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get vlan interfaces: %w", err)
 	}
 	return vlanIfaces, nil
 }
 
+// InterfaceHardware represents hardware configuration of the interface.
+type InterfaceHardware struct {
+	Speed  string `json:"speed"`
+	Duplex string `json:"duplex"`
+}
+
+// ObjectReference represents reference to another FMC object (e.g. security zone or interface).
+type ObjectReference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// InterfaceIPv4 represents ipv4 configuration of the interface.
+type InterfaceIPv4 struct {
+	Static *struct {
+		Address string `json:"address"`
+		Netmask string `json:"netmask"`
+	} `json:"static"`
+}
+
+// InterfaceIPv6 represents ipv6 configuration of the interface.
+type InterfaceIPv6 struct {
+	EnableIPv6 bool `json:"enableIPV6"`
+}
+
 type PhysicalInterfaceInfo struct {
-	Type        string `json:"type"`
-	MTU         int    `json:"MTU"`
-	Enabled     bool   `json:"enabled"`
-	Name        string `json:"name"`
-	ID          string `json:"id"`
-	Mode        string `json:"mode"`
-	Description string `json:"description"`
-	Hardware    *struct {
-		Speed  string `json:"speed"`
-		Duplex string `json:"duplex"`
-	} `json:"hardware"`
-	SecurityZone *struct {
-		ID   string `json:"id"`
-		Type string `json:"type"`
-	} `json:"securityZone"`
-	IPv4 *struct {
-		Static *struct {
-			Address string `json:"address"`
-			Netmask string `json:"netmask"`
-		} `json:"static"`
-	} `json:"ipv4"`
-	IPv6 *struct {
-		EnableIPv6 bool `json:"enableIPV6"`
-	} `json:"ipv6"`
+	Type         string             `json:"type"`
+	MTU          int                `json:"MTU"`
+	Enabled      bool               `json:"enabled"`
+	Name         string             `json:"name"`
+	ID           string             `json:"id"`
+	Mode         string             `json:"mode"`
+	Description  string             `json:"description"`
+	Hardware     *InterfaceHardware `json:"hardware"`
+	SecurityZone *ObjectReference   `json:"securityZone"`
+	IPv4         *InterfaceIPv4     `json:"ipv4"`
+	IPv6         *InterfaceIPv6     `json:"ipv6"`
 }
 
 func (fmcc *fmcClient) GetPhysicalInterfaceInfo(domainUUID string, deviceID string, interfaceID string) (*PhysicalInterfaceInfo, error) {
@@ -348,31 +423,123 @@ func (fmcc *fmcClient) GetVLANInterfaceInfo(domainUUID string, deviceID string,
 }
 
 type VLANInterfaceInfo struct {
-	Type        string `json:"type"`
-	Mode        string `json:"mode"`
-	VID         int    `json:"vlanId"`
-	MTU         int    `json:"MTU"`
-	Enabled     bool   `json:"enabled"`
-	Name        string `json:"name"`
-	ID          string `json:"id"`
-	Description string `json:"description"`
-	Hardware    *struct {
-		Speed  string `json:"speed"`
-		Duplex string `json:"duplex"`
-	} `json:"hardware"`
-	SecurityZone *struct {
-		ID   string `json:"id"`
-		Type string `json:"type"`
-	} `json:"securityZone"`
-	IPv4 *struct {
-		Static *struct {
-			Address string `json:"address"`
-			Netmask string `json:"netmask"`
-		} `json:"static"`
-	} `json:"ipv4"`
-	IPv6 *struct {
-		EnableIPv6 bool `json:"enableIPV6"`
-	} `json:"ipv6"`
+	Type         string             `json:"type"`
+	Mode         string             `json:"mode"`
+	VID          int                `json:"vlanId"`
+	MTU          int                `json:"MTU"`
+	Enabled      bool               `json:"enabled"`
+	Name         string             `json:"name"`
+	ID           string             `json:"id"`
+	Description  string             `json:"description"`
+	Hardware     *InterfaceHardware `json:"hardware"`
+	SecurityZone *ObjectReference   `json:"securityZone"`
+	IPv4         *InterfaceIPv4     `json:"ipv4"`
+	IPv6         *InterfaceIPv6     `json:"ipv6"`
+}
+
+// EtherChannelInterfaceInfo represents port channel, that aggregates selected physical interfaces.
+type EtherChannelInterfaceInfo struct {
+	Type               string             `json:"type"`
+	Mode               string             `json:"mode"`
+	MTU                int                `json:"MTU"`
+	Enabled            bool               `json:"enabled"`
+	Name               string             `json:"name"`
+	ID                 string             `json:"id"`
+	Description        string             `json:"description"`
+	EtherChannelID     int                `json:"etherChannelId"`
+	SelectedInterfaces []ObjectReference  `json:"selectedInterfaces"`
+	SecurityZone       *ObjectReference   `json:"securityZone"`
+	IPv4               *InterfaceIPv4     `json:"ipv4"`
+	IPv6               *InterfaceIPv6     `json:"ipv6"`
+	Hardware           *InterfaceHardware `json:"hardware"`
+}
+
+// GetDeviceEtherChannelInterfaces returns all etherchannel interfaces of the device.
+func (fmcc *fmcClient) GetDeviceEtherChannelInterfaces(domainUUID string, deviceID string) ([]EtherChannelInterfaceInfo, error) {
+	etherChannelsURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/etherchannelinterfaces?expanded=true", domainUUID, deviceID)
+	etherChannels, err := getAllItems[EtherChannelInterfaceInfo](context.Background(), fmcc, etherChannelsURL)
+	if err != nil {
+		return nil, fmt.Errorf("get etherchannel interfaces: %w", err)
+	}
+	return etherChannels, nil
+}
+
+// SubInterfaceInfo represents vlan subinterface of physical or etherchannel interface.
+// Name of the subinterface is the name of its parent interface.
+type SubInterfaceInfo struct {
+	Type         string           `json:"type"`
+	Mode         string           `json:"mode"`
+	MTU          int              `json:"MTU"`
+	Enabled      bool             `json:"enabled"`
+	Name         string           `json:"name"`
+	ID           string           `json:"id"`
+	Description  string           `json:"description"`
+	SubIntfID    int              `json:"subIntfId"`
+	VID          int              `json:"vlanId"`
+	SecurityZone *ObjectReference `json:"securityZone"`
+	IPv4         *InterfaceIPv4   `json:"ipv4"`
+	IPv6         *InterfaceIPv6   `json:"ipv6"`
+}
+
+// FullName returns name of the subinterface as shown on the device (e.g. GigabitEthernet0/1.100).
+func (si SubInterfaceInfo) FullName() string {
+	return fmt.Sprintf("%s.%d", si.Name, si.SubIntfID)
+}
+
+// GetDeviceSubInterfaces returns all subinterfaces of the device.
+func (fmcc *fmcClient) GetDeviceSubInterfaces(domainUUID string, deviceID string) ([]SubInterfaceInfo, error) {
+	subInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/subinterfaces?expanded=true", domainUUID, deviceID)
+	subIfaces, err := getAllItems[SubInterfaceInfo](context.Background(), fmcc, subInterfacesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get subinterfaces: %w", err)
+	}
+	return subIfaces, nil
+}
+
+// RedundantInterfaceInfo represents redundant interface, that consists of
+// active (primary) and standby (secondary) physical interface.
+type RedundantInterfaceInfo struct {
+	Type               string           `json:"type"`
+	Mode               string           `json:"mode"`
+	MTU                int              `json:"MTU"`
+	Enabled            bool             `json:"enabled"`
+	Name               string           `json:"name"`
+	ID                 string           `json:"id"`
+	Description        string           `json:"description"`
+	RedundantID        int              `json:"redundantId"`
+	PrimaryInterface   *ObjectReference `json:"primaryInterface"`
+	SecondaryInterface *ObjectReference `json:"secondaryInterface"`
+	SecurityZone       *ObjectReference `json:"securityZone"`
+	IPv4               *InterfaceIPv4   `json:"ipv4"`
+	IPv6               *InterfaceIPv6   `json:"ipv6"`
+}
+
+// GetDeviceRedundantInterfaces returns all redundant interfaces of the device.
+func (fmcc *fmcClient) GetDeviceRedundantInterfaces(domainUUID string, deviceID string) ([]RedundantInterfaceInfo, error) {
+	redundantInterfacesURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/redundantinterfaces?expanded=true", domainUUID, deviceID)
+	redundantIfaces, err := getAllItems[RedundantInterfaceInfo](context.Background(), fmcc, redundantInterfacesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get redundant interfaces: %w", err)
+	}
+	return redundantIfaces, nil
+}
+
+// SecurityZone represents security zone object, that groups interfaces.
+type SecurityZone struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	InterfaceMode string `json:"interfaceMode"`
+}
+
+// GetSecurityZones returns all security zones configured in the domain with domainUUID.
+func (fmcc *fmcClient) GetSecurityZones(domainUUID string) ([]SecurityZone, error) {
+	securityZonesURL := fmt.Sprintf("fmc_config/v1/domain/%s/object/securityzones?expanded=true", domainUUID)
+	securityZones, err := getAllItems[SecurityZone](context.Background(), fmcc, securityZonesURL)
+	if err != nil {
+		return nil, fmt.Errorf("get security zones: %w", err)
+	}
+	return securityZones, nil
 }
 
 type DeviceInfo struct {
@@ -416,3 +583,51 @@ func (fmcc *fmcClient) GetDeviceInfo(domainUUID string, deviceID string) (*Devic
 
 	return &deviceInfo, nil
 }
+
+type DeviceHAPair struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Primary   Device `json:"primary"`
+	Secondary Device `json:"secondary"`
+	// Status of the HA pair, returned only when metadata is requested.
+	Metadata *struct {
+		PrimaryStatus *struct {
+			CurrentStatus string `json:"currentStatus"`
+		} `json:"primaryStatus"`
+		SecondaryStatus *struct {
+			CurrentStatus string `json:"currentStatus"`
+		} `json:"secondaryStatus"`
+	} `json:"metadata"`
+}
+
+// GetDeviceHAPairs returns all FTD HA pairs configured in the domain with domainUUID.
+func (fmcc *fmcClient) GetDeviceHAPairs(domainUUID string) ([]DeviceHAPair, error) {
+	haPairsURL := fmt.Sprintf("fmc_config/v1/domain/%s/devicehapairs/ftddevicehapairs?expanded=true", domainUUID)
+	haPairs, err := getAllItems[DeviceHAPair](context.Background(), fmcc, haPairsURL)
+	if err != nil {
+		return nil, fmt.Errorf("get ha pairs: %w", err)
+	}
+	return haPairs, nil
+}
+
+// CommandOutput represents output of the operational command executed on the device.
+type CommandOutput struct {
+	CommandInput  string `json:"commandInput"`
+	CommandOutput string `json:"commandOutput"`
+}
+
+// GetDeviceCommandOutput executes read only operational command (e.g. "show arp")
+// on the device and returns its output.
+func (fmcc *fmcClient) GetDeviceCommandOutput(domainUUID string, deviceID string, command string) (string, error) {
+	commandURL := fmt.Sprintf("fmc_config/v1/domain/%s/devices/devicerecords/%s/operational/commands?command=%s", domainUUID, deviceID, url.QueryEscape(command))
+	outputs, err := getAllItems[CommandOutput](context.Background(), fmcc, commandURL)
+	if err != nil {
+		return "", fmt.Errorf("get command output: %w", err)
+	}
+	commandOutput := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		commandOutput = append(commandOutput, output.CommandOutput)
+	}
+	return strings.Join(commandOutput, "\n"), nil
+}
@@ -1,6 +1,12 @@
 package fmc
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+)
 
 func (fmcs *FMCSource) initDevices(c *fmcClient) error {
 	fmcs.Domains = make(map[string]*Domain)
@@ -12,7 +18,13 @@ func (fmcs *FMCSource) initDevices(c *fmcClient) error {
 	fmcs.Devices = make(map[string]*DeviceInfo)
 	fmcs.DevicePhysicalIfaces = make(map[string][]*PhysicalInterfaceInfo)
 	fmcs.DeviceVlanIfaces = make(map[string][]*VLANInterfaceInfo)
+	fmcs.DeviceEtherChannelIfaces = make(map[string][]*EtherChannelInterfaceInfo)
+	fmcs.DeviceSubIfaces = make(map[string][]*SubInterfaceInfo)
+	fmcs.DeviceRedundantIfaces = make(map[string][]*RedundantInterfaceInfo)
+	fmcs.DeviceArpEntries = make(map[string][]common.ArpEntry)
 	for _, domain := range domains {
+		domain := domain
+		fmcs.Domains[domain.UUID] = &domain
 		devices, err := c.GetDevices(domain.UUID)
 		if err != nil {
 			return fmt.Errorf("get devices: %s", err)
@@ -49,7 +61,106 @@ func (fmcs *FMCSource) initDevices(c *fmcClient) error {
 				}
 				fmcs.DeviceVlanIfaces[device.ID] = append(fmcs.DeviceVlanIfaces[device.ID], vlanIfaceInfo)
 			}
+
+			err = fmcs.initDeviceLogicalInterfaces(c, domain.UUID, device.ID)
+			if err != nil {
+				return err
+			}
+
+			if fmcs.SourceConfig.CollectArpData {
+				fmcs.initDeviceArpEntries(c, domain.UUID, device.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// initDeviceLogicalInterfaces collects etherchannel, sub and redundant interfaces of the device.
+// These are collected in expanded form, so no additional request is needed per interface.
+func (fmcs *FMCSource) initDeviceLogicalInterfaces(c *fmcClient, domainUUID string, deviceID string) error {
+	etherChannelIfaces, err := c.GetDeviceEtherChannelInterfaces(domainUUID, deviceID)
+	if err != nil {
+		return fmt.Errorf("error getting etherchannel interfaces: %s", err)
+	}
+	fmcs.DeviceEtherChannelIfaces[deviceID] = make([]*EtherChannelInterfaceInfo, 0, len(etherChannelIfaces))
+	for i := range etherChannelIfaces {
+		fmcs.DeviceEtherChannelIfaces[deviceID] = append(fmcs.DeviceEtherChannelIfaces[deviceID], &etherChannelIfaces[i])
+	}
+
+	subIfaces, err := c.GetDeviceSubInterfaces(domainUUID, deviceID)
+	if err != nil {
+		return fmt.Errorf("error getting subinterfaces: %s", err)
+	}
+	fmcs.DeviceSubIfaces[deviceID] = make([]*SubInterfaceInfo, 0, len(subIfaces))
+	for i := range subIfaces {
+		fmcs.DeviceSubIfaces[deviceID] = append(fmcs.DeviceSubIfaces[deviceID], &subIfaces[i])
+	}
+
+	redundantIfaces, err := c.GetDeviceRedundantInterfaces(domainUUID, deviceID)
+	if err != nil {
+		return fmt.Errorf("error getting redundant interfaces: %s", err)
+	}
+	fmcs.DeviceRedundantIfaces[deviceID] = make([]*RedundantInterfaceInfo, 0, len(redundantIfaces))
+	for i := range redundantIfaces {
+		fmcs.DeviceRedundantIfaces[deviceID] = append(fmcs.DeviceRedundantIfaces[deviceID], &redundantIfaces[i])
+	}
+	return nil
+}
+
+// initSecurityZones collects security zones of all domains.
+func (fmcs *FMCSource) initSecurityZones(c *fmcClient) error {
+	fmcs.SecurityZones = make(map[string]*SecurityZone)
+	for _, domain := range fmcs.Domains {
+		securityZones, err := c.GetSecurityZones(domain.UUID)
+		if err != nil {
+			return fmt.Errorf("get security zones: %s", err)
+		}
+		for _, securityZone := range securityZones {
+			securityZone := securityZone
+			fmcs.SecurityZones[securityZone.ID] = &securityZone
 		}
 	}
 	return nil
 }
+
+func (fmcs *FMCSource) initHAPairs(c *fmcClient) error {
+	fmcs.HAPairs = make(map[string]*DeviceHAPair)
+	for _, domain := range fmcs.Domains {
+		haPairs, err := c.GetDeviceHAPairs(domain.UUID)
+		if err != nil {
+			return fmt.Errorf("get ha pairs: %s", err)
+		}
+		for _, haPair := range haPairs {
+			haPair := haPair
+			fmcs.HAPairs[haPair.ID] = &haPair
+		}
+	}
+	return nil
+}
+
+// initDeviceArpEntries collects arp table of the device, using show arp operational command.
+// Operational commands are not supported by older FMC versions, so failure is not fatal.
+func (fmcs *FMCSource) initDeviceArpEntries(c *fmcClient, domainUUID string, deviceID string) {
+	arpOutput, err := c.GetDeviceCommandOutput(domainUUID, deviceID, "show arp")
+	if err != nil {
+		fmcs.Logger.Warningf(fmcs.Ctx, "can't collect arp entries of device %s: %s", deviceID, err)
+		return
+	}
+	fmcs.DeviceArpEntries[deviceID] = parseShowArp(arpOutput)
+}
+
+// parseShowArp parses output of the show arp command on FTD. Each arp
+// entry is in format "<interface> <ip> <mac> <age>", e.g.
+// "outside 10.0.0.1 0050.56b3.1234 12". Incomplete entries, where mac
+// is not resolved (e.g. "-"), are skipped.
+func parseShowArp(output string) []common.ArpEntry {
+	arpEntries := make([]common.ArpEntry, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || net.ParseIP(fields[1]) == nil || common.NormalizeMAC(fields[2]) == "" {
+			continue
+		}
+		arpEntries = append(arpEntries, common.ArpEntry{IP: fields[1], MAC: fields[2]})
+	}
+	return arpEntries
+}
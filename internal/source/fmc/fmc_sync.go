@@ -2,6 +2,7 @@ package fmc
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
@@ -11,6 +12,7 @@ import (
 )
 
 func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
+	fmcs.NBDevices = make(map[string]*objects.Device)
 	for deviceUUID, device := range fmcs.Devices {
 		deviceName := device.Name
 		if deviceName == "" {
@@ -86,14 +88,281 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		if err != nil {
 			return fmt.Errorf("add device: %s", err)
 		}
-		err = fmcs.syncPhysicalInterfaces(nbi, NBDevice, deviceUUID)
+		fmcs.NBDevices[deviceUUID] = NBDevice
+		err = fmcs.syncSecurityZones(nbi, NBDevice, deviceUUID)
+		if err != nil {
+			return fmt.Errorf("sync security zones: %s", err)
+		}
+		// Lags are synced first, so member interfaces can reference them
+		member2LAG, err := fmcs.syncLAGInterfaces(nbi, NBDevice, deviceUUID)
+		if err != nil {
+			return fmt.Errorf("sync lag interfaces: %s", err)
+		}
+		err = fmcs.syncPhysicalInterfaces(nbi, NBDevice, deviceUUID, member2LAG)
 		if err != nil {
 			return fmt.Errorf("sync physical interfaces: %s", err)
 		}
+		err = fmcs.syncSubInterfaces(nbi, NBDevice, deviceUUID)
+		if err != nil {
+			return fmt.Errorf("sync subinterfaces: %s", err)
+		}
 		err = fmcs.syncVlanInterfaces(nbi, NBDevice, deviceUUID)
 		if err != nil {
 			return fmt.Errorf("sync vlan interfaces: %s", err)
 		}
+		if fmcs.SourceConfig.CollectArpData {
+			err = common.SyncArpTable(fmcs.Ctx, nbi, fmcs.SourceTags, fmcs.SourceConfig.Name, fmcs.DeviceArpEntries[deviceUUID], nil)
+			if err != nil {
+				return fmt.Errorf("sync arp table: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// syncSecurityZones creates virtual device context on the device for
+// each security zone, that contains any of the device's interfaces.
+func (fmcs *FMCSource) syncSecurityZones(nbi *inventory.NetboxInventory, nbDevice *objects.Device, deviceUUID string) error {
+	zoneRefs := []*ObjectReference{}
+	for _, pIface := range fmcs.DevicePhysicalIfaces[deviceUUID] {
+		zoneRefs = append(zoneRefs, pIface.SecurityZone)
+	}
+	for _, vlanIface := range fmcs.DeviceVlanIfaces[deviceUUID] {
+		zoneRefs = append(zoneRefs, vlanIface.SecurityZone)
+	}
+	for _, etherChannelIface := range fmcs.DeviceEtherChannelIfaces[deviceUUID] {
+		zoneRefs = append(zoneRefs, etherChannelIface.SecurityZone)
+	}
+	for _, subIface := range fmcs.DeviceSubIfaces[deviceUUID] {
+		zoneRefs = append(zoneRefs, subIface.SecurityZone)
+	}
+	for _, redundantIface := range fmcs.DeviceRedundantIfaces[deviceUUID] {
+		zoneRefs = append(zoneRefs, redundantIface.SecurityZone)
+	}
+	for _, zoneRef := range zoneRefs {
+		zoneName := fmcs.securityZoneName(zoneRef)
+		if zoneName == "" {
+			continue
+		}
+		if _, ok := nbi.VirtualDeviceContextsIndexByNameAndDeviceID[zoneName][nbDevice.ID]; ok {
+			continue
+		}
+		_, err := nbi.AddVirtualDeviceContext(fmcs.Ctx, &objects.VirtualDeviceContext{
+			NetboxObject: objects.NetboxObject{
+				Tags: fmcs.SourceTags,
+			},
+			Name:   zoneName,
+			Device: nbDevice,
+			Status: &objects.VDCStatusActive,
+		})
+		if err != nil {
+			return fmt.Errorf("add VirtualDeviceContext: %s", err)
+		}
+	}
+	return nil
+}
+
+// securityZoneName returns name of the referenced security zone.
+func (fmcs *FMCSource) securityZoneName(zoneRef *ObjectReference) string {
+	if zoneRef == nil {
+		return ""
+	}
+	if securityZone, ok := fmcs.SecurityZones[zoneRef.ID]; ok {
+		return securityZone.Name
+	}
+	return zoneRef.Name
+}
+
+// getVirtualDeviceContexts returns already synced virtual device context of the interface's security zone.
+func (fmcs *FMCSource) getVirtualDeviceContexts(nbi *inventory.NetboxInventory, nbDevice *objects.Device, zoneRef *ObjectReference) []*objects.VirtualDeviceContext {
+	zoneName := fmcs.securityZoneName(zoneRef)
+	if vdc, ok := nbi.VirtualDeviceContextsIndexByNameAndDeviceID[zoneName][nbDevice.ID]; ok {
+		return []*objects.VirtualDeviceContext{vdc}
+	}
+	return nil
+}
+
+// syncLAGInterfaces syncs etherchannel and redundant interfaces of the device as lags.
+// It returns map of member interface ids to lags, that they belong to.
+func (fmcs *FMCSource) syncLAGInterfaces(nbi *inventory.NetboxInventory, nbDevice *objects.Device, deviceUUID string) (map[string]*objects.Interface, error) {
+	member2LAG := make(map[string]*objects.Interface)
+	for _, etherChannelIface := range fmcs.DeviceEtherChannelIfaces[deviceUUID] {
+		nbLAG, err := nbi.AddInterface(fmcs.Ctx, &objects.Interface{
+			NetboxObject: objects.NetboxObject{
+				Description: etherChannelIface.Description,
+				Tags:        fmcs.SourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceIDName: etherChannelIface.ID,
+				},
+			},
+			Name:   etherChannelIface.Name,
+			Device: nbDevice,
+			Status: etherChannelIface.Enabled,
+			MTU:    etherChannelIface.MTU,
+			Type:   &objects.LAGInterfaceType,
+			Vdcs:   fmcs.getVirtualDeviceContexts(nbi, nbDevice, etherChannelIface.SecurityZone),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add etherchannel interface: %s", err)
+		}
+		for _, member := range etherChannelIface.SelectedInterfaces {
+			member2LAG[member.ID] = nbLAG
+		}
+		_, err = fmcs.syncIPv4Address(nbi, nbLAG, etherChannelIface.IPv4)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, redundantIface := range fmcs.DeviceRedundantIfaces[deviceUUID] {
+		nbLAG, err := nbi.AddInterface(fmcs.Ctx, &objects.Interface{
+			NetboxObject: objects.NetboxObject{
+				Description: redundantIface.Description,
+				Tags:        fmcs.SourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceIDName: redundantIface.ID,
+				},
+			},
+			Name:   redundantIface.Name,
+			Device: nbDevice,
+			Status: redundantIface.Enabled,
+			MTU:    redundantIface.MTU,
+			Type:   &objects.LAGInterfaceType,
+			Vdcs:   fmcs.getVirtualDeviceContexts(nbi, nbDevice, redundantIface.SecurityZone),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add redundant interface: %s", err)
+		}
+		for _, member := range []*ObjectReference{redundantIface.PrimaryInterface, redundantIface.SecondaryInterface} {
+			if member != nil {
+				member2LAG[member.ID] = nbLAG
+			}
+		}
+		_, err = fmcs.syncIPv4Address(nbi, nbLAG, redundantIface.IPv4)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return member2LAG, nil
+}
+
+// syncSubInterfaces syncs all subinterfaces of the device. Parent interfaces
+// (physical or etherchannel) must already be synced.
+func (fmcs *FMCSource) syncSubInterfaces(nbi *inventory.NetboxInventory, nbDevice *objects.Device, deviceUUID string) error {
+	for _, subIface := range fmcs.DeviceSubIfaces[deviceUUID] {
+		parentIface, ok := nbi.InterfacesIndexByDeviceIDAndName[nbDevice.ID][subIface.Name]
+		if !ok {
+			fmcs.Logger.Warningf(fmcs.Ctx, "parent interface %s of subinterface %s is not synced. Skipping...", subIface.Name, subIface.FullName())
+			continue
+		}
+		var subIfaceVlan *objects.Vlan
+		var subIfaceMode *objects.InterfaceMode
+		taggedVlans := []*objects.Vlan{}
+		if subIface.VID != 0 {
+			vlanName := fmt.Sprintf("Vlan%d", subIface.VID)
+			vlanGroup, err := common.MatchVlanToGroup(fmcs.Ctx, nbi, vlanName, fmcs.VlanGroupRelations)
+			if err != nil {
+				return fmt.Errorf("match vlan to group: %s", err)
+			}
+			vlanTenant, err := common.MatchVlanToTenant(fmcs.Ctx, nbi, vlanName, fmcs.VlanTenantRelations)
+			if err != nil {
+				return fmt.Errorf("match vlan to tenant: %s", err)
+			}
+			subIfaceVlan, err = nbi.AddVlan(fmcs.Ctx, &objects.Vlan{
+				NetboxObject: objects.NetboxObject{
+					Tags:        fmcs.SourceTags,
+					Description: subIface.Description,
+				},
+				Status: &objects.VlanStatusActive,
+				Name:   vlanName,
+				Vid:    subIface.VID,
+				Tenant: vlanTenant,
+				Group:  vlanGroup,
+			})
+			if err != nil {
+				return fmt.Errorf("add vlan: %s", err)
+			}
+			taggedVlans = append(taggedVlans, subIfaceVlan)
+			subIfaceMode = &objects.InterfaceModeTagged
+		}
+		nbSubIface, err := nbi.AddInterface(fmcs.Ctx, &objects.Interface{
+			NetboxObject: objects.NetboxObject{
+				Description: subIface.Description,
+				Tags:        fmcs.SourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceIDName: subIface.ID,
+				},
+			},
+			Name:            subIface.FullName(),
+			Device:          nbDevice,
+			Status:          subIface.Enabled,
+			MTU:             subIface.MTU,
+			Type:            &objects.VirtualInterfaceType,
+			Mode:            subIfaceMode,
+			TaggedVlans:     taggedVlans,
+			ParentInterface: parentIface,
+			Vdcs:            fmcs.getVirtualDeviceContexts(nbi, nbDevice, subIface.SecurityZone),
+		})
+		if err != nil {
+			return fmt.Errorf("add subinterface: %s", err)
+		}
+		nbIPAddress, err := fmcs.syncIPv4Address(nbi, nbSubIface, subIface.IPv4)
+		if err != nil {
+			return err
+		}
+		if nbIPAddress != nil && subIfaceVlan != nil {
+			err = fmcs.syncPrefix(nbi, nbIPAddress.Address, subIfaceVlan)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncIPv4Address syncs static ipv4 address of the interface. It returns nil
+// if interface has no static ipv4 address.
+func (fmcs *FMCSource) syncIPv4Address(nbi *inventory.NetboxInventory, nbIface *objects.Interface, ipv4 *InterfaceIPv4) (*objects.IPAddress, error) {
+	if ipv4 == nil || ipv4.Static == nil {
+		return nil, nil
+	}
+	address := fmt.Sprintf("%s/%s", ipv4.Static.Address, ipv4.Static.Netmask)
+	dnsName := utils.ReverseLookup(ipv4.Static.Address)
+	nbIPAddress, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
+		NetboxObject: objects.NetboxObject{
+			Tags: fmcs.SourceTags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldArpEntryName: false,
+			},
+		},
+		Address:            address,
+		DNSName:            dnsName,
+		AssignedObjectID:   nbIface.ID,
+		AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ip address: %s", err)
+	}
+	return nbIPAddress, nil
+}
+
+// syncPrefix syncs prefix of the address and assigns it to the vlan.
+func (fmcs *FMCSource) syncPrefix(nbi *inventory.NetboxInventory, address string, vlan *objects.Vlan) error {
+	prefix, err := utils.ExtractPrefixFromIPAddress(address)
+	if err != nil {
+		fmcs.Logger.Warningf(fmcs.Ctx, "extract prefix from address: %s", err)
+		return nil
+	}
+	var prefixTenant *objects.Tenant
+	if vlan != nil {
+		prefixTenant = vlan.Tenant
+	}
+	_, err = nbi.AddPrefix(fmcs.Ctx, &objects.Prefix{
+		Prefix: prefix,
+		Tenant: prefixTenant,
+		Vlan:   vlan,
+	})
+	if err != nil {
+		return fmt.Errorf("add prefix: %s", err)
 	}
 	return nil
 }
@@ -143,50 +412,24 @@ func (fmcs *FMCSource) syncVlanInterfaces(nbi *inventory.NetboxInventory, nbDevi
 				MTU:         vlanIface.MTU,
 				TaggedVlans: ifaceTaggedVlans,
 				Type:        &objects.VirtualInterfaceType,
+				Vdcs:        fmcs.getVirtualDeviceContexts(nbi, nbDevice, vlanIface.SecurityZone),
 			})
 			if err != nil {
 				return fmt.Errorf("add vlan interface: %s", err)
 			}
 
-			if vlanIface.IPv4 != nil {
-				if vlanIface.IPv4.Static != nil {
-					address := fmt.Sprintf("%s/%s", vlanIface.IPv4.Static.Address, vlanIface.IPv4.Static.Netmask)
-					dnsName := utils.ReverseLookup(vlanIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
-						NetboxObject: objects.NetboxObject{
-							Tags: fmcs.SourceTags,
-							CustomFields: map[string]interface{}{
-								constants.CustomFieldArpEntryName: false,
-							},
-						},
-						Address:            address,
-						DNSName:            dnsName,
-						AssignedObjectID:   NBIface.ID,
-						AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
-					})
-					if err != nil {
-						return fmt.Errorf("add ip address")
-					}
-					// Also add prefix
-					prefix, err := utils.ExtractPrefixFromIPAddress(address)
-					if err != nil {
-						fmcs.Logger.Warningf(fmcs.Ctx, "extract prefix from address: %s", err)
-					} else {
-						var prefixTenant *objects.Tenant
-						var prefixVlan *objects.Vlan
-						if len(ifaceTaggedVlans) > 0 {
-							prefixVlan = ifaceTaggedVlans[0]
-							prefixTenant = prefixVlan.Tenant
-						}
-						_, err = nbi.AddPrefix(fmcs.Ctx, &objects.Prefix{
-							Prefix: prefix,
-							Tenant: prefixTenant,
-							Vlan:   prefixVlan,
-						})
-						if err != nil {
-							return fmt.Errorf("add prefix: %s", err)
-						}
-					}
+			nbIPAddress, err := fmcs.syncIPv4Address(nbi, NBIface, vlanIface.IPv4)
+			if err != nil {
+				return err
+			}
+			if nbIPAddress != nil {
+				var prefixVlan *objects.Vlan
+				if len(ifaceTaggedVlans) > 0 {
+					prefixVlan = ifaceTaggedVlans[0]
+				}
+				err = fmcs.syncPrefix(nbi, nbIPAddress.Address, prefixVlan)
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -194,7 +437,9 @@ func (fmcs *FMCSource) syncVlanInterfaces(nbi *inventory.NetboxInventory, nbDevi
 	return nil
 }
 
-func (fmcs *FMCSource) syncPhysicalInterfaces(nbi *inventory.NetboxInventory, nbDevice *objects.Device, deviceUUID string) error {
+// syncPhysicalInterfaces syncs all physical interfaces of the device. Members
+// of etherchannel and redundant interfaces are assigned to their lags.
+func (fmcs *FMCSource) syncPhysicalInterfaces(nbi *inventory.NetboxInventory, nbDevice *objects.Device, deviceUUID string, member2LAG map[string]*objects.Interface) error {
 	if physicalIfaces, ok := fmcs.DevicePhysicalIfaces[deviceUUID]; ok {
 		for _, pIface := range physicalIfaces {
 			NBIface, err := nbi.AddInterface(fmcs.Ctx, &objects.Interface{
@@ -210,33 +455,46 @@ func (fmcs *FMCSource) syncPhysicalInterfaces(nbi *inventory.NetboxInventory, nb
 				Status: pIface.Enabled,
 				MTU:    pIface.MTU,
 				Type:   &objects.OtherInterfaceType, // TODO
+				LAG:    member2LAG[pIface.ID],
+				Vdcs:   fmcs.getVirtualDeviceContexts(nbi, nbDevice, pIface.SecurityZone),
 			})
 			if err != nil {
 				return fmt.Errorf("add vlan interface: %s", err)
 			}
 
-			if pIface.IPv4 != nil {
-				if pIface.IPv4.Static != nil {
-					address := fmt.Sprintf("%s/%s", pIface.IPv4.Static.Address, pIface.IPv4.Static.Netmask)
-					dnsName := utils.ReverseLookup(pIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
-						NetboxObject: objects.NetboxObject{
-							Tags: fmcs.SourceTags,
-							CustomFields: map[string]interface{}{
-								constants.CustomFieldArpEntryName: false,
-							},
-						},
-						Address:            address,
-						DNSName:            dnsName,
-						AssignedObjectID:   NBIface.ID,
-						AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
-					})
-					if err != nil {
-						return fmt.Errorf("add ip address")
-					}
-				}
+			_, err = fmcs.syncIPv4Address(nbi, NBIface, pIface.IPv4)
+			if err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
+
+// syncHAPairs groups firewalls in the same HA pair into a virtual chassis.
+// Primary device gets position 1 and secondary device position 2.
+// The device that is currently active is set as master of the virtual chassis.
+func (fmcs *FMCSource) syncHAPairs(nbi *inventory.NetboxInventory) error {
+	for _, haPair := range fmcs.HAPairs {
+		primaryDevice, primaryOk := fmcs.NBDevices[haPair.Primary.ID]
+		secondaryDevice, secondaryOk := fmcs.NBDevices[haPair.Secondary.ID]
+		if !primaryOk || !secondaryOk {
+			fmcs.Logger.Warningf(fmcs.Ctx, "devices of ha pair %s are not synced. Skipping...", haPair.Name)
+			continue
+		}
+		secondaryActive := false
+		if haPair.Metadata != nil && haPair.Metadata.SecondaryStatus != nil {
+			secondaryActive = strings.EqualFold(haPair.Metadata.SecondaryStatus.CurrentStatus, "active")
+		}
+		syncedMembers, err := common.SyncVirtualChassis(fmcs.Ctx, nbi, fmcs.SourceTags, fmcs.SourceConfig.Name, haPair.Name, "", []common.VirtualChassisMember{
+			{Device: primaryDevice, Position: 1, Priority: 1, Master: !secondaryActive},
+			{Device: secondaryDevice, Position: 2, Priority: 2, Master: secondaryActive}, //nolint:gomnd
+		})
+		if err != nil {
+			return fmt.Errorf("sync ha pair %s: %s", haPair.Name, err)
+		}
+		fmcs.NBDevices[haPair.Primary.ID] = syncedMembers[1]
+		fmcs.NBDevices[haPair.Secondary.ID] = syncedMembers[2]
+	}
+	return nil
+}
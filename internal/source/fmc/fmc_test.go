@@ -1 +1,210 @@
 package fmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+)
+
+func TestSubInterfaceInfo_FullName(t *testing.T) {
+	tests := []struct {
+		name     string
+		subIface SubInterfaceInfo
+		want     string
+	}{
+		{
+			name:     "Subinterface of physical interface",
+			subIface: SubInterfaceInfo{Name: "GigabitEthernet0/1", SubIntfID: 100},
+			want:     "GigabitEthernet0/1.100",
+		},
+		{
+			name:     "Subinterface of etherchannel",
+			subIface: SubInterfaceInfo{Name: "Port-channel1", SubIntfID: 20},
+			want:     "Port-channel1.20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subIface.FullName(); got != tt.want {
+				t.Errorf("SubInterfaceInfo.FullName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFmcClient_rateLimitBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		retry      int
+		want       time.Duration
+	}{
+		{
+			name:  "First retry",
+			retry: 0,
+			want:  2 * time.Second,
+		},
+		{
+			name:  "Exponential backoff",
+			retry: 3,
+			want:  16 * time.Second,
+		},
+		{
+			name:       "Retry-After header",
+			retryAfter: "5",
+			retry:      3,
+			want:       5 * time.Second,
+		},
+		{
+			name:       "Invalid Retry-After header",
+			retryAfter: "soon",
+			retry:      1,
+			want:       4 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fmcClient{RateLimitBackoff: defaultRateLimitBackoff}
+			res := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				res.Header.Set("Retry-After", tt.retryAfter)
+			}
+			if got := c.rateLimitBackoff(res, tt.retry); got != tt.want {
+				t.Errorf("fmcClient.rateLimitBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFmcClient_MakeRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		// Status codes returned for the requests to the resource, last one is repeated.
+		statusCodes      []int
+		refreshCount     int
+		wantStatusCode   int
+		wantAccessToken  string
+		wantRefreshCount int
+		wantRequests     int
+	}{
+		{
+			name:             "Valid token",
+			statusCodes:      []int{http.StatusOK},
+			wantStatusCode:   http.StatusOK,
+			wantAccessToken:  "access",
+			wantRequests:     1,
+			wantRefreshCount: 0,
+		},
+		{
+			name:             "Expired token is refreshed",
+			statusCodes:      []int{http.StatusUnauthorized, http.StatusOK},
+			wantStatusCode:   http.StatusOK,
+			wantAccessToken:  "refreshed-access",
+			wantRequests:     2,
+			wantRefreshCount: 1,
+		},
+		{
+			name:             "Authenticate after all refreshes are used",
+			statusCodes:      []int{http.StatusUnauthorized, http.StatusOK},
+			refreshCount:     maxTokenRefreshes,
+			wantStatusCode:   http.StatusOK,
+			wantAccessToken:  "generated-access",
+			wantRequests:     2,
+			wantRefreshCount: 0,
+		},
+		{
+			name:             "Request is repeated only once after reauthentication",
+			statusCodes:      []int{http.StatusUnauthorized},
+			wantStatusCode:   http.StatusUnauthorized,
+			wantAccessToken:  "refreshed-access",
+			wantRequests:     2,
+			wantRefreshCount: 1,
+		},
+		{
+			name:            "Rate limited request is retried",
+			statusCodes:     []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			wantStatusCode:  http.StatusOK,
+			wantAccessToken: "access",
+			wantRequests:    3,
+		},
+		{
+			name:            "Rate limited request gives up after max retries",
+			statusCodes:     []int{http.StatusTooManyRequests},
+			wantStatusCode:  http.StatusTooManyRequests,
+			wantAccessToken: "access",
+			wantRequests:    maxRateLimitRetries + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/fmc_platform/v1/auth/generatetoken", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-auth-access-token", "generated-access")
+				w.Header().Set("X-auth-refresh-token", "generated-refresh")
+			})
+			mux.HandleFunc("/api/fmc_platform/v1/auth/refreshtoken", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-auth-refresh-token") != "refresh" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("X-auth-access-token", "refreshed-access")
+				w.Header().Set("X-auth-refresh-token", "refreshed-refresh")
+			})
+			mux.HandleFunc("/api/resource", func(w http.ResponseWriter, _ *http.Request) {
+				statusCode := tt.statusCodes[min(requests, len(tt.statusCodes)-1)]
+				requests++
+				w.WriteHeader(statusCode)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			c := &fmcClient{
+				HTTPClient:       server.Client(),
+				BaseURL:          server.URL + "/api",
+				AccessToken:      "access",
+				RefreshToken:     "refresh",
+				RefreshCount:     tt.refreshCount,
+				DefaultTimeout:   time.Second,
+				RateLimitBackoff: time.Millisecond,
+			}
+			res, err := c.MakeRequest(context.Background(), http.MethodGet, "resource", nil)
+			if err != nil {
+				t.Fatalf("fmcClient.MakeRequest() error = %v", err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("fmcClient.MakeRequest() status code = %d, want %d", res.StatusCode, tt.wantStatusCode)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("fmcClient.MakeRequest() requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if c.AccessToken != tt.wantAccessToken {
+				t.Errorf("fmcClient.AccessToken = %s, want %s", c.AccessToken, tt.wantAccessToken)
+			}
+			if c.RefreshCount != tt.wantRefreshCount {
+				t.Errorf("fmcClient.RefreshCount = %d, want %d", c.RefreshCount, tt.wantRefreshCount)
+			}
+		})
+	}
+}
+
+func TestParseShowArp(t *testing.T) {
+	output := `	outside 10.0.0.1 0050.56b3.1234 12
+	inside 192.168.1.10 000c.29ab.cdef 45 alias
+
+	diagnostic 169.254.1.1 - 0
+`
+	want := []common.ArpEntry{
+		{IP: "10.0.0.1", MAC: "0050.56b3.1234"},
+		{IP: "192.168.1.10", MAC: "000c.29ab.cdef"},
+	}
+	if got := parseShowArp(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseShowArp() = %v, want %v", got, want)
+	}
+}
@@ -19,7 +19,24 @@ type FortigateSource struct {
 	common.Config
 	// Fortinet data. Initialized in init functions.
 	SystemInfo FortiSystemInfo              // Map storing system information
+	Vdoms      []string                     // Names of all vdoms
 	Ifaces     map[string]InterfaceResponse // iface name -> FortigateInterface
+	// HA cluster data. Empty if firewall is running in standalone mode.
+	HAGroupName string
+	HAGroupID   int
+	HAMembers   []HAMember
+	// IPSec tunnels data.
+	Phase1s         map[string]Phase1InterfaceResponse   // phase1 name -> phase1
+	Phase1ToPhase2s map[string][]Phase2InterfaceResponse // phase1 name -> phase2s
+	// Arp entries of each vdom. Collected only if collectArpData is enabled.
+	Vdom2ArpData map[string][]ArpResponse
+	// Dhcp pools and address objects matching address object filters of each vdom.
+	Vdom2DhcpPools      map[string][]common.DhcpPool
+	Vdom2AddressObjects map[string][]common.AddressObject
+	// Routes of each vdom's routing table. Collected only if collectRoutes is enabled.
+	Vdom2Routes map[string][]common.Route
+	// Bgp configuration of each vdom with configured bgp.
+	Vdom2BGPConfig map[string]common.BGPConfig
 
 	// NBFirewall representing fortinet firewall created in syncDevice func.
 	NBFirewall *objects.Device
@@ -37,6 +54,13 @@ type FortiSystemInfo struct {
 	Serial   string
 }
 
+// APIClient makes requests to the fortigate rest api on the given path
+// (e.g. "cmdb/system/interface/"). It is implemented by FortiClient, and
+// by the fortimanager source, which proxies requests to managed fortigates.
+type APIClient interface {
+	MakeRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error)
+}
+
 type FortiClient struct {
 	HTTPClient *http.Client
 	BaseURL    string
@@ -72,8 +96,12 @@ func (fs *FortigateSource) Init() error {
 	c := NewAPIClient(fs.SourceConfig.APIToken, fmt.Sprintf("%s://%s:%d/api/v2", fs.SourceConfig.HTTPScheme, fs.SourceConfig.Hostname, fs.SourceConfig.Port), HTTPClient)
 	ctx := context.Background()
 	defer ctx.Done()
+	return fs.InitWithClient(ctx, c)
+}
 
-	// Initialize regex relations for the sourcce
+// InitWithClient initializes fortigate source using the given api client.
+// It is used by the fortimanager source, which proxies requests to managed fortigates.
+func (fs *FortigateSource) InitWithClient(ctx context.Context, c APIClient) error {
 	// Initialize regex relations for this source
 	fs.VlanGroupRelations = utils.ConvertStringsToRegexPairs(fs.SourceConfig.VlanGroupRelations)
 	fs.Logger.Debugf(fs.Ctx, "VlanGroupRelations: %s", fs.VlanGroupRelations)
@@ -84,9 +112,17 @@ func (fs *FortigateSource) Init() error {
 	fs.HostSiteRelations = utils.ConvertStringsToRegexPairs(fs.SourceConfig.HostSiteRelations)
 	fs.Logger.Debugf(fs.Ctx, "HostSiteRelations: %s", fs.HostSiteRelations)
 
-	initFunctions := []func(context.Context, *FortiClient) error{
+	initFunctions := []func(context.Context, APIClient) error{
 		fs.InitSystemInfo,
+		fs.InitVdoms,
 		fs.InitInterfaces,
+		fs.InitHAMembers,
+		fs.InitIPSecTunnels,
+		fs.InitArpData,
+		fs.InitDhcpPools,
+		fs.InitAddressObjects,
+		fs.InitRoutes,
+		fs.InitBGP,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -102,7 +138,15 @@ func (fs *FortigateSource) Init() error {
 func (fs *FortigateSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		fs.syncDevice,
+		fs.syncHAMembers,
+		fs.syncVdoms,
 		fs.SyncInterfaces,
+		fs.syncIPSecTunnels,
+		fs.syncDhcpPools,
+		fs.syncRoutes,
+		fs.syncBGP,
+		fs.syncArpTable,
+		fs.syncAddressObjects,
 	}
 
 	for _, syncFunc := range syncFunctions {
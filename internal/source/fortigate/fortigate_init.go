@@ -6,8 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
+// Default vdom, which always exists on the fortigate.
+const defaultVdom = "root"
+
 type APIResponse[T any] struct {
 	HTTPStatus int    `json:"http_status"`
 	Serial     string `json:"serial"`
@@ -20,20 +28,30 @@ type DeviceResponse struct {
 }
 
 type InterfaceResponse struct {
-	Name        string `json:"name"`
-	Vdom        string `json:"vdom"`
-	IP          string `json:"Ip"`
-	Type        string `json:"type"`
-	Status      string `json:"status"`
-	Speed       string `json:"speed"`
-	Description string `json:"description"`
-	MTU         int    `json:"mtu"`
-	MAC         string `json:"macaddr"`
-	VlanID      int    `json:"vlanid"`
+	Name        string         `json:"name"`
+	Vdom        string         `json:"vdom"`
+	IP          string         `json:"Ip"`
+	Type        string         `json:"type"`
+	Status      string         `json:"status"`
+	Speed       string         `json:"speed"`
+	Description string         `json:"description"`
+	MTU         int            `json:"mtu"`
+	MAC         string         `json:"macaddr"`
+	VlanID      int            `json:"vlanid"`
+	Vrrp        []VrrpResponse `json:"vrrp"`
+}
+
+// VrrpResponse represents VRRP group configured on the fortigate interface.
+type VrrpResponse struct {
+	Vrid     int    `json:"vrid"`
+	Version  string `json:"version"`
+	Vrip     string `json:"vrip"`
+	Priority int    `json:"priority"`
+	Status   string `json:"status"`
 }
 
 // Init system info collects system info from paloalto.
-func (fs *FortigateSource) InitSystemInfo(ctx context.Context, c *FortiClient) error {
+func (fs *FortigateSource) InitSystemInfo(ctx context.Context, c APIClient) error {
 	res, err := c.MakeRequest(ctx, http.MethodGet, "cmdb/system/global/", nil)
 	if err != nil {
 		return fmt.Errorf("request error: %s", err)
@@ -64,32 +82,442 @@ func (fs *FortigateSource) InitSystemInfo(ctx context.Context, c *FortiClient) e
 	return nil
 }
 
+// VdomResponse represents a virtual domain configured on the fortigate.
+type VdomResponse struct {
+	Name string `json:"name"`
+}
+
+// InitVdoms collects names of all vdoms. If vdom mode is disabled, only the
+// default root vdom is used.
+func (fs *FortigateSource) InitVdoms(ctx context.Context, c APIClient) error {
+	vdoms, err := getAPIResults[[]VdomResponse](ctx, c, "cmdb/system/vdom/")
+	if err != nil {
+		return fmt.Errorf("vdoms: %s", err)
+	}
+	fs.Vdoms = make([]string, 0, len(vdoms))
+	for _, vdom := range vdoms {
+		if vdom.Name != "" {
+			fs.Vdoms = append(fs.Vdoms, vdom.Name)
+		}
+	}
+	if len(fs.Vdoms) == 0 {
+		fs.Vdoms = []string{defaultVdom}
+	}
+	return nil
+}
+
 // Fetches all information about interfaces from fortigate api.
-func (fs *FortigateSource) InitInterfaces(ctx context.Context, c *FortiClient) error {
-	// Interfaces
-	res, err := c.MakeRequest(ctx, http.MethodGet, "cmdb/system/interface/", nil)
+// Interfaces are collected separately for each vdom.
+func (fs *FortigateSource) InitInterfaces(ctx context.Context, c APIClient) error {
+	fs.Ifaces = make(map[string]InterfaceResponse)
+	for _, vdom := range fs.Vdoms {
+		interfaces, err := getAPIResults[[]InterfaceResponse](ctx, c, vdomPath("cmdb/system/interface/", vdom))
+		if err != nil {
+			return fmt.Errorf("interfaces of vdom %s: %s", vdom, err)
+		}
+		for _, iface := range interfaces {
+			if iface.Vdom == "" {
+				iface.Vdom = vdom
+			}
+			fs.Ifaces[iface.Name] = iface
+		}
+	}
+	return nil
+}
+
+// vdomPath adds vdom query parameter to the api path, so the request
+// is scoped to the given vdom.
+func vdomPath(path string, vdom string) string {
+	return fmt.Sprintf("%s?vdom=%s", path, url.QueryEscape(vdom))
+}
+
+// Helper function that makes GET request to fortigate api on path,
+// and returns results of the response.
+func getAPIResults[T any](ctx context.Context, c APIClient, path string) (T, error) {
+	var results T
+	res, err := c.MakeRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
-		return fmt.Errorf("request error: %s", err)
+		return results, fmt.Errorf("request error: %s", err)
 	}
 	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf("body read error: %s", err)
+		return results, fmt.Errorf("body read error: %s", err)
 	}
-	var interfaceResponse APIResponse[[]InterfaceResponse]
-	err = json.Unmarshal(body, &interfaceResponse)
+	var apiResponse APIResponse[T]
+	err = json.Unmarshal(body, &apiResponse)
 	if err != nil {
-		return fmt.Errorf("body unamrshal error: %s", err)
+		return results, fmt.Errorf("body unmarshal error: %s", err)
+	}
+	if apiResponse.HTTPStatus != http.StatusOK {
+		return results, fmt.Errorf("got http status: %d", apiResponse.HTTPStatus)
+	}
+	return apiResponse.Results, nil
+}
+
+type HAConfigResponse struct {
+	GroupID   int    `json:"group-id"`
+	GroupName string `json:"group-name"`
+	Mode      string `json:"mode"`
+}
+
+type HAChecksumResponse struct {
+	SerialNo       string `json:"serial_no"`
+	IsManageMaster int    `json:"is_manage_master"`
+	IsRootMaster   int    `json:"is_root_master"`
+}
+
+type HAPeerResponse struct {
+	SerialNo   string `json:"serial_no"`
+	Hostname   string `json:"hostname"`
+	Priority   int    `json:"priority"`
+	VclusterID int    `json:"vcluster_id"`
+}
+
+// HAMember represents a member of fortigate HA cluster.
+type HAMember struct {
+	Serial   string
+	Hostname string
+	Priority int
+	Primary  bool
+}
+
+// InitHAMembers collects members of the fortigate HA cluster.
+// Membership is determined from ha-checksums, which lists all
+// cluster members, additional info is collected from ha-peer.
+func (fs *FortigateSource) InitHAMembers(ctx context.Context, c APIClient) error {
+	haConfig, err := getAPIResults[HAConfigResponse](ctx, c, "cmdb/system/ha/")
+	if err != nil {
+		return fmt.Errorf("ha config: %s", err)
+	}
+	if haConfig.Mode == "" || haConfig.Mode == "standalone" {
+		return nil
+	}
+	fs.HAGroupName = haConfig.GroupName
+	fs.HAGroupID = haConfig.GroupID
+
+	haChecksums, err := getAPIResults[[]HAChecksumResponse](ctx, c, "monitor/system/ha-checksums/")
+	if err != nil {
+		return fmt.Errorf("ha checksums: %s", err)
+	}
+	// Peer info is optional, it is only used for hostnames and priorities
+	haPeers, err := getAPIResults[[]HAPeerResponse](ctx, c, "monitor/system/ha-peer/")
+	if err != nil {
+		fs.Logger.Warningf(fs.Ctx, "can't collect ha peers: %s", err)
+	}
+	serial2Peer := make(map[string]HAPeerResponse, len(haPeers))
+	for _, haPeer := range haPeers {
+		serial2Peer[haPeer.SerialNo] = haPeer
+	}
+
+	fs.HAMembers = make([]HAMember, 0, len(haChecksums))
+	for _, haChecksum := range haChecksums {
+		if haChecksum.SerialNo == "" {
+			continue
+		}
+		member := HAMember{
+			Serial:   haChecksum.SerialNo,
+			Hostname: haChecksum.SerialNo,
+			Primary:  haChecksum.IsRootMaster == 1,
+		}
+		if haPeer, ok := serial2Peer[haChecksum.SerialNo]; ok {
+			if haPeer.Hostname != "" {
+				member.Hostname = haPeer.Hostname
+			}
+			member.Priority = haPeer.Priority
+		}
+		fs.HAMembers = append(fs.HAMembers, member)
+	}
+	return nil
+}
+
+// Phase1InterfaceResponse represents phase 1 of the route based ipsec tunnel.
+// Fortigate creates tunnel interface with the same name as phase 1.
+type Phase1InterfaceResponse struct {
+	Name       string `json:"name"`
+	Interface  string `json:"interface"`
+	IKEVersion string `json:"ike-version"`
+	Mode       string `json:"mode"`
+	AuthMethod string `json:"authmethod"`
+	Proposal   string `json:"proposal"`
+	DHGroup    string `json:"dhgrp"`
+	KeyLife    int    `json:"keylife"`
+	RemoteGW   string `json:"remote-gw"`
+	LocalGW    string `json:"local-gw"`
+	Comments   string `json:"comments"`
+}
+
+// Phase2InterfaceResponse represents phase 2 of the route based ipsec tunnel.
+type Phase2InterfaceResponse struct {
+	Name           string `json:"name"`
+	Phase1Name     string `json:"phase1name"`
+	Proposal       string `json:"proposal"`
+	PFS            string `json:"pfs"`
+	DHGroup        string `json:"dhgrp"`
+	KeyLifeType    string `json:"keylife-type"`
+	KeyLifeSeconds int    `json:"keylifeseconds"`
+	KeyLifeKBs     int    `json:"keylifekbs"`
+}
+
+// InitIPSecTunnels collects phase 1 and phase 2 configuration of all route based ipsec tunnels
+// in all vdoms. Names of phases are unique across vdoms, because fortigate creates tunnel
+// interface with the same name as phase 1.
+func (fs *FortigateSource) InitIPSecTunnels(ctx context.Context, c APIClient) error {
+	fs.Phase1s = make(map[string]Phase1InterfaceResponse)
+	fs.Phase1ToPhase2s = make(map[string][]Phase2InterfaceResponse)
+	for _, vdom := range fs.Vdoms {
+		phase1s, err := getAPIResults[[]Phase1InterfaceResponse](ctx, c, vdomPath("cmdb/vpn.ipsec/phase1-interface/", vdom))
+		if err != nil {
+			return fmt.Errorf("phase1 interfaces of vdom %s: %s", vdom, err)
+		}
+		phase2s, err := getAPIResults[[]Phase2InterfaceResponse](ctx, c, vdomPath("cmdb/vpn.ipsec/phase2-interface/", vdom))
+		if err != nil {
+			return fmt.Errorf("phase2 interfaces of vdom %s: %s", vdom, err)
+		}
+		for _, phase1 := range phase1s {
+			fs.Phase1s[phase1.Name] = phase1
+		}
+		for _, phase2 := range phase2s {
+			fs.Phase1ToPhase2s[phase2.Phase1Name] = append(fs.Phase1ToPhase2s[phase2.Phase1Name], phase2)
+		}
+	}
+	return nil
+}
+
+// ArpResponse represents an entry of the fortigate arp table.
+type ArpResponse struct {
+	IP        string `json:"ip"`
+	MAC       string `json:"mac"`
+	Interface string `json:"interface"`
+	Age       int    `json:"age"`
+}
+
+// InitArpData collects arp entries of all vdoms, if collectArpData is enabled.
+func (fs *FortigateSource) InitArpData(ctx context.Context, c APIClient) error {
+	if !fs.SourceConfig.CollectArpData {
+		return nil
+	}
+	fs.Vdom2ArpData = make(map[string][]ArpResponse)
+	for _, vdom := range fs.Vdoms {
+		arpEntries, err := getAPIResults[[]ArpResponse](ctx, c, vdomPath("monitor/network/arp/", vdom))
+		if err != nil {
+			return fmt.Errorf("arp entries of vdom %s: %s", vdom, err)
+		}
+		fs.Vdom2ArpData[vdom] = arpEntries
 	}
+	return nil
+}
+
+// DhcpServerResponse represents dhcp server configured on the fortigate's interface.
+type DhcpServerResponse struct {
+	ID        int    `json:"id"`
+	Status    string `json:"status"`
+	Interface string `json:"interface"`
+	Netmask   string `json:"netmask"`
+	IPRange   []struct {
+		StartIP string `json:"start-ip"`
+		EndIP   string `json:"end-ip"`
+	} `json:"ip-range"`
+}
 
-	if interfaceResponse.HTTPStatus != http.StatusOK {
-		return fmt.Errorf("got http status: %d", interfaceResponse.HTTPStatus)
+// InitDhcpPools collects ip ranges of enabled dhcp servers of all vdoms.
+func (fs *FortigateSource) InitDhcpPools(ctx context.Context, c APIClient) error {
+	fs.Vdom2DhcpPools = make(map[string][]common.DhcpPool)
+	for _, vdom := range fs.Vdoms {
+		dhcpServers, err := getAPIResults[[]DhcpServerResponse](ctx, c, vdomPath("cmdb/system.dhcp/server/", vdom))
+		if err != nil {
+			return fmt.Errorf("dhcp servers of vdom %s: %s", vdom, err)
+		}
+		for _, dhcpServer := range dhcpServers {
+			if dhcpServer.Status == "disable" {
+				continue
+			}
+			maskBits, err := utils.MaskToBits(dhcpServer.Netmask)
+			if err != nil {
+				fs.Logger.Warningf(fs.Ctx, "dhcp server %d mask: %s", dhcpServer.ID, err)
+			}
+			for _, ipRange := range dhcpServer.IPRange {
+				fs.Vdom2DhcpPools[vdom] = append(fs.Vdom2DhcpPools[vdom], common.DhcpPool{
+					Interface:    dhcpServer.Interface,
+					StartAddress: ipRange.StartIP,
+					EndAddress:   ipRange.EndIP,
+					MaskBits:     maskBits,
+				})
+			}
+		}
 	}
+	return nil
+}
+
+// AddressResponse represents firewall address object.
+type AddressResponse struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Subnet  string `json:"subnet"`
+	StartIP string `json:"start-ip"`
+	EndIP   string `json:"end-ip"`
+	Tagging []struct {
+		Name     string `json:"name"`
+		Category string `json:"category"`
+		Tags     []struct {
+			Name string `json:"name"`
+		} `json:"tags"`
+	} `json:"tagging"`
+}
 
-	fs.Ifaces = make(map[string]InterfaceResponse, len(interfaceResponse.Results))
-	for _, iface := range interfaceResponse.Results {
-		fs.Ifaces[iface.Name] = iface
+// toAddressObject converts fortigate address object to common address object.
+// False is returned for address objects, that don't represent ip addresses (e.g. fqdn).
+func (a AddressResponse) toAddressObject() (common.AddressObject, bool) {
+	addressObject := common.AddressObject{Name: a.Name}
+	for _, tagging := range a.Tagging {
+		for _, tag := range tagging.Tags {
+			addressObject.Tags = append(addressObject.Tags, tag.Name)
+		}
+	}
+	switch a.Type {
+	case "ipmask":
+		ipAndMask := strings.Split(a.Subnet, " ")
+		if len(ipAndMask) != 2 { //nolint:gomnd
+			return addressObject, false
+		}
+		maskBits, err := utils.MaskToBits(ipAndMask[1])
+		if err != nil {
+			return addressObject, false
+		}
+		addressObject.Value = fmt.Sprintf("%s/%d", ipAndMask[0], maskBits)
+	case "iprange":
+		addressObject.Value = fmt.Sprintf("%s-%s", a.StartIP, a.EndIP)
+	default:
+		return addressObject, false
 	}
+	return addressObject, true
+}
 
+// InitAddressObjects collects address objects of all vdoms, which are matching
+// addressObjectFilter or addressObjectTags.
+func (fs *FortigateSource) InitAddressObjects(ctx context.Context, c APIClient) error {
+	if fs.SourceConfig.AddressObjectFilterRegex == nil && len(fs.SourceConfig.AddressObjectTags) == 0 {
+		return nil
+	}
+	fs.Vdom2AddressObjects = make(map[string][]common.AddressObject)
+	for _, vdom := range fs.Vdoms {
+		addresses, err := getAPIResults[[]AddressResponse](ctx, c, vdomPath("cmdb/firewall/address/", vdom))
+		if err != nil {
+			return fmt.Errorf("address objects of vdom %s: %s", vdom, err)
+		}
+		for _, address := range addresses {
+			addressObject, ok := address.toAddressObject()
+			if ok && common.MatchAddressObject(addressObject, fs.SourceConfig.AddressObjectFilterRegex, fs.SourceConfig.AddressObjectTags) {
+				fs.Vdom2AddressObjects[vdom] = append(fs.Vdom2AddressObjects[vdom], addressObject)
+			}
+		}
+	}
 	return nil
 }
+
+// RouteResponse represents an entry of the fortigate routing table.
+type RouteResponse struct {
+	Type      string `json:"type"`
+	IPMask    string `json:"ip_mask"`
+	Gateway   string `json:"gateway"`
+	Interface string `json:"interface"`
+}
+
+// toRoute converts fortigate route to common route.
+func (r RouteResponse) toRoute() common.Route {
+	route := common.Route{Prefix: r.IPMask, NextHop: r.Gateway, Interface: r.Interface}
+	switch r.Type {
+	case "connect":
+		route.Type = common.RouteTypeConnected
+		route.NextHop = ""
+	case "static":
+		route.Type = common.RouteTypeStatic
+	case "bgp":
+		route.Type = common.RouteTypeBGP
+	}
+	return route
+}
+
+// InitRoutes collects ipv4 and ipv6 routing tables of all vdoms, if collectRoutes is enabled.
+func (fs *FortigateSource) InitRoutes(ctx context.Context, c APIClient) error {
+	if !fs.SourceConfig.CollectRoutes {
+		return nil
+	}
+	fs.Vdom2Routes = make(map[string][]common.Route)
+	for _, vdom := range fs.Vdoms {
+		routes := make([]common.Route, 0)
+		for _, path := range []string{"monitor/router/ipv4/", "monitor/router/ipv6/"} {
+			routeResponses, err := getAPIResults[[]RouteResponse](ctx, c, vdomPath(path, vdom))
+			if err != nil {
+				return fmt.Errorf("routes of vdom %s: %s", vdom, err)
+			}
+			for _, routeResponse := range routeResponses {
+				routes = append(routes, routeResponse.toRoute())
+			}
+		}
+		fs.Vdom2Routes[vdom] = common.FilterRoutes(routes, fs.SourceConfig)
+	}
+	return nil
+}
+
+// BGPResponse represents bgp configuration of the vdom. Autonomous system
+// numbers are returned as numbers or strings (asdot), depending on FortiOS version.
+type BGPResponse struct {
+	AS        json.Number           `json:"as"`
+	RouterID  string                `json:"router-id"`
+	Neighbors []BGPNeighborResponse `json:"neighbor"`
+}
+
+type BGPNeighborResponse struct {
+	IP           string      `json:"ip"`
+	RemoteAS     json.Number `json:"remote-as"`
+	UpdateSource string      `json:"update-source"`
+}
+
+// InitBGP collects bgp configuration of all vdoms, when collectBgp is enabled.
+func (fs *FortigateSource) InitBGP(ctx context.Context, c APIClient) error {
+	fs.Vdom2BGPConfig = make(map[string]common.BGPConfig)
+	if !fs.SourceConfig.CollectBGP {
+		return nil
+	}
+	for _, vdom := range fs.Vdoms {
+		bgpResponse, err := getAPIResults[BGPResponse](ctx, c, vdomPath("cmdb/router/bgp/", vdom))
+		if err != nil {
+			return fmt.Errorf("bgp of vdom %s: %s", vdom, err)
+		}
+		if bgpResponse.AS == "" || bgpResponse.AS == "0" {
+			// Bgp is not configured in the vdom
+			continue
+		}
+		bgpConfig, err := fs.toBGPConfig(bgpResponse)
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "bgp of vdom %s: %s", vdom, err)
+			continue
+		}
+		fs.Vdom2BGPConfig[vdom] = bgpConfig
+	}
+	return nil
+}
+
+// toBGPConfig converts fortigate bgp configuration to common bgp config.
+// Local address of the neighbor is the ip of its update source interface.
+func (fs *FortigateSource) toBGPConfig(bgpResponse BGPResponse) (common.BGPConfig, error) {
+	localAS, err := common.ParseASN(bgpResponse.AS.String())
+	if err != nil {
+		return common.BGPConfig{}, err
+	}
+	bgpConfig := common.BGPConfig{LocalAS: localAS, RouterID: bgpResponse.RouterID}
+	for _, neighbor := range bgpResponse.Neighbors {
+		remoteAS, err := common.ParseASN(neighbor.RemoteAS.String())
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "bgp neighbor %s: %s", neighbor.IP, err)
+			continue
+		}
+		bgpPeer := common.BGPPeer{RemoteAddress: neighbor.IP, RemoteAS: remoteAS}
+		if ifaceIP := strings.Fields(fs.Ifaces[neighbor.UpdateSource].IP); len(ifaceIP) > 0 {
+			bgpPeer.LocalAddress = ifaceIP[0]
+		}
+		bgpConfig.Peers = append(bgpConfig.Peers, bgpPeer)
+	}
+	return bgpConfig, nil
+}
@@ -2,6 +2,8 @@ package fortigate
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -87,6 +89,133 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// syncHAMembers groups all members of fortigate HA cluster into a virtual chassis.
+// Only the primary member answers api requests, so devices for other members
+// are created from the primary's attributes. Interfaces stay on the device
+// of the member that was queried.
+func (fs *FortigateSource) syncHAMembers(nbi *inventory.NetboxInventory) error {
+	if len(fs.HAMembers) < 2 { //nolint:gomnd
+		fs.Logger.Debug(fs.Ctx, "firewall is not part of HA cluster. Skipping...")
+		return nil
+	}
+	// Member with higher priority is preferred, so it gets the lower position
+	haMembers := slices.Clone(fs.HAMembers)
+	slices.SortFunc(haMembers, func(a, b HAMember) int {
+		if a.Priority != b.Priority {
+			return b.Priority - a.Priority
+		}
+		return strings.Compare(a.Serial, b.Serial)
+	})
+
+	vcMembers := make([]common.VirtualChassisMember, 0, len(haMembers))
+	localPosition := 0
+	for i, haMember := range haMembers {
+		memberDevice := fs.NBFirewall
+		if haMember.Serial != fs.SystemInfo.Serial {
+			// Peer inherits attributes of the queried firewall
+			nbPeer, err := nbi.AddDevice(fs.Ctx, &objects.Device{
+				NetboxObject: objects.NetboxObject{
+					Tags: fs.SourceTags,
+				},
+				Name:         haMember.Hostname,
+				Site:         fs.NBFirewall.Site,
+				DeviceRole:   fs.NBFirewall.DeviceRole,
+				Status:       &objects.DeviceStatusActive,
+				DeviceType:   fs.NBFirewall.DeviceType,
+				Tenant:       fs.NBFirewall.Tenant,
+				Platform:     fs.NBFirewall.Platform,
+				SerialNumber: haMember.Serial,
+			})
+			if err != nil {
+				return fmt.Errorf("add ha peer %s: %s", haMember.Hostname, err)
+			}
+			memberDevice = nbPeer
+		} else {
+			localPosition = i + 1
+		}
+		vcMembers = append(vcMembers, common.VirtualChassisMember{
+			Device:   memberDevice,
+			Position: i + 1,
+			Priority: haMember.Priority,
+			Master:   haMember.Primary,
+		})
+	}
+
+	vcName := fs.HAGroupName
+	if vcName == "" {
+		vcName = fmt.Sprintf("%s HA", fs.NBFirewall.Name)
+	}
+	members, err := common.SyncVirtualChassis(fs.Ctx, nbi, fs.SourceTags, fs.SourceConfig.Name, vcName, strconv.Itoa(fs.HAGroupID), vcMembers)
+	if err != nil {
+		return fmt.Errorf("sync virtual chassis: %s", err)
+	}
+	if localPosition > 0 {
+		fs.NBFirewall = members[localPosition]
+	}
+	return nil
+}
+
+// syncVdoms creates virtual device context for each vdom of the firewall. When firewall
+// runs multiple vdoms, each vdom also gets its own vrf, because vdoms have separate
+// routing tables and their address spaces can overlap.
+func (fs *FortigateSource) syncVdoms(nbi *inventory.NetboxInventory) error {
+	for _, vdom := range fs.Vdoms {
+		_, err := nbi.AddVirtualDeviceContext(fs.Ctx, &objects.VirtualDeviceContext{
+			NetboxObject: objects.NetboxObject{
+				Tags: fs.SourceTags,
+			},
+			Name:   vdom,
+			Device: fs.NBFirewall,
+			Status: &objects.VDCStatusActive,
+		})
+		if err != nil {
+			return fmt.Errorf("add VirtualDeviceContext: %s", err)
+		}
+		if len(fs.Vdoms) < 2 { //nolint:gomnd
+			continue
+		}
+		_, err = nbi.AddVRF(fs.Ctx, &objects.VRF{
+			NetboxObject: objects.NetboxObject{
+				Tags:        fs.SourceTags,
+				Description: fmt.Sprintf("Vdom %s", vdom),
+			},
+			Name:   fs.vdomVRFName(vdom),
+			Tenant: fs.NBFirewall.Tenant,
+		})
+		if err != nil {
+			return fmt.Errorf("add vrf: %s", err)
+		}
+	}
+	return nil
+}
+
+// vdomVRFName returns name of the vrf representing the vdom. Vrfs are named after
+// the HA group if firewall is part of HA cluster, so all members share them.
+func (fs *FortigateSource) vdomVRFName(vdom string) string {
+	deviceName := fs.SystemInfo.Hostname
+	if len(fs.HAMembers) > 1 && fs.HAGroupName != "" {
+		deviceName = fs.HAGroupName
+	}
+	return fmt.Sprintf("%s (%s)", deviceName, vdom)
+}
+
+// getVirtualDeviceContext returns already synced virtual device context of the vdom.
+func (fs *FortigateSource) getVirtualDeviceContext(nbi *inventory.NetboxInventory, vdom string) *objects.VirtualDeviceContext {
+	if vdom == "" {
+		return nil
+	}
+	return nbi.VirtualDeviceContextsIndexByNameAndDeviceID[vdom][fs.NBFirewall.ID]
+}
+
+// getVRF returns already synced vrf of the vdom. Nil is returned if firewall
+// runs a single vdom, so its addresses stay in the global routing table.
+func (fs *FortigateSource) getVRF(nbi *inventory.NetboxInventory, vdom string) *objects.VRF {
+	if vdom == "" || len(fs.Vdoms) < 2 { //nolint:gomnd
+		return nil
+	}
+	return nbi.VRFsIndexByName[fs.vdomVRFName(vdom)]
+}
+
 // SyncInterfaces syncs all interfaces for firewall.
 func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error {
 	for _, iface := range fs.Ifaces {
@@ -114,20 +243,10 @@ func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error
 		interfaceMAC := iface.MAC
 
 		var vdcs []*objects.VirtualDeviceContext
-		if iface.Vdom != "" {
-			vdom, err := nbi.AddVirtualDeviceContext(fs.Ctx, &objects.VirtualDeviceContext{
-				NetboxObject: objects.NetboxObject{
-					Tags: fs.SourceTags,
-				},
-				Name:   iface.Vdom,
-				Device: fs.NBFirewall,
-				Status: &objects.VDCStatusActive,
-			})
-			if err != nil {
-				return fmt.Errorf("add VirtualDeviceContext: %s", err)
-			}
-			vdcs = append(vdcs, vdom)
+		if vdc := fs.getVirtualDeviceContext(nbi, iface.Vdom); vdc != nil {
+			vdcs = []*objects.VirtualDeviceContext{vdc}
 		}
+		vrf := fs.getVRF(nbi, iface.Vdom)
 		NBIface, err := nbi.AddInterface(fs.Ctx, &objects.Interface{
 			NetboxObject: objects.NetboxObject{
 				Tags:        fs.SourceTags,
@@ -161,6 +280,7 @@ func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error
 					},
 				},
 				Address:            fmt.Sprintf("%s/%d", ipAndMask[0], maskBits),
+				Vrf:                vrf,
 				AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
 				AssignedObjectID:   NBIface.ID,
 			})
@@ -169,6 +289,13 @@ func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error
 			}
 		}
 
+		if len(iface.Vrrp) > 0 {
+			err = fs.syncVrrpGroups(nbi, NBIface, iface)
+			if err != nil {
+				return fmt.Errorf("sync vrrp groups: %s", err)
+			}
+		}
+
 		if iface.Type == "vlan" {
 			// Add Vlan for interface
 			vlanID := iface.VlanID
@@ -206,6 +333,7 @@ func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error
 					Prefix: prefix,
 					Tenant: NBVlan.Tenant,
 					Vlan:   NBVlan,
+					Vrf:    vrf,
 				})
 				if err != nil {
 					return fmt.Errorf("add prefix: %s", err)
@@ -215,3 +343,227 @@ func (fs *FortigateSource) SyncInterfaces(nbi *inventory.NetboxInventory) error
 	}
 	return nil
 }
+
+// syncVrrpGroups syncs all enabled vrrp groups of the interface as FHRP groups.
+// Virtual ips inherit the mask of the interface's ip address.
+func (fs *FortigateSource) syncVrrpGroups(nbi *inventory.NetboxInventory, nbIface *objects.Interface, iface InterfaceResponse) error {
+	maskBits := 32 //nolint:gomnd
+	ipAndMask := strings.Split(iface.IP, " ")
+	if len(ipAndMask) == 2 && ipAndMask[0] != "0.0.0.0" { //nolint:gomnd
+		ifaceMaskBits, err := utils.MaskToBits(ipAndMask[1])
+		if err != nil {
+			return fmt.Errorf("mask to bits: %s", err)
+		}
+		maskBits = ifaceMaskBits
+	}
+	for _, vrrp := range iface.Vrrp {
+		if vrrp.Status == "disable" || vrrp.Vrip == "" || vrrp.Vrip == "0.0.0.0" {
+			continue
+		}
+		protocol := &objects.FHRPGroupProtocolVRRP2
+		if vrrp.Version == "3" {
+			protocol = &objects.FHRPGroupProtocolVRRP3
+		}
+		_, err := common.SyncFHRPGroup(fs.Ctx, nbi, fs.SourceTags, fs.SourceConfig.Name, protocol, vrrp.Vrid, []string{fmt.Sprintf("%s/%d", vrrp.Vrip, maskBits)}, nil, fs.getVRF(nbi, iface.Vdom), []common.FHRPGroupMember{
+			{Interface: nbIface, Priority: vrrp.Priority},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncIPSecTunnels syncs all route based ipsec tunnels. Each tunnel is terminated on the
+// tunnel interface named after its phase 1. Since netbox proposals only support a single
+// algorithm, the first (most preferred) proposal and dh group of each phase is used.
+func (fs *FortigateSource) syncIPSecTunnels(nbi *inventory.NetboxInventory) error {
+	for _, phase1 := range fs.Phase1s {
+		nbIface, ok := nbi.InterfacesIndexByDeviceIDAndName[fs.NBFirewall.ID][phase1.Name]
+		if !ok {
+			fs.Logger.Debugf(fs.Ctx, "tunnel interface of phase1 %s is not synced. Skipping...", phase1.Name)
+			continue
+		}
+		tunnel := common.IPSecTunnel{
+			Name:      phase1.Name,
+			Status:    &objects.TunnelStatusActive,
+			Comments:  fmt.Sprintf("Peer: %s", phase1.RemoteGW),
+			Interface: nbIface,
+			OutsideIP: fs.getOutsideIP(nbi, phase1),
+			IKEGroup:  fortigateDHGroup(phase1.DHGroup),
+		}
+		tunnel.IKEVersion = &objects.IKEVersion1
+		if phase1.IKEVersion == "2" {
+			tunnel.IKEVersion = &objects.IKEVersion2
+		}
+		switch phase1.Mode {
+		case "aggressive":
+			tunnel.IKEMode = &objects.IKEModeAggressive
+		default:
+			// Main mode is the default mode of phase1 interfaces
+			tunnel.IKEMode = &objects.IKEModeMain
+		}
+		tunnel.AuthenticationMethod = &objects.AuthenticationMethodPresharedKeys
+		if phase1.AuthMethod == "signature" {
+			tunnel.AuthenticationMethod = &objects.AuthenticationMethodCertificates
+		}
+		tunnel.IKEEncryption, tunnel.IKEAuthentication = parseFortigateProposal(phase1.Proposal)
+		tunnel.IKELifetime = phase1.KeyLife
+
+		if phase2s := fs.Phase1ToPhase2s[phase1.Name]; len(phase2s) > 0 {
+			phase2 := phase2s[0]
+			tunnel.IPSecMode = &objects.IPSecModeESP
+			tunnel.IPSecEncryption, tunnel.IPSecAuthentication = parseFortigateProposal(phase2.Proposal)
+			if phase2.PFS == "enable" {
+				tunnel.PFSGroup = fortigateDHGroup(phase2.DHGroup)
+			}
+			if phase2.KeyLifeType != "kbs" {
+				tunnel.IPSecLifetimeSeconds = phase2.KeyLifeSeconds
+			}
+			if phase2.KeyLifeType != "seconds" {
+				tunnel.IPSecLifetimeData = phase2.KeyLifeKBs
+			}
+		}
+		_, err := common.SyncIPSecTunnel(fs.Ctx, nbi, fs.SourceTags, fs.SourceConfig.Name, tunnel)
+		if err != nil {
+			return fmt.Errorf("sync ipsec tunnel %s: %s", phase1.Name, err)
+		}
+	}
+	return nil
+}
+
+// getOutsideIP returns already synced ip address of the interface, that phase 1 is bound to.
+func (fs *FortigateSource) getOutsideIP(nbi *inventory.NetboxInventory, phase1 Phase1InterfaceResponse) *objects.IPAddress {
+	iface, ok := fs.Ifaces[phase1.Interface]
+	if !ok {
+		return nil
+	}
+	ipAndMask := strings.Split(iface.IP, " ")
+	if len(ipAndMask) != 2 || ipAndMask[0] == "0.0.0.0" { //nolint:gomnd
+		return nil
+	}
+	maskBits, err := utils.MaskToBits(ipAndMask[1])
+	if err != nil {
+		return nil
+	}
+	address := fmt.Sprintf("%s/%d", ipAndMask[0], maskBits)
+	nbIPAddress, _ := nbi.GetIPAddress(fs.getVRF(nbi, iface.Vdom), address)
+	return nbIPAddress
+}
+
+// Mappings of fortigate crypto algorithms to netbox algorithms.
+var fortigateEncryption2NBEncryption = map[string]*objects.EncryptionAlgorithm{
+	"des":       &objects.EncryptionAlgorithmDESCBC,
+	"3des":      &objects.EncryptionAlgorithm3DESCBC,
+	"aes128":    &objects.EncryptionAlgorithmAES128CBC,
+	"aes192":    &objects.EncryptionAlgorithmAES192CBC,
+	"aes256":    &objects.EncryptionAlgorithmAES256CBC,
+	"aes128gcm": &objects.EncryptionAlgorithmAES128GCM,
+	"aes256gcm": &objects.EncryptionAlgorithmAES256GCM,
+}
+
+var fortigateAuthentication2NBAuthentication = map[string]*objects.AuthenticationAlgorithm{
+	"md5":    &objects.AuthenticationAlgorithmHMACMD5,
+	"sha1":   &objects.AuthenticationAlgorithmHMACSHA1,
+	"sha256": &objects.AuthenticationAlgorithmHMACSHA256,
+	"sha384": &objects.AuthenticationAlgorithmHMACSHA384,
+	"sha512": &objects.AuthenticationAlgorithmHMACSHA512,
+}
+
+// parseFortigateProposal parses the first proposal from the space separated list of
+// fortigate proposals (e.g. "aes256-sha256 aes128-sha1") into netbox algorithms.
+// Pseudo random functions of AEAD ciphers (e.g. aes256gcm-prfsha384) are ignored.
+func parseFortigateProposal(proposals string) (*objects.EncryptionAlgorithm, *objects.AuthenticationAlgorithm) {
+	fields := strings.Fields(proposals)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	encryption, authentication, _ := strings.Cut(fields[0], "-")
+	return fortigateEncryption2NBEncryption[encryption], fortigateAuthentication2NBAuthentication[authentication]
+}
+
+// fortigateDHGroup converts the first dh group from the space separated
+// list of fortigate dh groups (e.g. "14 5") to netbox DH group.
+func fortigateDHGroup(dhGroups string) *objects.DHGroup {
+	fields := strings.Fields(dhGroups)
+	if len(fields) == 0 {
+		return nil
+	}
+	groupNumber, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil
+	}
+	return objects.DHGroups[groupNumber]
+}
+
+// syncArpTable syncs ip and mac addresses from the arp tables of all vdoms
+// to the vrfs of their vdoms.
+func (fs *FortigateSource) syncArpTable(nbi *inventory.NetboxInventory) error {
+	if !fs.SourceConfig.CollectArpData {
+		fs.Logger.Info(fs.Ctx, "skipping collecting of arp data")
+		return nil
+	}
+	for vdom, vdomArpData := range fs.Vdom2ArpData {
+		arpEntries := make([]common.ArpEntry, 0, len(vdomArpData))
+		for _, entry := range vdomArpData {
+			arpEntries = append(arpEntries, common.ArpEntry{IP: entry.IP, MAC: entry.MAC})
+		}
+		err := common.SyncArpTable(fs.Ctx, nbi, fs.SourceTags, fs.SourceConfig.Name, arpEntries, fs.getVRF(nbi, vdom))
+		if err != nil {
+			return fmt.Errorf("sync arp table of vdom %s: %s", vdom, err)
+		}
+	}
+	return nil
+}
+
+// syncDhcpPools syncs ip ranges of dhcp servers as ip ranges in the vrf of their vdom.
+func (fs *FortigateSource) syncDhcpPools(nbi *inventory.NetboxInventory) error {
+	for vdom, dhcpPools := range fs.Vdom2DhcpPools {
+		err := common.SyncDhcpPools(fs.Ctx, nbi, fs.SourceTags, fs.SystemInfo.Hostname, dhcpPools, fs.getVRF(nbi, vdom))
+		if err != nil {
+			return fmt.Errorf("sync dhcp pools of vdom %s: %s", vdom, err)
+		}
+	}
+	return nil
+}
+
+// syncAddressObjects syncs collected address objects as prefixes, ip ranges
+// and ip addresses in the vrf of their vdom. It has to run after SyncInterfaces
+// and syncArpTable, so their ip addresses take precedence.
+func (fs *FortigateSource) syncAddressObjects(nbi *inventory.NetboxInventory) error {
+	for vdom, addressObjects := range fs.Vdom2AddressObjects {
+		err := common.SyncAddressObjects(fs.Ctx, nbi, fs.SourceTags, addressObjects, fs.getVRF(nbi, vdom))
+		if err != nil {
+			return fmt.Errorf("sync address objects of vdom %s: %s", vdom, err)
+		}
+	}
+	return nil
+}
+
+// syncRoutes syncs networks from routing tables as prefixes in the vrf of their vdom.
+func (fs *FortigateSource) syncRoutes(nbi *inventory.NetboxInventory) error {
+	for vdom, routes := range fs.Vdom2Routes {
+		err := common.SyncRoutes(fs.Ctx, nbi, fs.SourceTags, routes, fs.getVRF(nbi, vdom))
+		if err != nil {
+			return fmt.Errorf("sync routes of vdom %s: %s", vdom, err)
+		}
+	}
+	return nil
+}
+
+// syncBGP syncs bgp configuration of all vdoms, when collectBgp is enabled.
+func (fs *FortigateSource) syncBGP(nbi *inventory.NetboxInventory) error {
+	if !fs.SourceConfig.CollectBGP {
+		return nil
+	}
+	bgpConfigs := make([]common.BGPConfig, 0, len(fs.Vdom2BGPConfig))
+	for vdom, bgpConfig := range fs.Vdom2BGPConfig {
+		bgpConfig.VRF = fs.getVRF(nbi, vdom)
+		bgpConfigs = append(bgpConfigs, bgpConfig)
+	}
+	err := common.SyncBGP(fs.Ctx, nbi, fs.SourceTags, fs.NBFirewall, bgpConfigs)
+	if err != nil {
+		return fmt.Errorf("sync bgp: %s", err)
+	}
+	return nil
+}
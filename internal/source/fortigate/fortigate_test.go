@@ -1 +1,185 @@
 package fortigate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+)
+
+func TestParseFortigateProposal(t *testing.T) {
+	tests := []struct {
+		name               string
+		proposals          string
+		wantEncryption     *objects.EncryptionAlgorithm
+		wantAuthentication *objects.AuthenticationAlgorithm
+	}{
+		{
+			name:               "First of multiple proposals",
+			proposals:          "aes256-sha256 aes128-sha1",
+			wantEncryption:     &objects.EncryptionAlgorithmAES256CBC,
+			wantAuthentication: &objects.AuthenticationAlgorithmHMACSHA256,
+		},
+		{
+			name:           "AEAD cipher with pseudo random function",
+			proposals:      "aes256gcm-prfsha384",
+			wantEncryption: &objects.EncryptionAlgorithmAES256GCM,
+		},
+		{
+			name:               "Null encryption",
+			proposals:          "null-md5",
+			wantAuthentication: &objects.AuthenticationAlgorithmHMACMD5,
+		},
+		{
+			name:      "Empty proposal",
+			proposals: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEncryption, gotAuthentication := parseFortigateProposal(tt.proposals)
+			if gotEncryption != tt.wantEncryption {
+				t.Errorf("parseFortigateProposal() encryption = %v, want %v", gotEncryption, tt.wantEncryption)
+			}
+			if gotAuthentication != tt.wantAuthentication {
+				t.Errorf("parseFortigateProposal() authentication = %v, want %v", gotAuthentication, tt.wantAuthentication)
+			}
+		})
+	}
+}
+
+func TestFortigateDHGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		dhGroups string
+		want     *objects.DHGroup
+	}{
+		{
+			name:     "First of multiple groups",
+			dhGroups: "14 5",
+			want:     objects.DHGroups[14],
+		},
+		{
+			name:     "Unknown group",
+			dhGroups: "3",
+			want:     nil,
+		},
+		{
+			name:     "Empty groups",
+			dhGroups: "",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fortigateDHGroup(tt.dhGroups); got != tt.want {
+				t.Errorf("fortigateDHGroup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVdomPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		vdom string
+		want string
+	}{
+		{
+			name: "Root vdom",
+			path: "cmdb/system/interface/",
+			vdom: "root",
+			want: "cmdb/system/interface/?vdom=root",
+		},
+		{
+			name: "Vdom with special characters",
+			path: "cmdb/vpn.ipsec/phase1-interface/",
+			vdom: "cust a&b",
+			want: "cmdb/vpn.ipsec/phase1-interface/?vdom=cust+a%26b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vdomPath(tt.path, tt.vdom); got != tt.want {
+				t.Errorf("vdomPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFortigateSource_vdomVRFName(t *testing.T) {
+	tests := []struct {
+		name        string
+		hostname    string
+		haGroupName string
+		haMembers   []HAMember
+		vdom        string
+		want        string
+	}{
+		{
+			name:     "Standalone firewall",
+			hostname: "fw01",
+			vdom:     "cust1",
+			want:     "fw01 (cust1)",
+		},
+		{
+			name:        "HA cluster",
+			hostname:    "fw01",
+			haGroupName: "fw-cluster",
+			haMembers:   []HAMember{{Serial: "FG1"}, {Serial: "FG2"}},
+			vdom:        "cust1",
+			want:        "fw-cluster (cust1)",
+		},
+		{
+			name:      "HA cluster without group name",
+			hostname:  "fw01",
+			haMembers: []HAMember{{Serial: "FG1"}, {Serial: "FG2"}},
+			vdom:      "root",
+			want:      "fw01 (root)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &FortigateSource{
+				SystemInfo:  FortiSystemInfo{Hostname: tt.hostname},
+				HAGroupName: tt.haGroupName,
+				HAMembers:   tt.haMembers,
+			}
+			if got := fs.vdomVRFName(tt.vdom); got != tt.want {
+				t.Errorf("FortigateSource.vdomVRFName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFortigateSource_toBGPConfig(t *testing.T) {
+	bgpJSON := `{"as": "1.10", "router-id": "10.255.0.1", "neighbor": [
+		{"ip": "10.0.0.2", "remote-as": 65002, "update-source": "port1"},
+		{"ip": "2001:db8::2", "remote-as": "65003", "update-source": ""}
+	]}`
+	var bgpResponse BGPResponse
+	if err := json.Unmarshal([]byte(bgpJSON), &bgpResponse); err != nil {
+		t.Fatalf("unmarshal bgp response: %s", err)
+	}
+	fs := &FortigateSource{
+		Ifaces: map[string]InterfaceResponse{"port1": {Name: "port1", IP: "10.0.0.1 255.255.255.252"}},
+	}
+	want := common.BGPConfig{
+		LocalAS:  65546,
+		RouterID: "10.255.0.1",
+		Peers: []common.BGPPeer{
+			{RemoteAddress: "10.0.0.2", RemoteAS: 65002, LocalAddress: "10.0.0.1"},
+			{RemoteAddress: "2001:db8::2", RemoteAS: 65003},
+		},
+	}
+	got, err := fs.toBGPConfig(bgpResponse)
+	if err != nil {
+		t.Fatalf("FortigateSource.toBGPConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FortigateSource.toBGPConfig() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package fortimanager
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/source/fortigate"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// Source representing FortiManager, that manages multiple fortigates.
+//
+//nolint:revive
+type FortiManagerSource struct {
+	common.Config
+	// FortiManager data. Initialized in init functions.
+	Adoms          []string                 // Names of all administrative domains
+	ManagedDevices map[string]ManagedDevice // Serial -> Managed device
+
+	// Fortigate sources of managed devices, that are synced
+	// through FortiManager. Initialized in initFortigates.
+	Fortigates map[string]*fortigate.FortigateSource // Serial -> Fortigate source
+	// Serials of managed fortigates, that are not connected or failed to initialize
+	UnreachableFortigates []string
+}
+
+func (fms *FortiManagerSource) Init() error {
+	HTTPClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !fms.SourceConfig.ValidateCert,
+			},
+		},
+	}
+	c := newFMGClient(fms.SourceConfig.APIToken, string(fms.SourceConfig.HTTPScheme), fms.SourceConfig.Hostname, fms.SourceConfig.Port, HTTPClient)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Api token is preferred, otherwise we log in with username and password
+	if c.APIToken == "" {
+		if err := c.Login(ctx, fms.SourceConfig.Username, fms.SourceConfig.Password); err != nil {
+			return fmt.Errorf("fortimanager login: %s", err)
+		}
+		defer func() {
+			if err := c.Logout(ctx); err != nil {
+				fms.Logger.Warningf(fms.Ctx, "error occurred when ending fortimanager session: %s", err)
+			}
+		}()
+	}
+
+	initFunctions := []func(context.Context, *fmgClient) error{
+		fms.initAdoms,
+		fms.initManagedDevices,
+		fms.initFortigates,
+	}
+	for _, initFunc := range initFunctions {
+		startTime := time.Now()
+		if err := initFunc(ctx, c); err != nil {
+			return fmt.Errorf("fortimanager initialization failure: %v", err)
+		}
+		duration := time.Since(startTime)
+		fms.Logger.Infof(fms.Ctx, "Successfully initialized %s in %f seconds", utils.ExtractFunctionName(initFunc), duration.Seconds())
+	}
+	return nil
+}
+
+// Sync syncs each managed fortigate using fortigate source's sync logic.
+// Reachable fortigates are synced, even if some fortigates are unreachable.
+func (fms *FortiManagerSource) Sync(nbi *inventory.NetboxInventory) error {
+	serials := make([]string, 0, len(fms.Fortigates))
+	for serial := range fms.Fortigates {
+		serials = append(serials, serial)
+	}
+	slices.Sort(serials)
+	for _, serial := range serials {
+		startTime := time.Now()
+		err := fms.Fortigates[serial].Sync(nbi)
+		if err != nil {
+			return fmt.Errorf("sync fortigate %s: %s", serial, err)
+		}
+		duration := time.Since(startTime)
+		fms.Logger.Infof(fms.Ctx, "Successfully synced fortigate %s (%s) in %f seconds", fms.ManagedDevices[serial].Name, serial, duration.Seconds())
+	}
+	// Objects of unreachable fortigates are not synced, so the run must fail to keep them from orphan cleanup
+	if len(fms.UnreachableFortigates) > 0 {
+		slices.Sort(fms.UnreachableFortigates)
+		return fmt.Errorf("fortigates %v are unreachable, their objects were not synced", fms.UnreachableFortigates)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package fortimanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+// fmgClient is a client for FortiManager JSON-RPC api. Requests are authenticated
+// either with api token, or with session obtained by logging in with username and password.
+type fmgClient struct {
+	HTTPClient     *http.Client
+	BaseURL        string
+	APIToken       string
+	Session        string
+	DefaultTimeout time.Duration
+	// ID of the last JSON-RPC request.
+	requestID int
+}
+
+func newFMGClient(apiToken string, httpScheme string, hostname string, port int, httpClient *http.Client) *fmgClient {
+	return &fmgClient{
+		HTTPClient:     httpClient,
+		BaseURL:        fmt.Sprintf("%s://%s:%d/jsonrpc", httpScheme, hostname, port),
+		APIToken:       apiToken,
+		DefaultTimeout: time.Second * constants.DefaultAPITimeout,
+	}
+}
+
+type jsonRPCRequest struct {
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  []jsonRPCParams `json:"params"`
+	Session string          `json:"session,omitempty"`
+}
+
+type jsonRPCParams struct {
+	URL    string   `json:"url"`
+	Data   any      `json:"data,omitempty"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+type jsonRPCResponse[T any] struct {
+	ID      int                `json:"id"`
+	Result  []jsonRPCResult[T] `json:"result"`
+	Session string             `json:"session"`
+}
+
+type jsonRPCResult[T any] struct {
+	Data   T             `json:"data"`
+	Status jsonRPCStatus `json:"status"`
+	URL    string        `json:"url"`
+}
+
+type jsonRPCStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// doRequest sends JSON-RPC request with method on the given url to FortiManager, and returns its response.
+func doRequest[T any](ctx context.Context, c *fmgClient, method string, params jsonRPCParams) (*jsonRPCResponse[T], error) {
+	c.requestID++
+	reqBody, err := json.Marshal(jsonRPCRequest{
+		ID:      c.requestID,
+		Method:  method,
+		Params:  []jsonRPCParams{params},
+		Session: c.Session,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.DefaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("new request with context: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIToken)
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request error: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got http status: %d", res.StatusCode) //nolint:goerr113
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("body read error: %w", err)
+	}
+	var rpcResponse jsonRPCResponse[T]
+	if err := json.Unmarshal(body, &rpcResponse); err != nil {
+		return nil, fmt.Errorf("body unmarshal error: %w", err)
+	}
+	if len(rpcResponse.Result) == 0 {
+		return nil, fmt.Errorf("empty result for %s", params.URL) //nolint:goerr113
+	}
+	if status := rpcResponse.Result[0].Status; status.Code != 0 {
+		return nil, fmt.Errorf("%s %s: %s (code %d)", method, params.URL, status.Message, status.Code) //nolint:goerr113
+	}
+	return &rpcResponse, nil
+}
+
+// call sends JSON-RPC request to FortiManager and returns data of its result.
+func call[T any](ctx context.Context, c *fmgClient, method string, params jsonRPCParams) (T, error) {
+	rpcResponse, err := doRequest[T](ctx, c, method, params)
+	if err != nil {
+		var data T
+		return data, err
+	}
+	return rpcResponse.Result[0].Data, nil
+}
+
+type loginData struct {
+	User   string `json:"user"`
+	Passwd string `json:"passwd"`
+}
+
+// Login obtains session, that is used for all further requests.
+func (c *fmgClient) Login(ctx context.Context, username, password string) error {
+	rpcResponse, err := doRequest[json.RawMessage](ctx, c, "exec", jsonRPCParams{
+		URL:  "/sys/login/user",
+		Data: loginData{User: username, Passwd: password},
+	})
+	if err != nil {
+		return err
+	}
+	if rpcResponse.Session == "" {
+		return fmt.Errorf("failed extracting session from login response") //nolint:goerr113
+	}
+	c.Session = rpcResponse.Session
+	return nil
+}
+
+// Logout ends the session obtained by Login.
+func (c *fmgClient) Logout(ctx context.Context) error {
+	if c.Session == "" {
+		return nil
+	}
+	_, err := call[json.RawMessage](ctx, c, "exec", jsonRPCParams{URL: "/sys/logout"})
+	if err != nil {
+		return err
+	}
+	c.Session = ""
+	return nil
+}
+
+type proxyData struct {
+	Target   []string        `json:"target"`
+	Action   string          `json:"action"`
+	Resource string          `json:"resource"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+}
+
+type proxyResult struct {
+	Response json.RawMessage `json:"response"`
+	Status   jsonRPCStatus   `json:"status"`
+	Target   string          `json:"target"`
+}
+
+// proxyClient makes requests to the rest api of a managed fortigate through
+// FortiManager's proxy. It implements fortigate.APIClient, so the managed
+// fortigate can be initialized the same way as a standalone one.
+type proxyClient struct {
+	Client *fmgClient
+	// Target of the proxied requests in format "adom/<adom>/device/<device>".
+	Target string
+}
+
+// MakeRequest proxies request on fortigate's api path (e.g. "cmdb/system/interface/")
+// and returns fortigate's response as the body of http response.
+func (c *proxyClient) MakeRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	data := proxyData{
+		Target:   []string{c.Target},
+		Action:   strings.ToLower(method),
+		Resource: "/api/v2/" + path,
+	}
+	if body != nil {
+		payload, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("read body: %w", err)
+		}
+		data.Payload = payload
+	}
+	results, err := call[[]proxyResult](ctx, c.Client, "exec", jsonRPCParams{URL: "/sys/proxy/json", Data: data})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("empty proxy response from %s", c.Target) //nolint:goerr113
+	}
+	if status := results[0].Status; status.Code != 0 {
+		return nil, fmt.Errorf("proxy request to %s: %s (code %d)", c.Target, status.Message, status.Code) //nolint:goerr113
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(results[0].Response)),
+	}, nil
+}
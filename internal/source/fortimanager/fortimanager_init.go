@@ -0,0 +1,87 @@
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bl4ko/netbox-ssot/internal/source/fortigate"
+)
+
+// Connection status of the managed device, when FortiManager can reach it.
+const connStatusUp = 1
+
+type AdomResponse struct {
+	Name string `json:"name"`
+}
+
+// ManagedDevice represents a fortigate managed by FortiManager.
+type ManagedDevice struct {
+	Name       string `json:"name"`
+	Hostname   string `json:"hostname"`
+	Serial     string `json:"sn"`
+	ConnStatus int    `json:"conn_status"`
+	// Administrative domain of the device, it is not part of the response.
+	Adom string `json:"-"`
+}
+
+// ProxyTarget returns target of the device used for proxied requests.
+func (d ManagedDevice) ProxyTarget() string {
+	return fmt.Sprintf("adom/%s/device/%s", d.Adom, d.Name)
+}
+
+// initAdoms collects names of all administrative domains.
+func (fms *FortiManagerSource) initAdoms(ctx context.Context, c *fmgClient) error {
+	adoms, err := call[[]AdomResponse](ctx, c, "get", jsonRPCParams{URL: "/dvmdb/adom", Fields: []string{"name"}})
+	if err != nil {
+		return fmt.Errorf("adoms: %s", err)
+	}
+	fms.Adoms = make([]string, 0, len(adoms))
+	for _, adom := range adoms {
+		fms.Adoms = append(fms.Adoms, adom.Name)
+	}
+	return nil
+}
+
+// initManagedDevices collects fortigates of all adoms. Devices that are not connected
+// to FortiManager are marked as unreachable, because they can't be proxied.
+func (fms *FortiManagerSource) initManagedDevices(ctx context.Context, c *fmgClient) error {
+	fms.ManagedDevices = make(map[string]ManagedDevice)
+	fms.UnreachableFortigates = nil
+	for _, adom := range fms.Adoms {
+		devices, err := call[[]ManagedDevice](ctx, c, "get", jsonRPCParams{
+			URL:    fmt.Sprintf("/dvmdb/adom/%s/device", adom),
+			Fields: []string{"name", "hostname", "sn", "conn_status"},
+		})
+		if err != nil {
+			return fmt.Errorf("devices of adom %s: %s", adom, err)
+		}
+		for _, device := range devices {
+			if device.ConnStatus != connStatusUp {
+				fms.Logger.Warningf(fms.Ctx, "device %s in adom %s is not connected. Skipping...", device.Name, adom)
+				fms.UnreachableFortigates = append(fms.UnreachableFortigates, device.Serial)
+				continue
+			}
+			device.Adom = adom
+			fms.ManagedDevices[device.Serial] = device
+		}
+	}
+	return nil
+}
+
+// initFortigates creates fortigate source for each managed device. Requests of each
+// fortigate are proxied through FortiManager. Fortigates that fail to initialize are
+// marked as unreachable, so they don't block the others.
+func (fms *FortiManagerSource) initFortigates(ctx context.Context, c *fmgClient) error {
+	fms.Fortigates = make(map[string]*fortigate.FortigateSource)
+	for serial, device := range fms.ManagedDevices {
+		fs := &fortigate.FortigateSource{Config: fms.Config}
+		err := fs.InitWithClient(ctx, &proxyClient{Client: c, Target: device.ProxyTarget()})
+		if err != nil {
+			fms.Logger.Errorf(fms.Ctx, "failed to initialize fortigate %s (%s): %s", device.Name, serial, err)
+			fms.UnreachableFortigates = append(fms.UnreachableFortigates, serial)
+			continue
+		}
+		fms.Fortigates[serial] = fs
+	}
+	return nil
+}
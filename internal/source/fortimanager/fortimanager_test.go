@@ -0,0 +1,129 @@
+package fortimanager
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer creates FortiManager JSON-RPC server, which responds
+// to each request with the response of handler for the request's url.
+func newTestServer(t *testing.T, handler func(req jsonRPCRequest) string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(handler(req)))
+	}))
+}
+
+func newTestClient(server *httptest.Server) *fmgClient {
+	return &fmgClient{
+		HTTPClient:     server.Client(),
+		BaseURL:        server.URL + "/jsonrpc",
+		DefaultTimeout: time.Second,
+	}
+}
+
+func TestFmgClient_Login(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		wantSession string
+		wantErr     bool
+	}{
+		{
+			name:        "Successful login",
+			response:    `{"id":1,"result":[{"status":{"code":0,"message":"OK"},"url":"/sys/login/user"}],"session":"session-id"}`,
+			wantSession: "session-id",
+		},
+		{
+			name:     "Invalid credentials",
+			response: `{"id":1,"result":[{"status":{"code":-22,"message":"Login fail"},"url":"/sys/login/user"}]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(_ jsonRPCRequest) string { return tt.response })
+			defer server.Close()
+			c := newTestClient(server)
+			err := c.Login(context.Background(), "user", "pass")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fmgClient.Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.Session != tt.wantSession {
+				t.Errorf("fmgClient.Session = %s, want %s", c.Session, tt.wantSession)
+			}
+		})
+	}
+}
+
+func TestProxyClient_MakeRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "Fortigate response is returned",
+			response: `{"id":1,"result":[{"data":[{"response":{"http_status":200,"serial":"FGT1","results":[]},"status":{"code":0,"message":"OK"},"target":"FGT1"}],"status":{"code":0,"message":"OK"},"url":"/sys/proxy/json"}]}`,
+			wantBody: `{"http_status":200,"serial":"FGT1","results":[]}`,
+		},
+		{
+			name:     "Device is unreachable",
+			response: `{"id":1,"result":[{"data":[{"status":{"code":-10,"message":"timeout"},"target":"FGT1"}],"status":{"code":0,"message":"OK"},"url":"/sys/proxy/json"}]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReq jsonRPCRequest
+			server := newTestServer(t, func(req jsonRPCRequest) string {
+				gotReq = req
+				return tt.response
+			})
+			defer server.Close()
+			c := newTestClient(server)
+			c.Session = "session-id"
+			proxy := &proxyClient{Client: c, Target: "adom/root/device/FGT1"}
+			res, err := proxy.MakeRequest(context.Background(), http.MethodGet, "cmdb/system/interface/?vdom=root", nil)
+			if gotReq.Session != "session-id" {
+				t.Errorf("request session = %s, want session-id", gotReq.Session)
+			}
+			if len(gotReq.Params) != 1 || gotReq.Params[0].URL != "/sys/proxy/json" {
+				t.Errorf("request params = %v, want proxy request", gotReq.Params)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("proxyClient.MakeRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("proxyClient.MakeRequest() body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestManagedDevice_ProxyTarget(t *testing.T) {
+	device := ManagedDevice{Name: "fgt-branch-1", Adom: "Branches"}
+	want := "adom/Branches/device/fgt-branch-1"
+	if got := device.ProxyTarget(); got != want {
+		t.Errorf("ManagedDevice.ProxyTarget() = %v, want %v", got, want)
+	}
+}
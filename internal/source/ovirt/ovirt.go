@@ -24,6 +24,11 @@ type OVirtSource struct {
 	Vms         map[string]*ovirtsdk4.Vm
 	Networks    *NetworkData
 
+	VMPools        map[string]*ovirtsdk4.VmPool
+	StorageDomains map[string]*ovirtsdk4.StorageDomain
+	// Object2AffinityLabels is a map of affinity labels attached to hosts and vms
+	Object2AffinityLabels map[string][]string // ObjectID -> AffinityLabelNames
+
 	HostSiteRelations      map[string]string
 	ClusterSiteRelations   map[string]string
 	ClusterTenantRelations map[string]string
@@ -31,6 +36,8 @@ type OVirtSource struct {
 	VMTenantRelations      map[string]string
 	VlanGroupRelations     map[string]string
 	VlanTenantRelations    map[string]string
+	TagTenantRelations     map[string]string
+	TagRoleRelations       map[string]string
 }
 
 type NetworkData struct {
@@ -57,6 +64,10 @@ func (o *OVirtSource) Init() error {
 	o.Logger.Debug(o.Ctx, "VlanGroupRelations: ", o.VlanGroupRelations)
 	o.VlanTenantRelations = utils.ConvertStringsToRegexPairs(o.SourceConfig.VlanTenantRelations)
 	o.Logger.Debug(o.Ctx, "VlanTenantRelations: ", o.VlanTenantRelations)
+	o.TagTenantRelations = utils.ConvertStringsToRegexPairs(o.SourceConfig.TagTenantRelations)
+	o.Logger.Debug(o.Ctx, "TagTenantRelations: ", o.TagTenantRelations)
+	o.TagRoleRelations = utils.ConvertStringsToRegexPairs(o.SourceConfig.TagRoleRelations)
+	o.Logger.Debug(o.Ctx, "TagRoleRelations: ", o.TagRoleRelations)
 	// Initialize the connection
 	o.Logger.Debug(o.Ctx, "Initializing oVirt source ", o.SourceConfig.Name)
 	conn, err := ovirtsdk4.NewConnectionBuilder().
@@ -75,11 +86,14 @@ func (o *OVirtSource) Init() error {
 	// Initialize items to local storage
 	initFunctions := []func(*ovirtsdk4.Connection) error{
 		o.InitNetworks,
+		o.InitStorageDomains,
 		o.InitDisks,
 		o.InitDataCenters,
 		o.InitClusters,
 		o.InitHosts,
 		o.InitVms,
+		o.InitVMPools,
+		o.InitAffinityLabels,
 	}
 
 	for _, initFunc := range initFunctions {
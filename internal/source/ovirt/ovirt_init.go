@@ -130,3 +130,75 @@ func (o *OVirtSource) InitVms(conn *ovirtsdk4.Connection) error {
 	}
 	return nil
 }
+
+// Function that queries the ovirt api for storage domains and stores them locally.
+func (o *OVirtSource) InitStorageDomains(conn *ovirtsdk4.Connection) error {
+	storageDomainsResponse, err := conn.SystemService().StorageDomainsService().List().Send()
+	if err != nil {
+		return fmt.Errorf("failed to get oVirt storage domains: %v", err)
+	}
+	o.StorageDomains = make(map[string]*ovirtsdk4.StorageDomain)
+	if storageDomains, ok := storageDomainsResponse.StorageDomains(); ok {
+		for _, storageDomain := range storageDomains.Slice() {
+			o.StorageDomains[storageDomain.MustId()] = storageDomain
+		}
+		o.Logger.Debug(o.Ctx, "Successfully initialized oVirt storage domains: ", o.StorageDomains)
+	} else {
+		o.Logger.Warning(o.Ctx, "Error initializing oVirt storage domains")
+	}
+	return nil
+}
+
+// Function that queries the ovirt api for vm pools and stores them locally.
+func (o *OVirtSource) InitVMPools(conn *ovirtsdk4.Connection) error {
+	vmPoolsResponse, err := conn.SystemService().VmPoolsService().List().Send()
+	if err != nil {
+		return fmt.Errorf("failed to get oVirt vm pools: %v", err)
+	}
+	o.VMPools = make(map[string]*ovirtsdk4.VmPool)
+	if vmPools, ok := vmPoolsResponse.Pools(); ok {
+		for _, vmPool := range vmPools.Slice() {
+			o.VMPools[vmPool.MustId()] = vmPool
+		}
+		o.Logger.Debug(o.Ctx, "Successfully initialized oVirt vm pools: ", o.VMPools)
+	} else {
+		o.Logger.Warning(o.Ctx, "Error initializing oVirt vm pools")
+	}
+	return nil
+}
+
+// Function that queries the ovirt api for affinity labels, and stores
+// names of labels attached to each host and vm.
+func (o *OVirtSource) InitAffinityLabels(conn *ovirtsdk4.Connection) error {
+	labelsResponse, err := conn.SystemService().AffinityLabelsService().List().Follow("hosts,vms").Send()
+	if err != nil {
+		return fmt.Errorf("failed to get oVirt affinity labels: %v", err)
+	}
+	o.Object2AffinityLabels = make(map[string][]string)
+	if labels, ok := labelsResponse.Labels(); ok {
+		for _, label := range labels.Slice() {
+			labelName, ok := label.Name()
+			if !ok {
+				continue
+			}
+			if hosts, ok := label.Hosts(); ok {
+				for _, host := range hosts.Slice() {
+					if hostID, ok := host.Id(); ok {
+						o.Object2AffinityLabels[hostID] = append(o.Object2AffinityLabels[hostID], labelName)
+					}
+				}
+			}
+			if vms, ok := label.Vms(); ok {
+				for _, vm := range vms.Slice() {
+					if vmID, ok := vm.Id(); ok {
+						o.Object2AffinityLabels[vmID] = append(o.Object2AffinityLabels[vmID], labelName)
+					}
+				}
+			}
+		}
+		o.Logger.Debug(o.Ctx, "Successfully initialized oVirt affinity labels: ", o.Object2AffinityLabels)
+	} else {
+		o.Logger.Warning(o.Ctx, "Error initializing oVirt affinity labels")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ovirt
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -171,6 +172,17 @@ func (o *OVirtSource) syncHosts(nbi *inventory.NetboxInventory) error {
 		if err != nil {
 			return fmt.Errorf("hostTenant: %s", err)
 		}
+		labelTenant, err := common.MatchTagsToTenant(o.Ctx, nbi, o.Object2AffinityLabels[hostID], o.TagTenantRelations)
+		if err != nil {
+			return fmt.Errorf("match host affinity labels to tenant: %s", err)
+		}
+		if labelTenant != nil {
+			hostTenant = labelTenant
+		}
+		hostTags, err := o.syncAffinityLabels(nbi, hostID)
+		if err != nil {
+			return fmt.Errorf("sync host affinity labels: %s", err)
+		}
 
 		var hostSerialNumber, manufacturerName, hostAssetTag, hostModel string
 		hwInfo, exists := host.HardwareInformation()
@@ -282,7 +294,7 @@ func (o *OVirtSource) syncHosts(nbi *inventory.NetboxInventory) error {
 		nbHost := &objects.Device{
 			NetboxObject: objects.NetboxObject{
 				Description: hostDescription,
-				Tags:        o.Config.SourceTags,
+				Tags:        hostTags,
 				CustomFields: map[string]interface{}{
 					constants.CustomFieldSourceName:       o.SourceConfig.Name,
 					constants.CustomFieldHostCPUCoresName: hostCPUCores,
@@ -629,6 +641,10 @@ func (o *OVirtSource) collectHostNicsData(nbHost *objects.Device, nbi *inventory
 
 // syncVms synces ovirt vms into netbox inventory.
 func (o *OVirtSource) syncVms(nbi *inventory.NetboxInventory) error {
+	err := o.addVMCustomFields(nbi)
+	if err != nil {
+		return fmt.Errorf("add vm custom fields: %s", err)
+	}
 	for vmID, ovirtVM := range o.Vms {
 		collectedVM, err := o.extractVMData(nbi, vmID, ovirtVM)
 		if err != nil {
@@ -674,6 +690,24 @@ func (o *OVirtSource) extractVMData(nbi *inventory.NetboxInventory, vmID string,
 		vmSite = vmCluster.Site
 	}
 
+	// Tenant and role can also be received from vm's affinity labels
+	labelTenant, err := common.MatchTagsToTenant(o.Ctx, nbi, o.Object2AffinityLabels[vmID], o.TagTenantRelations)
+	if err != nil {
+		return nil, fmt.Errorf("match vm affinity labels to tenant: %s", err)
+	}
+	if labelTenant != nil {
+		vmTenant = labelTenant
+		vmTenantGroup = labelTenant.Group
+	}
+	vmRole, err := common.MatchTagsToVMRole(o.Ctx, nbi, o.Object2AffinityLabels[vmID], o.TagRoleRelations)
+	if err != nil {
+		return nil, fmt.Errorf("match vm affinity labels to role: %s", err)
+	}
+	vmTags, err := o.syncAffinityLabels(nbi, vmID)
+	if err != nil {
+		return nil, fmt.Errorf("sync vm affinity labels: %s", err)
+	}
+
 	// VM's Status
 	var vmStatus *objects.VMStatus
 	status, exists := vm.Status()
@@ -716,14 +750,26 @@ func (o *OVirtSource) extractVMData(nbi *inventory.NetboxInventory, vmID string,
 	}
 
 	// Disks
-	var vmDiskSizeBytes int64
-	if diskAttachment, exists := vm.DiskAttachments(); exists {
-		for _, diskAttachment := range diskAttachment.Slice() {
-			if ovirtDisk, exists := diskAttachment.Disk(); exists {
-				disk := o.Disks[ovirtDisk.MustId()]
-				if provisionedDiskSize, exists := disk.ProvisionedSize(); exists {
-					vmDiskSizeBytes += provisionedDiskSize
-				}
+	vmStorageDomains, vmDisks, vmDiskSizeBytes := o.vmStorage(vm)
+
+	// Storage and pool custom fields are cleared, when disks are detached or
+	// the vm is removed from the pool
+	vmCustomFields := map[string]interface{}{
+		constants.CustomFieldSourceName:           o.SourceConfig.Name,
+		constants.CustomFieldVMStorageDomainsName: nil,
+		constants.CustomFieldVMDisksName:          nil,
+		constants.CustomFieldVMPoolName:           nil,
+	}
+	if len(vmStorageDomains) > 0 {
+		vmCustomFields[constants.CustomFieldVMStorageDomainsName] = strings.Join(vmStorageDomains, ", ")
+	}
+	if len(vmDisks) > 0 {
+		vmCustomFields[constants.CustomFieldVMDisksName] = strings.Join(vmDisks, ", ")
+	}
+	if vmPool, exists := vm.VmPool(); exists {
+		if pool, ok := o.VMPools[vmPool.MustId()]; ok {
+			if poolName, ok := pool.Name(); ok {
+				vmCustomFields[constants.CustomFieldVMPoolName] = poolName
 			}
 		}
 	}
@@ -762,7 +808,7 @@ func (o *OVirtSource) extractVMData(nbi *inventory.NetboxInventory, vmID string,
 		}
 	}
 	platformName := utils.GeneratePlatformName(vmOsType, vmOsVersion)
-	vmPlatform, err := nbi.AddPlatform(o.Ctx, &objects.Platform{
+	vmPlatform, err = nbi.AddPlatform(o.Ctx, &objects.Platform{
 		Name: platformName,
 		Slug: utils.Slugify(platformName),
 	})
@@ -772,12 +818,11 @@ func (o *OVirtSource) extractVMData(nbi *inventory.NetboxInventory, vmID string,
 
 	return &objects.VM{
 		NetboxObject: objects.NetboxObject{
-			Tags: o.Config.SourceTags,
-			CustomFields: map[string]interface{}{
-				constants.CustomFieldSourceName: o.SourceConfig.Name,
-			},
+			Tags:         vmTags,
+			CustomFields: vmCustomFields,
 		},
 		Name:        vmName,
+		Role:        vmRole,
 		Cluster:     vmCluster,
 		Site:        vmSite,
 		Tenant:      vmTenant,
@@ -792,6 +837,96 @@ func (o *OVirtSource) extractVMData(nbi *inventory.NetboxInventory, vmID string,
 	}, nil
 }
 
+// vmStorage returns names of storage domains of vm's disks, description of each
+// disk (e.g. "disk1 (data1): 50 GB") and total provisioned size of disks in bytes.
+func (o *OVirtSource) vmStorage(vm *ovirtsdk4.Vm) ([]string, []string, int64) {
+	storageDomains := []string{}
+	disks := []string{}
+	var totalSizeBytes int64
+	diskAttachments, exists := vm.DiskAttachments()
+	if !exists {
+		return storageDomains, disks, totalSizeBytes
+	}
+	for _, diskAttachment := range diskAttachments.Slice() {
+		ovirtDisk, exists := diskAttachment.Disk()
+		if !exists {
+			continue
+		}
+		disk, ok := o.Disks[ovirtDisk.MustId()]
+		if !ok {
+			continue
+		}
+		provisionedDiskSize, _ := disk.ProvisionedSize()
+		totalSizeBytes += provisionedDiskSize
+
+		diskStorageDomains := []string{}
+		if diskDomains, exists := disk.StorageDomains(); exists {
+			for _, diskDomain := range diskDomains.Slice() {
+				domainID, ok := diskDomain.Id()
+				if !ok {
+					continue
+				}
+				domainName := domainID
+				if storageDomain, ok := o.StorageDomains[domainID]; ok {
+					if name, ok := storageDomain.Name(); ok {
+						domainName = name
+					}
+				}
+				diskStorageDomains = append(diskStorageDomains, domainName)
+				if !slices.Contains(storageDomains, domainName) {
+					storageDomains = append(storageDomains, domainName)
+				}
+			}
+		}
+
+		diskName, exists := disk.Alias()
+		if !exists {
+			diskName = disk.MustId()
+		}
+		diskSizeGB := provisionedDiskSize / constants.KiB / constants.KiB / constants.KiB
+		if len(diskStorageDomains) > 0 {
+			disks = append(disks, fmt.Sprintf("%s (%s): %d GB", diskName, strings.Join(diskStorageDomains, ", "), diskSizeGB))
+		} else {
+			disks = append(disks, fmt.Sprintf("%s: %d GB", diskName, diskSizeGB))
+		}
+	}
+	return storageDomains, disks, totalSizeBytes
+}
+
+// syncAffinityLabels syncs affinity labels attached to the object as netbox tags,
+// filtered with vmTagAllowlist. Returned tags also include source tags.
+func (o *OVirtSource) syncAffinityLabels(nbi *inventory.NetboxInventory, objectID string) ([]*objects.Tag, error) {
+	tags := make([]*objects.Tag, 0, len(o.SourceTags))
+	tags = append(tags, o.SourceTags...)
+	for _, label := range o.Object2AffinityLabels[objectID] {
+		if len(o.SourceConfig.VMTagAllowlist) > 0 && !slices.Contains(o.SourceConfig.VMTagAllowlist, label) {
+			continue
+		}
+		tagName := o.SourceConfig.VMTagPrefix + label
+		tag, err := nbi.AddTag(o.Ctx, &objects.Tag{
+			Name:        tagName,
+			Slug:        utils.Slugify(tagName),
+			Color:       constants.DefaultVMTagColor,
+			Description: fmt.Sprintf("oVirt affinity label %s", label),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add tag %s: %s", tagName, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// addVMCustomFields adds custom fields for storing vm's pool and storage,
+// if they don't exist yet.
+func (o *OVirtSource) addVMCustomFields(nbi *inventory.NetboxInventory) error {
+	return common.AddVMCustomFields(o.Ctx, nbi, []common.VMCustomField{
+		{Name: constants.CustomFieldVMPoolName, Label: constants.CustomFieldVMPoolLabel, Description: constants.CustomFieldVMPoolDescription},
+		{Name: constants.CustomFieldVMStorageDomainsName, Label: constants.CustomFieldVMStorageDomainsLabel, Description: constants.CustomFieldVMStorageDomainsDescription},
+		{Name: constants.CustomFieldVMDisksName, Label: constants.CustomFieldVMDisksLabel, Description: constants.CustomFieldVMDisksDescription},
+	})
+}
+
 // syncVMInterfaces is a helper function for syncVMS. It syncs all interfaces from a VM to netbox.
 func (o *OVirtSource) syncVMInterfaces(nbi *inventory.NetboxInventory, ovirtVM *ovirtsdk4.Vm, netboxVM *objects.VM) error {
 	err := o.syncVMNics(nbi, ovirtVM, netboxVM)
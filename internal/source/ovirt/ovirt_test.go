@@ -1 +1,70 @@
 package ovirt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func TestOVirtSource_vmStorage(t *testing.T) {
+	storageDomain1 := ovirtsdk4.NewStorageDomainBuilder().Id("sd1").Name("data1").MustBuild()
+	storageDomain2 := ovirtsdk4.NewStorageDomainBuilder().Id("sd2").Name("data2").MustBuild()
+	disk1 := ovirtsdk4.NewDiskBuilder().Id("d1").Alias("vm1_Disk1").ProvisionedSize(50 * constants.GiB).StorageDomainsOfAny(ovirtsdk4.NewStorageDomainBuilder().Id("sd1").MustBuild()).MustBuild()
+	disk2 := ovirtsdk4.NewDiskBuilder().Id("d2").Alias("vm1_Disk2").ProvisionedSize(100 * constants.GiB).StorageDomainsOfAny(ovirtsdk4.NewStorageDomainBuilder().Id("sd2").MustBuild()).MustBuild()
+	disk3 := ovirtsdk4.NewDiskBuilder().Id("d3").Alias("vm2_Disk1").ProvisionedSize(10 * constants.GiB).MustBuild()
+	o := &OVirtSource{
+		Disks: map[string]*ovirtsdk4.Disk{"d1": disk1, "d2": disk2, "d3": disk3},
+		StorageDomains: map[string]*ovirtsdk4.StorageDomain{
+			"sd1": storageDomain1,
+			"sd2": storageDomain2,
+		},
+	}
+	diskAttachment := func(diskID string) *ovirtsdk4.DiskAttachment {
+		return ovirtsdk4.NewDiskAttachmentBuilder().Disk(ovirtsdk4.NewDiskBuilder().Id(diskID).MustBuild()).MustBuild()
+	}
+	tests := []struct {
+		name               string
+		vm                 *ovirtsdk4.Vm
+		wantStorageDomains []string
+		wantDisks          []string
+		wantSize           int64
+	}{
+		{
+			name:               "Vm without disks",
+			vm:                 ovirtsdk4.NewVmBuilder().Id("vm0").MustBuild(),
+			wantStorageDomains: []string{},
+			wantDisks:          []string{},
+			wantSize:           0,
+		},
+		{
+			name:               "Vm with disks on multiple storage domains",
+			vm:                 ovirtsdk4.NewVmBuilder().Id("vm1").DiskAttachmentsOfAny(diskAttachment("d1"), diskAttachment("d2")).MustBuild(),
+			wantStorageDomains: []string{"data1", "data2"},
+			wantDisks:          []string{"vm1_Disk1 (data1): 50 GB", "vm1_Disk2 (data2): 100 GB"},
+			wantSize:           150 * constants.GiB,
+		},
+		{
+			name:               "Vm with disk without storage domain",
+			vm:                 ovirtsdk4.NewVmBuilder().Id("vm2").DiskAttachmentsOfAny(diskAttachment("d3"), diskAttachment("unknown")).MustBuild(),
+			wantStorageDomains: []string{},
+			wantDisks:          []string{"vm2_Disk1: 10 GB"},
+			wantSize:           10 * constants.GiB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStorageDomains, gotDisks, gotSize := o.vmStorage(tt.vm)
+			if !reflect.DeepEqual(gotStorageDomains, tt.wantStorageDomains) {
+				t.Errorf("OVirtSource.vmStorage() storageDomains = %v, want %v", gotStorageDomains, tt.wantStorageDomains)
+			}
+			if !reflect.DeepEqual(gotDisks, tt.wantDisks) {
+				t.Errorf("OVirtSource.vmStorage() disks = %v, want %v", gotDisks, tt.wantDisks)
+			}
+			if gotSize != tt.wantSize {
+				t.Errorf("OVirtSource.vmStorage() size = %v, want %v", gotSize, tt.wantSize)
+			}
+		})
+	}
+}
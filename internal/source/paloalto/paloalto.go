@@ -5,8 +5,13 @@ import (
 	"time"
 
 	"github.com/PaloAltoNetworks/pango"
+	"github.com/PaloAltoNetworks/pango/netw/ikegw"
 	"github.com/PaloAltoNetworks/pango/netw/interface/eth"
 	"github.com/PaloAltoNetworks/pango/netw/interface/subinterface/layer3"
+	"github.com/PaloAltoNetworks/pango/netw/interface/tunnel"
+	"github.com/PaloAltoNetworks/pango/netw/ipsectunnel"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ike"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ipsec"
 	"github.com/PaloAltoNetworks/pango/netw/routing/router"
 	"github.com/PaloAltoNetworks/pango/netw/zone"
 	"github.com/PaloAltoNetworks/pango/vsys"
@@ -21,26 +26,58 @@ import (
 type PaloAltoSource struct {
 	common.Config
 	// Paloalto data. Initialized in init functions.
-	SystemInfo          map[string]string         // Map storing system information
-	VirtualSystems      map[string]vsys.Entry     // VirtualSystem name -> VirtualSystem
-	SecurityZones       map[string]zone.Entry     // SecurityZone name -> SecurityZone
-	Iface2SecurityZone  map[string]string         // Iface name -> SecurityZone name
-	Iface2VirtualRouter map[string]string         // Iface name -> VirtualRouter name
-	Ifaces              map[string]eth.Entry      // Iface name -> Iface
-	Iface2SubIfaces     map[string][]layer3.Entry // Iface name -> SubIfaces
-	VirtualRouters      map[string]router.Entry   // VirtualRouter name -> VirutalRouter
-	ArpData             []ArpEntry                // Array of arp entreies
+	SystemInfo              map[string]string            // Map storing system information
+	VirtualSystems          map[string]vsys.Entry        // VirtualSystem name -> VirtualSystem
+	SecurityZones           map[string]zone.Entry        // SecurityZone name -> SecurityZone
+	Iface2SecurityZone      map[string]string            // Iface name -> SecurityZone name
+	Iface2VirtualRouter     map[string]string            // Iface name -> VirtualRouter name
+	Ifaces                  map[string]eth.Entry         // Iface name -> Iface
+	Iface2SubIfaces         map[string][]layer3.Entry    // Iface name -> SubIfaces
+	TunnelIfaces            map[string]tunnel.Entry      // Tunnel iface name -> Tunnel iface
+	IPSecTunnels            map[string]ipsectunnel.Entry // IPSec tunnel name -> IPSec tunnel
+	IKEGateways             map[string]ikegw.Entry       // IKE gateway name -> IKE gateway
+	IKECryptoProfiles       map[string]ike.Entry         // IKE crypto profile name -> IKE crypto profile
+	IPSecCryptoProfiles     map[string]ipsec.Entry       // IPSec crypto profile name -> IPSec crypto profile
+	VirtualRouters          map[string]router.Entry      // VirtualRouter name -> VirutalRouter
+	ArpData                 []ArpEntry                   // Array of arp entreies
+	AddressObjects          []common.AddressObject       // Address objects matching address object filters
+	DhcpPools               []common.DhcpPool            // Ip pools of dhcp servers
+	VirtualRouter2Routes    map[string][]common.Route    // VirtualRouter name -> Routes of its routing table
+	VirtualRouter2BGPConfig map[string]common.BGPConfig  // VirtualRouter name -> Bgp config of the virtual router
+	HAState                 *HAGroup                     // High availability state, nil if HA is not enabled
+	HAGroupConfig           *HAGroupConfig               // High availability group config, nil if HA is not enabled
+
+	// Vrfs of virtual routers, when firewall has multiple virtual routers.
+	// Created in syncVirtualRouters func.
+	VirtualRouter2VRF map[string]*objects.VRF // VirtualRouter name -> Vrf
 
 	// NBFirewall representing paloalto firewall created in syncDevice func.
 	NBFirewall *objects.Device
 
+	// Attributes of the firewall in format "attribute:value" (e.g. "deviceGroup:Branches"),
+	// which are matched against host relations. Set by the panorama source for managed firewalls.
+	HostAttributes []string
+
 	// User defined relation
 	HostTenantRelations map[string]string
 	HostSiteRelations   map[string]string
 	VlanGroupRelations  map[string]string
 	VlanTenantRelations map[string]string
+
+	// HostAttributeTenantRelations and HostAttributeSiteRelations are host relations
+	// matching firewall's attributes (e.g. "deviceGroup:Branch.* = Branches")
+	HostAttributeTenantRelations map[string]string
+	HostAttributeSiteRelations   map[string]string
 }
 
+// Prefixes of firewall's attributes, that can be used in host relations.
+const (
+	DeviceGroupAttributePrefix = "deviceGroup:"
+	TemplateAttributePrefix    = "template:"
+)
+
+var hostAttributePrefixes = []string{DeviceGroupAttributePrefix, TemplateAttributePrefix}
+
 func (pas *PaloAltoSource) Init() error {
 	c := &pango.Firewall{Client: pango.Client{
 		Hostname:          pas.SourceConfig.Hostname,
@@ -56,24 +93,39 @@ func (pas *PaloAltoSource) Init() error {
 	if err := c.Initialize(); err != nil {
 		return fmt.Errorf("paloalto failed to initialize client: %s", err)
 	}
+	return pas.InitWithClient(c)
+}
 
-	// Initialize regex relations for the sourcce
+// InitWithClient initializes paloalto source using already initialized client.
+// It is used by the panorama source, which proxies requests to managed firewalls.
+func (pas *PaloAltoSource) InitWithClient(c *pango.Firewall) error {
 	// Initialize regex relations for this source
 	pas.VlanGroupRelations = utils.ConvertStringsToRegexPairs(pas.SourceConfig.VlanGroupRelations)
 	pas.Logger.Debugf(pas.Ctx, "VlanGroupRelations: %s", pas.VlanGroupRelations)
 	pas.VlanTenantRelations = utils.ConvertStringsToRegexPairs(pas.SourceConfig.VlanTenantRelations)
 	pas.Logger.Debugf(pas.Ctx, "VlanTenantRelations: %s", pas.VlanTenantRelations)
 	pas.HostTenantRelations = utils.ConvertStringsToRegexPairs(pas.SourceConfig.HostTenantRelations)
+	pas.HostAttributeTenantRelations = common.SplitAttributeRelations(pas.HostTenantRelations, hostAttributePrefixes)
 	pas.Logger.Debugf(pas.Ctx, "HostTenantRelations: %s", pas.HostTenantRelations)
+	pas.Logger.Debugf(pas.Ctx, "HostAttributeTenantRelations: %s", pas.HostAttributeTenantRelations)
 	pas.HostSiteRelations = utils.ConvertStringsToRegexPairs(pas.SourceConfig.HostSiteRelations)
+	pas.HostAttributeSiteRelations = common.SplitAttributeRelations(pas.HostSiteRelations, hostAttributePrefixes)
 	pas.Logger.Debugf(pas.Ctx, "HostSiteRelations: %s", pas.HostSiteRelations)
+	pas.Logger.Debugf(pas.Ctx, "HostAttributeSiteRelations: %s", pas.HostAttributeSiteRelations)
 
 	initFunctions := []func(*pango.Firewall) error{
 		pas.initArpData,
 		pas.initSystemInfo,
 		pas.initVirtualSystems,
 		pas.initInterfaces,
+		pas.initIPSecTunnels,
 		pas.initVirtualRouters,
+		pas.initAddressObjects,
+		pas.initDhcpPools,
+		pas.initRoutes,
+		pas.initBGP,
+		pas.initHAState,
+		pas.initHAGroupConfig,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -89,9 +141,17 @@ func (pas *PaloAltoSource) Init() error {
 func (pas *PaloAltoSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		pas.syncDevice,
+		pas.syncHighAvailability,
 		pas.syncSecurityZones,
+		pas.syncVirtualRouters,
 		pas.syncInterfaces,
+		pas.syncHAVirtualAddresses,
+		pas.syncIPSecTunnels,
+		pas.syncDhcpPools,
+		pas.syncRoutes,
+		pas.syncBGP,
 		pas.syncArpTable,
+		pas.syncAddressObjects,
 	}
 
 	for _, syncFunc := range syncFunctions {
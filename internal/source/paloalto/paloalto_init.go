@@ -2,14 +2,27 @@ package paloalto
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
 
 	"github.com/PaloAltoNetworks/pango"
+	pangoerrors "github.com/PaloAltoNetworks/pango/errors"
+	"github.com/PaloAltoNetworks/pango/netw/ikegw"
 	"github.com/PaloAltoNetworks/pango/netw/interface/eth"
 	"github.com/PaloAltoNetworks/pango/netw/interface/subinterface/layer3"
+	"github.com/PaloAltoNetworks/pango/netw/interface/tunnel"
+	"github.com/PaloAltoNetworks/pango/netw/ipsectunnel"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ike"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ipsec"
+	"github.com/PaloAltoNetworks/pango/netw/routing/protocol/bgp/peer"
 	"github.com/PaloAltoNetworks/pango/netw/routing/router"
 	"github.com/PaloAltoNetworks/pango/netw/zone"
+	"github.com/PaloAltoNetworks/pango/objs/addr"
 	"github.com/PaloAltoNetworks/pango/vsys"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
 )
 
 // Init system info collects system info from paloalto.
@@ -76,6 +89,52 @@ func (pas *PaloAltoSource) initInterfaces(c *pango.Firewall) error {
 		pas.Iface2SubIfaces[ethInterface.Name] = make([]layer3.Entry, 0, len(subInterfaces))
 		pas.Iface2SubIfaces[ethInterface.Name] = subInterfaces
 	}
+	tunnelInterfaces, err := c.Network.TunnelInterface.GetAll()
+	if err != nil {
+		return fmt.Errorf("tunnel interfaces: %s", err)
+	}
+	pas.TunnelIfaces = make(map[string]tunnel.Entry, len(tunnelInterfaces))
+	for _, tunnelInterface := range tunnelInterfaces {
+		pas.TunnelIfaces[tunnelInterface.Name] = tunnelInterface
+	}
+	return nil
+}
+
+// initIPSecTunnels collects all ipsec tunnels, together with ike gateways
+// and crypto profiles they use. It stores them as attribute of the paloalto source.
+func (pas *PaloAltoSource) initIPSecTunnels(c *pango.Firewall) error {
+	ipsecTunnels, err := c.Network.IpsecTunnel.GetAll()
+	if err != nil {
+		return fmt.Errorf("ipsec tunnels: %s", err)
+	}
+	pas.IPSecTunnels = make(map[string]ipsectunnel.Entry, len(ipsecTunnels))
+	for _, ipsecTunnel := range ipsecTunnels {
+		pas.IPSecTunnels[ipsecTunnel.Name] = ipsecTunnel
+	}
+	ikeGateways, err := c.Network.IkeGateway.GetAll()
+	if err != nil {
+		return fmt.Errorf("ike gateways: %s", err)
+	}
+	pas.IKEGateways = make(map[string]ikegw.Entry, len(ikeGateways))
+	for _, ikeGateway := range ikeGateways {
+		pas.IKEGateways[ikeGateway.Name] = ikeGateway
+	}
+	ikeCryptoProfiles, err := c.Network.IkeCryptoProfile.GetAll()
+	if err != nil {
+		return fmt.Errorf("ike crypto profiles: %s", err)
+	}
+	pas.IKECryptoProfiles = make(map[string]ike.Entry, len(ikeCryptoProfiles))
+	for _, ikeCryptoProfile := range ikeCryptoProfiles {
+		pas.IKECryptoProfiles[ikeCryptoProfile.Name] = ikeCryptoProfile
+	}
+	ipsecCryptoProfiles, err := c.Network.IpsecCryptoProfile.GetAll()
+	if err != nil {
+		return fmt.Errorf("ipsec crypto profiles: %s", err)
+	}
+	pas.IPSecCryptoProfiles = make(map[string]ipsec.Entry, len(ipsecCryptoProfiles))
+	for _, ipsecCryptoProfile := range ipsecCryptoProfiles {
+		pas.IPSecCryptoProfiles[ipsecCryptoProfile.Name] = ipsecCryptoProfile
+	}
 	return nil
 }
 
@@ -123,3 +182,353 @@ func (pas *PaloAltoSource) initArpData(c *pango.Firewall) error {
 	}
 	return nil
 }
+
+// Structs to parse xml high availability state response.
+type HAStateResponse struct {
+	XMLName xml.Name      `xml:"response"`
+	Status  string        `xml:"status,attr"`
+	Result  HAStateResult `xml:"result"`
+}
+
+type HAStateResult struct {
+	Enabled string  `xml:"enabled"`
+	Group   HAGroup `xml:"group"`
+}
+
+type HAGroup struct {
+	Mode      string       `xml:"mode"`
+	LocalInfo HAMemberInfo `xml:"local-info"`
+	PeerInfo  HAMemberInfo `xml:"peer-info"`
+}
+
+type HAMemberInfo struct {
+	State     string `xml:"state"`
+	Priority  string `xml:"priority"`
+	MgmtIP    string `xml:"mgmt-ip"`
+	SerialNum string `xml:"serial-num"`
+}
+
+// initHAState collects high availability state of the paloalto firewall.
+// If HA is not enabled, HAState is left empty.
+func (pas *PaloAltoSource) initHAState(c *pango.Firewall) error {
+	var haState HAStateResponse
+	haXMLString := "<show><high-availability><state></state></high-availability></show>"
+	haXMLResponse, err := c.Op(haXMLString, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("init ha state: %s", err)
+	}
+	err = xml.Unmarshal(haXMLResponse, &haState)
+	if err != nil {
+		return fmt.Errorf("init ha state: %s", err)
+	}
+	if haState.Result.Enabled == "yes" {
+		pas.HAState = &haState.Result.Group
+	}
+	return nil
+}
+
+// Structs to parse xml high availability group config response.
+type HAGroupConfigResponse struct {
+	XMLName xml.Name      `xml:"response"`
+	Status  string        `xml:"status,attr"`
+	Group   HAGroupConfig `xml:"result>group"`
+}
+
+type HAGroupConfig struct {
+	GroupID          int                  `xml:"group-id"`
+	VirtualAddresses []HAVirtualAddresses `xml:"mode>active-active>virtual-address>entry"`
+}
+
+// HAVirtualAddresses represents virtual addresses configured on the interface
+// of the firewall running in active-active mode.
+type HAVirtualAddresses struct {
+	Interface string             `xml:"name,attr"`
+	IPs       []HAVirtualAddress `xml:"ip>entry"`
+}
+
+type HAVirtualAddress struct {
+	Address        string    `xml:"name,attr"`
+	Floating       *struct{} `xml:"floating"`
+	ArpLoadSharing *struct{} `xml:"arp-load-sharing"`
+}
+
+// initHAGroupConfig collects high availability group configuration of the paloalto firewall,
+// which contains virtual addresses shared between HA peers in active-active mode.
+// It has to run after initHAState.
+func (pas *PaloAltoSource) initHAGroupConfig(c *pango.Firewall) error {
+	if pas.HAState == nil {
+		return nil
+	}
+	var haGroupConfig HAGroupConfigResponse
+	haGroupXpath := "/config/devices/entry[@name='localhost.localdomain']/deviceconfig/high-availability/group"
+	haGroupXMLResponse, err := c.Show(haGroupXpath, nil, nil)
+	if err != nil {
+		return fmt.Errorf("init ha group config: %s", err)
+	}
+	err = xml.Unmarshal(haGroupXMLResponse, &haGroupConfig)
+	if err != nil {
+		return fmt.Errorf("init ha group config: %s", err)
+	}
+	pas.HAGroupConfig = &haGroupConfig.Group
+	return nil
+}
+
+// initAddressObjects collects address objects of all virtual systems, which are
+// matching addressObjectFilter or addressObjectTags. Only ip-netmask and ip-range
+// address objects are collected. It has to run after initVirtualSystems.
+func (pas *PaloAltoSource) initAddressObjects(c *pango.Firewall) error {
+	if pas.SourceConfig.AddressObjectFilterRegex == nil && len(pas.SourceConfig.AddressObjectTags) == 0 {
+		return nil
+	}
+	pas.AddressObjects = make([]common.AddressObject, 0)
+	for vsysName := range pas.VirtualSystems {
+		addressObjects, err := c.Objects.Address.GetAll(vsysName)
+		if err != nil {
+			return fmt.Errorf("address objects of virtual system %s: %s", vsysName, err)
+		}
+		for _, addressObject := range addressObjects {
+			if addressObject.Type != addr.IpNetmask && addressObject.Type != addr.IpRange {
+				continue
+			}
+			commonAddressObject := common.AddressObject{
+				Name:  addressObject.Name,
+				Value: addressObject.Value,
+				Tags:  addressObject.Tags,
+			}
+			if common.MatchAddressObject(commonAddressObject, pas.SourceConfig.AddressObjectFilterRegex, pas.SourceConfig.AddressObjectTags) {
+				pas.AddressObjects = append(pas.AddressObjects, commonAddressObject)
+			}
+		}
+	}
+	return nil
+}
+
+// Structs to parse xml dhcp server config response.
+type DhcpConfigResponse struct {
+	XMLName    xml.Name              `xml:"response"`
+	Status     string                `xml:"status,attr"`
+	Interfaces []DhcpInterfaceConfig `xml:"result>interface>entry"`
+}
+
+type DhcpInterfaceConfig struct {
+	Interface string   `xml:"name,attr"`
+	IPPools   []string `xml:"server>ip-pool>member"`
+}
+
+// initDhcpPools collects ip pools of dhcp servers running on the firewall's interfaces.
+// It has to run after initInterfaces.
+func (pas *PaloAltoSource) initDhcpPools(c *pango.Firewall) error {
+	var dhcpConfig DhcpConfigResponse
+	dhcpXpath := "/config/devices/entry[@name='localhost.localdomain']/network/dhcp/interface"
+	dhcpXMLResponse, err := c.Show(dhcpXpath, nil, nil)
+	if err != nil {
+		var panosErr pangoerrors.Panos
+		if errors.As(err, &panosErr) && (panosErr.ObjectNotFound() || panosErr.Msg == "No such node") {
+			// Dhcp is not configured on the firewall
+			return nil
+		}
+		return fmt.Errorf("init dhcp pools: %s", err)
+	}
+	err = xml.Unmarshal(dhcpXMLResponse, &dhcpConfig)
+	if err != nil {
+		return fmt.Errorf("init dhcp pools: %s", err)
+	}
+	pas.DhcpPools = make([]common.DhcpPool, 0)
+	for _, dhcpInterface := range dhcpConfig.Interfaces {
+		for _, ipPool := range dhcpInterface.IPPools {
+			dhcpPool, err := parseDhcpPoolMember(ipPool)
+			if err != nil {
+				pas.Logger.Warningf(pas.Ctx, "dhcp pool %s on interface %s: %s", ipPool, dhcpInterface.Interface, err)
+				continue
+			}
+			dhcpPool.Interface = dhcpInterface.Interface
+			if dhcpPool.MaskBits == 0 {
+				dhcpPool.MaskBits = pas.interfaceMaskBits(dhcpInterface.Interface)
+			}
+			pas.DhcpPools = append(pas.DhcpPools, dhcpPool)
+		}
+	}
+	return nil
+}
+
+// parseDhcpPoolMember parses member of paloalto's dhcp ip pool, which can be
+// a range (e.g. "10.0.0.10-10.0.0.20"), subnet (e.g. "10.0.0.0/24") or single ip.
+// Mask bits are set only for subnets, because ranges don't contain mask.
+func parseDhcpPoolMember(member string) (common.DhcpPool, error) {
+	if start, end, isRange := strings.Cut(member, "-"); isRange {
+		return common.DhcpPool{StartAddress: strings.TrimSpace(start), EndAddress: strings.TrimSpace(end)}, nil
+	}
+	if strings.Contains(member, "/") {
+		prefix, err := netip.ParsePrefix(member)
+		if err != nil {
+			return common.DhcpPool{}, err
+		}
+		prefix = prefix.Masked()
+		lastAddr := prefix.Addr()
+		for next := lastAddr.Next(); next.IsValid() && prefix.Contains(next); next = next.Next() {
+			lastAddr = next
+		}
+		return common.DhcpPool{StartAddress: prefix.Addr().String(), EndAddress: lastAddr.String(), MaskBits: prefix.Bits()}, nil
+	}
+	addr, err := netip.ParseAddr(member)
+	if err != nil {
+		return common.DhcpPool{}, err
+	}
+	return common.DhcpPool{StartAddress: addr.String(), EndAddress: addr.String()}, nil
+}
+
+// interfaceMaskBits returns mask bits of the first static ip configured on the
+// interface or subinterface. If interface has no static ips, 0 is returned.
+func (pas *PaloAltoSource) interfaceMaskBits(ifaceName string) int {
+	staticIPs := pas.Ifaces[ifaceName].StaticIps
+	for _, subIfaces := range pas.Iface2SubIfaces {
+		for _, subIface := range subIfaces {
+			if subIface.Name == ifaceName {
+				staticIPs = subIface.StaticIps
+			}
+		}
+	}
+	for _, staticIP := range staticIPs {
+		if prefix, err := netip.ParsePrefix(staticIP); err == nil {
+			return prefix.Bits()
+		}
+	}
+	return 0
+}
+
+// Structs to parse xml routing table response.
+type RoutesResponse struct {
+	XMLName xml.Name     `xml:"response"`
+	Status  string       `xml:"status,attr"`
+	Entries []RouteEntry `xml:"result>entry"`
+}
+
+type RouteEntry struct {
+	VirtualRouter string `xml:"virtual-router"`
+	Destination   string `xml:"destination"`
+	NextHop       string `xml:"nexthop"`
+	Flags         string `xml:"flags"`
+	Interface     string `xml:"interface"`
+}
+
+// toRoute converts paloalto route entry to common route. Type of the route
+// is determined from its flags (e.g. "A C" for active connected route).
+func (r RouteEntry) toRoute() common.Route {
+	route := common.Route{Prefix: r.Destination, Interface: r.Interface}
+	flags := strings.Fields(r.Flags)
+	switch {
+	case slices.Contains(flags, "C"):
+		route.Type = common.RouteTypeConnected
+	case slices.Contains(flags, "S"):
+		route.Type = common.RouteTypeStatic
+		route.NextHop = r.NextHop
+	case slices.Contains(flags, "B"):
+		route.Type = common.RouteTypeBGP
+		route.NextHop = r.NextHop
+	}
+	return route
+}
+
+// initRoutes collects routing tables of all virtual routers, if collectRoutes is enabled.
+func (pas *PaloAltoSource) initRoutes(c *pango.Firewall) error {
+	if !pas.SourceConfig.CollectRoutes {
+		return nil
+	}
+	var routesResponse RoutesResponse
+	routesXMLString := "<show><routing><route></route></routing></show>"
+	routesXMLResponse, err := c.Op(routesXMLString, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("init routes: %s", err)
+	}
+	err = xml.Unmarshal(routesXMLResponse, &routesResponse)
+	if err != nil {
+		return fmt.Errorf("init routes: %s", err)
+	}
+	routes := make(map[string][]common.Route)
+	for _, entry := range routesResponse.Entries {
+		routes[entry.VirtualRouter] = append(routes[entry.VirtualRouter], entry.toRoute())
+	}
+	pas.VirtualRouter2Routes = make(map[string][]common.Route, len(routes))
+	for virtualRouter, virtualRouterRoutes := range routes {
+		pas.VirtualRouter2Routes[virtualRouter] = common.FilterRoutes(virtualRouterRoutes, pas.SourceConfig)
+	}
+	return nil
+}
+
+// initBGP collects bgp configuration of all virtual routers with enabled bgp,
+// when collectBgp is enabled. It has to run after initVirtualRouters.
+func (pas *PaloAltoSource) initBGP(c *pango.Firewall) error {
+	pas.VirtualRouter2BGPConfig = make(map[string]common.BGPConfig)
+	if !pas.SourceConfig.CollectBGP {
+		return nil
+	}
+	for virtualRouter := range pas.VirtualRouters {
+		bgpConfig, err := c.Network.BgpConfig.Get(virtualRouter)
+		if err != nil {
+			var panosErr pangoerrors.Panos
+			if errors.As(err, &panosErr) && (panosErr.ObjectNotFound() || panosErr.Msg == "No such node") {
+				// Bgp is not configured on the virtual router
+				continue
+			}
+			return fmt.Errorf("init bgp config of virtual router %s: %s", virtualRouter, err)
+		}
+		if !bgpConfig.Enable {
+			continue
+		}
+		localAS, err := common.ParseASN(bgpConfig.AsNumber)
+		if err != nil {
+			pas.Logger.Warningf(pas.Ctx, "bgp of virtual router %s: %s", virtualRouter, err)
+			continue
+		}
+		peerGroups, err := c.Network.BgpPeerGroup.GetAll(virtualRouter)
+		if err != nil {
+			return fmt.Errorf("init bgp peer groups of virtual router %s: %s", virtualRouter, err)
+		}
+		peers := make([]common.BGPPeer, 0)
+		for _, peerGroup := range peerGroups {
+			if !peerGroup.Enable {
+				continue
+			}
+			peerEntries, err := c.Network.BgpPeer.GetAll(virtualRouter, peerGroup.Name)
+			if err != nil {
+				return fmt.Errorf("init bgp peers of peer group %s: %s", peerGroup.Name, err)
+			}
+			for _, peerEntry := range peerEntries {
+				if !peerEntry.Enable {
+					continue
+				}
+				bgpPeer, err := toBGPPeer(peerEntry)
+				if err != nil {
+					pas.Logger.Warningf(pas.Ctx, "bgp peer %s of virtual router %s: %s", peerEntry.Name, virtualRouter, err)
+					continue
+				}
+				peers = append(peers, bgpPeer)
+			}
+		}
+		pas.VirtualRouter2BGPConfig[virtualRouter] = common.BGPConfig{
+			LocalAS:  localAS,
+			RouterID: bgpConfig.RouterId,
+			Peers:    peers,
+		}
+	}
+	return nil
+}
+
+// toBGPPeer converts paloalto bgp peer entry to common bgp peer.
+// Local address of the peer is configured with mask (e.g. "10.0.0.1/30"),
+// so only its ip part is kept.
+func toBGPPeer(peerEntry peer.Entry) (common.BGPPeer, error) {
+	remoteAS, err := common.ParseASN(peerEntry.PeerAs)
+	if err != nil {
+		return common.BGPPeer{}, err
+	}
+	remoteAddr, err := netip.ParseAddr(peerEntry.PeerAddressIp)
+	if err != nil {
+		return common.BGPPeer{}, fmt.Errorf("parse peer address: %s", err)
+	}
+	return common.BGPPeer{
+		RemoteAddress: remoteAddr.String(),
+		RemoteAS:      remoteAS,
+		LocalAddress:  strings.Split(peerEntry.LocalAddressIp, "/")[0],
+	}, nil
+}
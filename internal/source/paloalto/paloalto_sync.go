@@ -2,9 +2,14 @@ package paloalto
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
-	"time"
+	"strings"
 
+	"github.com/PaloAltoNetworks/pango/netw/ikegw"
+	"github.com/PaloAltoNetworks/pango/netw/ipsectunnel"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ike"
+	"github.com/PaloAltoNetworks/pango/netw/profile/ipsec"
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
@@ -40,10 +45,18 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 		return fmt.Errorf("add device type: %s", err)
 	}
 
+	// Relations on firewall's attributes have priority over relations on its hostname
 	deviceTenant, err := common.MatchHostToTenant(pas.Ctx, nbi, deviceName, pas.HostTenantRelations)
 	if err != nil {
 		return fmt.Errorf("match host to tenant: %s", err)
 	}
+	attributeTenant, err := common.MatchTagsToTenant(pas.Ctx, nbi, pas.HostAttributes, pas.HostAttributeTenantRelations)
+	if err != nil {
+		return fmt.Errorf("match host attributes to tenant: %s", err)
+	}
+	if attributeTenant != nil {
+		deviceTenant = attributeTenant
+	}
 
 	deviceRole, err := nbi.AddDeviceRole(pas.Ctx, &objects.DeviceRole{
 		Name:   constants.DeviceRoleFirewall,
@@ -54,9 +67,15 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if err != nil {
 		return fmt.Errorf("add DeviceRole: %s", err)
 	}
-	deviceSite, err := common.MatchHostToSite(pas.Ctx, nbi, deviceName, pas.HostSiteRelations)
+	deviceSite, err := common.MatchTagsToSite(pas.Ctx, nbi, pas.HostAttributes, pas.HostAttributeSiteRelations)
 	if err != nil {
-		return fmt.Errorf("match host to site: %s", err)
+		return fmt.Errorf("match host attributes to site: %s", err)
+	}
+	if deviceSite == nil {
+		deviceSite, err = common.MatchHostToSite(pas.Ctx, nbi, deviceName, pas.HostSiteRelations)
+		if err != nil {
+			return fmt.Errorf("match host to site: %s", err)
+		}
 	}
 	devicePlatformName := fmt.Sprintf("PAN-OS %s", pas.SystemInfo["sw-version"])
 	devicePlatform, err := nbi.AddPlatform(pas.Ctx, &objects.Platform{
@@ -88,6 +107,64 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// syncHighAvailability adds paloalto firewall into virtual chassis representing its HA pair.
+// Each firewall in the HA pair is synced from its own source, so we only sync the local member.
+// Attributes of the virtual chassis are only synced by the member on the first position.
+// The name of the virtual chassis is determined from identifiers of both members, so both
+// firewalls end up in the same virtual chassis.
+func (pas *PaloAltoSource) syncHighAvailability(nbi *inventory.NetboxInventory) error {
+	if pas.HAState == nil {
+		pas.Logger.Debug(pas.Ctx, "high availability is not enabled. Skipping...")
+		return nil
+	}
+	localID := pas.HAState.LocalInfo.SerialNum
+	if localID == "" {
+		localID = pas.SystemInfo["serial"]
+	}
+	peerID := pas.HAState.PeerInfo.SerialNum
+	if localID == "" || peerID == "" {
+		// Fallback to management ips, which are always present
+		localID = strings.Split(pas.HAState.LocalInfo.MgmtIP, "/")[0]
+		peerID = strings.Split(pas.HAState.PeerInfo.MgmtIP, "/")[0]
+	}
+	if localID == "" || peerID == "" {
+		pas.Logger.Warning(pas.Ctx, "can't identify members of HA pair. Skipping...")
+		return nil
+	}
+
+	// Member with lower priority value is the preferred one, so it gets the first position
+	localPriority, _ := strconv.Atoi(pas.HAState.LocalInfo.Priority)
+	peerPriority, _ := strconv.Atoi(pas.HAState.PeerInfo.Priority)
+	localPosition := 1
+	if peerPriority < localPriority || (peerPriority == localPriority && peerID < localID) {
+		localPosition = 2
+	}
+	memberIDs := []string{localID, peerID}
+	slices.Sort(memberIDs)
+	vcName := fmt.Sprintf("HA %s", strings.Join(memberIDs, "-"))
+
+	localMember := []common.VirtualChassisMember{
+		{
+			Device:   pas.NBFirewall,
+			Position: localPosition,
+			Priority: localPriority,
+			Master:   strings.HasPrefix(pas.HAState.LocalInfo.State, "active"),
+		},
+	}
+	// Member on the first position owns the virtual chassis, while the other
+	// member only joins it, so the virtual chassis doesn't flap between sources
+	syncVirtualChassis := common.JoinVirtualChassis
+	if localPosition == 1 {
+		syncVirtualChassis = common.SyncVirtualChassis
+	}
+	members, err := syncVirtualChassis(pas.Ctx, nbi, pas.SourceTags, pas.SourceConfig.Name, vcName, pas.HAState.Mode, localMember)
+	if err != nil {
+		return fmt.Errorf("sync virtual chassis: %s", err)
+	}
+	pas.NBFirewall = members[localPosition]
+	return nil
+}
+
 func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error {
 	for _, iface := range pas.Ifaces {
 		if iface.Name == "" {
@@ -209,12 +286,45 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			}
 		}
 	}
+
+	for _, tunnelIface := range pas.TunnelIfaces {
+		if tunnelIface.Name == "" {
+			continue
+		}
+		if utils.FilterInterfaceName(tunnelIface.Name, pas.SourceConfig.InterfaceFilter) {
+			pas.Logger.Debugf(pas.Ctx, "interface %s is filtered out with interface filter %s", tunnelIface.Name, pas.SourceConfig.InterfaceFilter)
+			continue
+		}
+		var vdcs []*objects.VirtualDeviceContext
+		if vdc := pas.getVirtualDeviceContext(nbi, tunnelIface.Name); vdc != nil {
+			vdcs = []*objects.VirtualDeviceContext{vdc}
+		}
+		nbTunnelIface, err := nbi.AddInterface(pas.Ctx, &objects.Interface{
+			NetboxObject: objects.NetboxObject{
+				Tags:        pas.SourceTags,
+				Description: tunnelIface.Comment,
+			},
+			Name:   tunnelIface.Name,
+			Type:   &objects.VirtualInterfaceType,
+			Device: pas.NBFirewall,
+			MTU:    tunnelIface.Mtu,
+			Vdcs:   vdcs,
+		})
+		if err != nil {
+			return fmt.Errorf("add tunnel interface: %s", err)
+		}
+		if len(tunnelIface.StaticIps) > 0 {
+			pas.syncIPs(nbi, nbTunnelIface, tunnelIface.StaticIps, nil)
+		}
+	}
 	return nil
 }
 
 // syncIPs adds all of the given ips to the given nbIface. It also
 // Extracts prefixes from ips and connect them with prefix vlan.
+// Ips and prefixes are synced in the vrf of the interface's virtual router.
 func (pas *PaloAltoSource) syncIPs(nbi *inventory.NetboxInventory, nbIface *objects.Interface, ips []string, prefixVlan *objects.Vlan) {
+	vrf := pas.ifaceVRF(nbIface.Name)
 	for _, ipAddress := range ips {
 		if !utils.SubnetsContainIPAddress(ipAddress, pas.SourceConfig.IgnoredSubnets) {
 			dnsName := utils.ReverseLookup(ipAddress)
@@ -226,6 +336,7 @@ func (pas *PaloAltoSource) syncIPs(nbi *inventory.NetboxInventory, nbIface *obje
 					},
 				},
 				Address:            ipAddress,
+				Vrf:                vrf,
 				AssignedObjectID:   nbIface.ID,
 				DNSName:            dnsName,
 				AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
@@ -246,6 +357,7 @@ func (pas *PaloAltoSource) syncIPs(nbi *inventory.NetboxInventory, nbIface *obje
 					Prefix: prefix,
 					Tenant: prefixTenant,
 					Vlan:   prefixVlan,
+					Vrf:    vrf,
 				})
 				if err != nil {
 					pas.Logger.Errorf(pas.Ctx, "adding prefix: %s", err)
@@ -284,63 +396,337 @@ func (pas *PaloAltoSource) getVirtualDeviceContext(nbi *inventory.NetboxInventor
 	return virtualDeviceContext
 }
 
+// syncHAVirtualAddresses syncs virtual addresses of firewalls running in active-active
+// mode as FHRP groups. Each interface with virtual addresses represents one group, that
+// is identified by HA group id. Since there is no standard protocol for these addresses,
+// groups are synced with protocol other and ips with role VIP.
+func (pas *PaloAltoSource) syncHAVirtualAddresses(nbi *inventory.NetboxInventory) error {
+	if pas.HAGroupConfig == nil || len(pas.HAGroupConfig.VirtualAddresses) == 0 {
+		return nil
+	}
+	priority, _ := strconv.Atoi(pas.HAState.LocalInfo.Priority)
+	for _, virtualAddresses := range pas.HAGroupConfig.VirtualAddresses {
+		nbIface, ok := nbi.InterfacesIndexByDeviceIDAndName[pas.NBFirewall.ID][virtualAddresses.Interface]
+		if !ok {
+			pas.Logger.Debugf(pas.Ctx, "interface %s with virtual addresses is not synced. Skipping...", virtualAddresses.Interface)
+			continue
+		}
+		virtualIPs := make([]string, 0, len(virtualAddresses.IPs))
+		for _, virtualAddress := range virtualAddresses.IPs {
+			if virtualAddress.Address == "" || utils.SubnetsContainIPAddress(virtualAddress.Address, pas.SourceConfig.IgnoredSubnets) {
+				continue
+			}
+			ipAddress := virtualAddress.Address
+			if !strings.Contains(ipAddress, "/") {
+				ipAddress = fmt.Sprintf("%s/32", ipAddress)
+			}
+			virtualIPs = append(virtualIPs, ipAddress)
+		}
+		if len(virtualIPs) == 0 {
+			continue
+		}
+		_, err := common.SyncFHRPGroup(pas.Ctx, nbi, pas.SourceTags, pas.SourceConfig.Name, &objects.FHRPGroupProtocolOther, pas.HAGroupConfig.GroupID, virtualIPs, nil, pas.ifaceVRF(virtualAddresses.Interface), []common.FHRPGroupMember{
+			{Interface: nbIface, Priority: priority},
+		})
+		if err != nil {
+			return fmt.Errorf("sync ha virtual addresses of %s: %s", virtualAddresses.Interface, err)
+		}
+	}
+	return nil
+}
+
+// syncIPSecTunnels syncs all auto key ipsec tunnels, that are terminated on already synced
+// tunnel interfaces. Phase 1 parameters are taken from the ike gateway of the tunnel and
+// phase 2 parameters from its ipsec crypto profile. Since netbox proposals only support a
+// single algorithm, the first (most preferred) algorithm of each crypto profile is used.
+func (pas *PaloAltoSource) syncIPSecTunnels(nbi *inventory.NetboxInventory) error {
+	for _, ipsecTunnel := range pas.IPSecTunnels {
+		if ipsecTunnel.Type != ipsectunnel.TypeAutoKey {
+			pas.Logger.Debugf(pas.Ctx, "ipsec tunnel %s of type %s is not supported. Skipping...", ipsecTunnel.Name, ipsecTunnel.Type)
+			continue
+		}
+		nbIface, ok := nbi.InterfacesIndexByDeviceIDAndName[pas.NBFirewall.ID][ipsecTunnel.TunnelInterface]
+		if !ok {
+			pas.Logger.Debugf(pas.Ctx, "tunnel interface %s of ipsec tunnel %s is not synced. Skipping...", ipsecTunnel.TunnelInterface, ipsecTunnel.Name)
+			continue
+		}
+		tunnel := common.IPSecTunnel{
+			Name:      ipsecTunnel.Name,
+			Status:    &objects.TunnelStatusActive,
+			Interface: nbIface,
+		}
+		if ipsecTunnel.Disabled {
+			tunnel.Status = &objects.TunnelStatusDisabled
+		}
+		if ikeGateway, ok := pas.IKEGateways[ipsecTunnel.AkIkeGateway]; ok {
+			pas.setIKEParameters(&tunnel, ikeGateway)
+			tunnel.OutsideIP = pas.getOutsideIP(nbi, ikeGateway)
+			if ikeGateway.Disabled {
+				tunnel.Status = &objects.TunnelStatusDisabled
+			}
+		}
+		if ipsecCryptoProfile, ok := pas.IPSecCryptoProfiles[ipsecTunnel.AkIpsecCryptoProfile]; ok {
+			tunnel.IPSecMode = &objects.IPSecModeESP
+			if ipsecCryptoProfile.Protocol == ipsec.ProtocolAh {
+				tunnel.IPSecMode = &objects.IPSecModeAH
+			}
+			if len(ipsecCryptoProfile.Encryption) > 0 {
+				tunnel.IPSecEncryption = paloaltoEncryption2NBEncryption[ipsecCryptoProfile.Encryption[0]]
+			}
+			if len(ipsecCryptoProfile.Authentication) > 0 {
+				tunnel.IPSecAuthentication = paloaltoAuthentication2NBAuthentication[ipsecCryptoProfile.Authentication[0]]
+			}
+			tunnel.PFSGroup = paloaltoDHGroup(ipsecCryptoProfile.DhGroup)
+			tunnel.IPSecLifetimeSeconds = lifetimeToSeconds(ipsecCryptoProfile.LifetimeType, ipsecCryptoProfile.LifetimeValue)
+			tunnel.IPSecLifetimeData = lifesizeToKilobytes(ipsecCryptoProfile.LifesizeType, ipsecCryptoProfile.LifesizeValue)
+		}
+		_, err := common.SyncIPSecTunnel(pas.Ctx, nbi, pas.SourceTags, pas.SourceConfig.Name, tunnel)
+		if err != nil {
+			return fmt.Errorf("sync ipsec tunnel %s: %s", ipsecTunnel.Name, err)
+		}
+	}
+	return nil
+}
+
+// setIKEParameters sets phase 1 parameters of the tunnel from the ike gateway
+// and its ike crypto profile.
+func (pas *PaloAltoSource) setIKEParameters(tunnel *common.IPSecTunnel, ikeGateway ikegw.Entry) {
+	tunnel.Comments = fmt.Sprintf("Peer: %s", ikeGateway.PeerIpValue)
+	if ikeGateway.PeerIpValue == "" {
+		tunnel.Comments = fmt.Sprintf("Peer: %s", ikeGateway.PeerIpType)
+	}
+	tunnel.AuthenticationMethod = &objects.AuthenticationMethodPresharedKeys
+	if ikeGateway.AuthType == ikegw.AuthCertificate {
+		tunnel.AuthenticationMethod = &objects.AuthenticationMethodCertificates
+	}
+	cryptoProfileName := ikeGateway.Ikev2CryptoProfile
+	tunnel.IKEVersion = &objects.IKEVersion2
+	if ikeGateway.Version == ikegw.Ikev1 {
+		cryptoProfileName = ikeGateway.Ikev1CryptoProfile
+		tunnel.IKEVersion = &objects.IKEVersion1
+		switch ikeGateway.Ikev1ExchangeMode {
+		case "aggressive":
+			tunnel.IKEMode = &objects.IKEModeAggressive
+		default:
+			// Auto mode initiates in main mode
+			tunnel.IKEMode = &objects.IKEModeMain
+		}
+	}
+	ikeCryptoProfile, ok := pas.IKECryptoProfiles[cryptoProfileName]
+	if !ok {
+		return
+	}
+	if len(ikeCryptoProfile.Encryption) > 0 {
+		tunnel.IKEEncryption = paloaltoEncryption2NBEncryption[ikeCryptoProfile.Encryption[0]]
+	}
+	if len(ikeCryptoProfile.Authentication) > 0 {
+		tunnel.IKEAuthentication = paloaltoAuthentication2NBAuthentication[ikeCryptoProfile.Authentication[0]]
+	}
+	if len(ikeCryptoProfile.DhGroup) > 0 {
+		tunnel.IKEGroup = paloaltoDHGroup(ikeCryptoProfile.DhGroup[0])
+	}
+	tunnel.IKELifetime = lifetimeToSeconds(ikeCryptoProfile.LifetimeType, ikeCryptoProfile.LifetimeValue)
+}
+
+// getOutsideIP returns already synced local ip address of the ike gateway.
+// If local ip address is not set, first ip of the gateway's interface is used.
+func (pas *PaloAltoSource) getOutsideIP(nbi *inventory.NetboxInventory, ikeGateway ikegw.Entry) *objects.IPAddress {
+	if ikeGateway.LocalIpAddressValue != "" {
+		if nbIPAddress, ok := nbi.GetIPAddress(pas.ifaceVRF(ikeGateway.Interface), ikeGateway.LocalIpAddressValue); ok {
+			return nbIPAddress
+		}
+	}
+	var ifaceIPs []string
+	if iface, ok := pas.Ifaces[ikeGateway.Interface]; ok {
+		ifaceIPs = iface.StaticIps
+	}
+	for _, subIfaces := range pas.Iface2SubIfaces {
+		for _, subIface := range subIfaces {
+			if subIface.Name == ikeGateway.Interface {
+				ifaceIPs = subIface.StaticIps
+			}
+		}
+	}
+	for _, ifaceIP := range ifaceIPs {
+		if nbIPAddress, ok := nbi.GetIPAddress(pas.ifaceVRF(ikeGateway.Interface), ifaceIP); ok {
+			return nbIPAddress
+		}
+	}
+	return nil
+}
+
+// Mappings of paloalto crypto algorithms to netbox algorithms.
+var paloaltoEncryption2NBEncryption = map[string]*objects.EncryptionAlgorithm{
+	ike.EncryptionDes:       &objects.EncryptionAlgorithmDESCBC,
+	ike.Encryption3des:      &objects.EncryptionAlgorithm3DESCBC,
+	ike.EncryptionAes128:    &objects.EncryptionAlgorithmAES128CBC,
+	ike.EncryptionAes192:    &objects.EncryptionAlgorithmAES192CBC,
+	ike.EncryptionAes256:    &objects.EncryptionAlgorithmAES256CBC,
+	ike.EncryptionAes128Gcm: &objects.EncryptionAlgorithmAES128GCM,
+	ike.EncryptionAes256Gcm: &objects.EncryptionAlgorithmAES256GCM,
+}
+
+var paloaltoAuthentication2NBAuthentication = map[string]*objects.AuthenticationAlgorithm{
+	"md5":    &objects.AuthenticationAlgorithmHMACMD5,
+	"sha1":   &objects.AuthenticationAlgorithmHMACSHA1,
+	"sha256": &objects.AuthenticationAlgorithmHMACSHA256,
+	"sha384": &objects.AuthenticationAlgorithmHMACSHA384,
+	"sha512": &objects.AuthenticationAlgorithmHMACSHA512,
+}
+
+// paloaltoDHGroup converts paloalto dh group (e.g. group14) to netbox DH group.
+// It returns nil for unknown groups and for no-pfs.
+func paloaltoDHGroup(dhGroup string) *objects.DHGroup {
+	groupNumber, err := strconv.Atoi(strings.TrimPrefix(dhGroup, "group"))
+	if err != nil {
+		return nil
+	}
+	return objects.DHGroups[groupNumber]
+}
+
+// lifetimeToSeconds converts paloalto lifetime to seconds.
+func lifetimeToSeconds(lifetimeType string, lifetimeValue int) int {
+	switch lifetimeType {
+	case ike.TimeMinutes:
+		return lifetimeValue * 60 //nolint:gomnd
+	case ike.TimeHours:
+		return lifetimeValue * 60 * 60 //nolint:gomnd
+	case ike.TimeDays:
+		return lifetimeValue * 24 * 60 * 60 //nolint:gomnd
+	default:
+		return lifetimeValue
+	}
+}
+
+// lifesizeToKilobytes converts paloalto lifesize to kilobytes.
+func lifesizeToKilobytes(lifesizeType string, lifesizeValue int) int {
+	switch lifesizeType {
+	case ipsec.SizeMb:
+		return lifesizeValue * 1024 //nolint:gomnd
+	case ipsec.SizeGb:
+		return lifesizeValue * 1024 * 1024 //nolint:gomnd
+	case ipsec.SizeTb:
+		return lifesizeValue * 1024 * 1024 * 1024 //nolint:gomnd
+	default:
+		return lifesizeValue
+	}
+}
+
+// syncArpTable syncs ip and mac addresses from the arp table to the vrfs
+// of virtual routers of the interfaces, on which the entries were seen.
 func (pas *PaloAltoSource) syncArpTable(nbi *inventory.NetboxInventory) error {
 	if !pas.SourceConfig.CollectArpData {
 		pas.Logger.Info(pas.Ctx, "skipping collecting of arp data")
 		return nil
 	}
 
-	// We tag it with special tag for arp data.
-	arpTag, err := nbi.AddTag(pas.Ctx, &objects.Tag{
-		Name:        constants.DefaultArpTagName,
-		Slug:        utils.Slugify(constants.DefaultArpTagName),
-		Color:       constants.DefaultArpTagColor,
-		Description: "tag created for ip's collected from arp table",
-	})
-	if err != nil {
-		return fmt.Errorf("add tag: %s", err)
-	}
-	// We create custom field for tracking when was arp entry last seen
-	_, err = nbi.AddCustomField(pas.Ctx, &objects.CustomField{
-		Name:                  constants.CustomFieldArpIPLastSeenName,
-		Label:                 constants.CustomFieldArpIPLastSeenLabel,
-		Type:                  objects.CustomFieldTypeText,
-		FilterLogic:           objects.FilterLogicLoose,
-		CustomFieldUIVisible:  &objects.CustomFieldUIVisibleAlways,
-		CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
-		DisplayWeight:         objects.DisplayWeightDefault,
-		Description:           constants.CustomFieldArpIPLastSeenDescription,
-		SearchWeight:          objects.SearchWeightDefault,
-		ContentTypes:          []string{constants.ContentTypeIpamIPAddress},
-	})
+	vrf2ArpEntries := make(map[*objects.VRF][]common.ArpEntry)
+	for _, entry := range pas.ArpData {
+		vrf := pas.ifaceVRF(entry.Interface)
+		vrf2ArpEntries[vrf] = append(vrf2ArpEntries[vrf], common.ArpEntry{IP: entry.IP, MAC: entry.MAC})
+	}
+	for vrf, arpEntries := range vrf2ArpEntries {
+		err := common.SyncArpTable(pas.Ctx, nbi, pas.SourceTags, pas.SourceConfig.Name, arpEntries, vrf)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncAddressObjects syncs collected address objects as prefixes, ip ranges and ip addresses.
+// It has to run after syncInterfaces and syncArpTable, so their ip addresses take precedence.
+func (pas *PaloAltoSource) syncAddressObjects(nbi *inventory.NetboxInventory) error {
+	err := common.SyncAddressObjects(pas.Ctx, nbi, pas.SourceTags, pas.AddressObjects, nil)
 	if err != nil {
-		return fmt.Errorf("add custom field: %s", err)
+		return fmt.Errorf("sync address objects: %s", err)
 	}
-	for _, entry := range pas.ArpData {
-		if entry.MAC != "(incomplete)" {
-			newTags := pas.SourceTags
-			newTags = append(newTags, arpTag)
-			currentTime := time.Now()
-			dnsName := utils.ReverseLookup(entry.IP)
-			defaultMask := 32
-			addressWithMask := fmt.Sprintf("%s/%d", entry.IP, defaultMask)
-			_, err = nbi.AddIPAddress(pas.Ctx, &objects.IPAddress{
-				NetboxObject: objects.NetboxObject{
-					Tags:        newTags,
-					Description: fmt.Sprintf("IP collected from %s arp table", pas.SourceConfig.Name),
-					CustomFields: map[string]interface{}{
-						constants.CustomFieldArpIPLastSeenName: currentTime.Format(constants.ArpLastSeenFormat),
-						constants.CustomFieldArpEntryName:      true,
-					},
-				},
-				Address: addressWithMask,
-				DNSName: dnsName,
-				Status:  &objects.IPAddressStatusActive,
-			})
-			if err != nil {
-				return fmt.Errorf("add arp ip address: %s", err)
-			}
+	return nil
+}
+
+// syncDhcpPools syncs ip pools of dhcp servers as ip ranges in the vrf of their interface.
+func (pas *PaloAltoSource) syncDhcpPools(nbi *inventory.NetboxInventory) error {
+	vrf2DhcpPools := make(map[*objects.VRF][]common.DhcpPool)
+	for _, dhcpPool := range pas.DhcpPools {
+		vrf := pas.ifaceVRF(dhcpPool.Interface)
+		vrf2DhcpPools[vrf] = append(vrf2DhcpPools[vrf], dhcpPool)
+	}
+	for vrf, dhcpPools := range vrf2DhcpPools {
+		err := common.SyncDhcpPools(pas.Ctx, nbi, pas.SourceTags, pas.NBFirewall.Name, dhcpPools, vrf)
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
+
+// syncVirtualRouters syncs a vrf for each virtual router, when firewall has multiple
+// virtual routers, because their address spaces can overlap. Ip addresses and prefixes
+// of interfaces and routes of each virtual router are then synced in its vrf.
+func (pas *PaloAltoSource) syncVirtualRouters(nbi *inventory.NetboxInventory) error {
+	pas.VirtualRouter2VRF = make(map[string]*objects.VRF)
+	if len(pas.VirtualRouters) <= 1 {
+		return nil
+	}
+	for virtualRouter := range pas.VirtualRouters {
+		vrf, err := nbi.AddVRF(pas.Ctx, &objects.VRF{
+			NetboxObject: objects.NetboxObject{
+				Tags:        pas.SourceTags,
+				Description: fmt.Sprintf("Virtual router %s", virtualRouter),
+			},
+			Name:   pas.virtualRouterVRFName(virtualRouter),
+			Tenant: pas.NBFirewall.Tenant,
+		})
+		if err != nil {
+			return fmt.Errorf("add vrf: %s", err)
+		}
+		pas.VirtualRouter2VRF[virtualRouter] = vrf
+	}
+	return nil
+}
+
+// ifaceVRF returns vrf of the interface's virtual router. It returns
+// nil, when firewall has a single virtual router.
+func (pas *PaloAltoSource) ifaceVRF(ifaceName string) *objects.VRF {
+	return pas.VirtualRouter2VRF[pas.Iface2VirtualRouter[ifaceName]]
+}
+
+// syncRoutes syncs networks from routing tables of virtual routers as prefixes
+// in the vrfs of their virtual routers.
+func (pas *PaloAltoSource) syncRoutes(nbi *inventory.NetboxInventory) error {
+	for virtualRouter, routes := range pas.VirtualRouter2Routes {
+		err := common.SyncRoutes(pas.Ctx, nbi, pas.SourceTags, routes, pas.VirtualRouter2VRF[virtualRouter])
+		if err != nil {
+			return fmt.Errorf("sync routes of virtual router %s: %s", virtualRouter, err)
+		}
+	}
+	return nil
+}
+
+// syncBGP syncs bgp configuration of the firewall's virtual routers in their vrfs,
+// when collectBgp is enabled.
+func (pas *PaloAltoSource) syncBGP(nbi *inventory.NetboxInventory) error {
+	if !pas.SourceConfig.CollectBGP {
+		return nil
+	}
+	bgpConfigs := make([]common.BGPConfig, 0, len(pas.VirtualRouter2BGPConfig))
+	for virtualRouter, bgpConfig := range pas.VirtualRouter2BGPConfig {
+		bgpConfig.VRF = pas.VirtualRouter2VRF[virtualRouter]
+		bgpConfigs = append(bgpConfigs, bgpConfig)
+	}
+	err := common.SyncBGP(pas.Ctx, nbi, pas.SourceTags, pas.NBFirewall, bgpConfigs)
+	if err != nil {
+		return fmt.Errorf("sync bgp: %s", err)
+	}
+	return nil
+}
+
+// virtualRouterVRFName returns name of the vrf representing the virtual router. Vrfs are
+// named after the virtual chassis if firewall is part of HA pair, so both members share them.
+func (pas *PaloAltoSource) virtualRouterVRFName(virtualRouter string) string {
+	deviceName := pas.NBFirewall.Name
+	if pas.NBFirewall.VirtualChassis != nil {
+		deviceName = pas.NBFirewall.VirtualChassis.Name
+	}
+	return fmt.Sprintf("%s (%s)", deviceName, virtualRouter)
+}
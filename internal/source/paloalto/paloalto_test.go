@@ -1 +1,142 @@
 package paloalto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PaloAltoNetworks/pango/netw/routing/protocol/bgp/peer"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+)
+
+func TestIsHostAttributeRelation(t *testing.T) {
+	tests := []struct {
+		regex string
+		want  bool
+	}{
+		{regex: "deviceGroup:Branch.*", want: true},
+		{regex: "^template:Ljubljana$", want: true},
+		{regex: "fw-.*", want: false},
+		{regex: ".*", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.regex, func(t *testing.T) {
+			if got := common.IsAttributeRelation(tt.regex, hostAttributePrefixes); got != tt.want {
+				t.Errorf("common.IsAttributeRelation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDhcpPoolMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  string
+		want    common.DhcpPool
+		wantErr bool
+	}{
+		{
+			name:   "Range",
+			member: "192.168.1.10-192.168.1.100",
+			want:   common.DhcpPool{StartAddress: "192.168.1.10", EndAddress: "192.168.1.100"},
+		},
+		{
+			name:   "Subnet",
+			member: "192.168.1.0/25",
+			want:   common.DhcpPool{StartAddress: "192.168.1.0", EndAddress: "192.168.1.127", MaskBits: 25},
+		},
+		{
+			name:   "Single ip",
+			member: "192.168.1.10",
+			want:   common.DhcpPool{StartAddress: "192.168.1.10", EndAddress: "192.168.1.10"},
+		},
+		{
+			name:    "Invalid member",
+			member:  "invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDhcpPoolMember(tt.member)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDhcpPoolMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDhcpPoolMember() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteEntryToRoute(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry RouteEntry
+		want  common.Route
+	}{
+		{
+			name:  "Connected route",
+			entry: RouteEntry{Destination: "10.0.0.0/24", NextHop: "10.0.0.1", Flags: "A C", Interface: "ethernet1/1"},
+			want:  common.Route{Type: common.RouteTypeConnected, Prefix: "10.0.0.0/24", Interface: "ethernet1/1"},
+		},
+		{
+			name:  "Static route",
+			entry: RouteEntry{Destination: "172.16.0.0/16", NextHop: "10.0.0.254", Flags: "A S", Interface: "ethernet1/1"},
+			want:  common.Route{Type: common.RouteTypeStatic, Prefix: "172.16.0.0/16", NextHop: "10.0.0.254", Interface: "ethernet1/1"},
+		},
+		{
+			name:  "Bgp route",
+			entry: RouteEntry{Destination: "10.10.0.0/16", NextHop: "10.0.0.250", Flags: "A B", Interface: "ethernet1/2"},
+			want:  common.Route{Type: common.RouteTypeBGP, Prefix: "10.10.0.0/16", NextHop: "10.0.0.250", Interface: "ethernet1/2"},
+		},
+		{
+			name:  "Ospf route",
+			entry: RouteEntry{Destination: "10.20.0.0/16", NextHop: "10.0.0.249", Flags: "A O", Interface: "ethernet1/3"},
+			want:  common.Route{Prefix: "10.20.0.0/16", Interface: "ethernet1/3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.toRoute(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toRoute() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToBGPPeer(t *testing.T) {
+	tests := []struct {
+		name      string
+		peerEntry peer.Entry
+		want      common.BGPPeer
+		wantErr   bool
+	}{
+		{
+			name:      "Peer with local address",
+			peerEntry: peer.Entry{Name: "isp1", PeerAs: "65002", PeerAddressIp: "10.0.0.2", LocalAddressIp: "10.0.0.1/30"},
+			want:      common.BGPPeer{RemoteAddress: "10.0.0.2", RemoteAS: 65002, LocalAddress: "10.0.0.1"},
+		},
+		{
+			name:      "Peer with asdot as",
+			peerEntry: peer.Entry{Name: "isp2", PeerAs: "1.10", PeerAddressIp: "2001:db8::2"},
+			want:      common.BGPPeer{RemoteAddress: "2001:db8::2", RemoteAS: 65546},
+		},
+		{
+			name:      "Peer with address object",
+			peerEntry: peer.Entry{Name: "isp3", PeerAs: "65003", PeerAddressIp: "isp3-address"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toBGPPeer(tt.peerEntry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toBGPPeer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toBGPPeer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package panorama
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/PaloAltoNetworks/pango"
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/source/paloalto"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+//nolint:revive
+type PanoramaSource struct {
+	common.Config
+	// Panorama data. Initialized in init functions.
+	ManagedDevices      map[string]ManagedDevice // Serial -> Managed device
+	Device2DeviceGroups map[string][]string      // Serial -> Device group names
+	Device2Templates    map[string][]string      // Serial -> Template and template stack names
+
+	// Paloalto sources of managed firewalls, that are synced
+	// through panorama. Initialized in initFirewalls.
+	Firewalls map[string]*paloalto.PaloAltoSource // Serial -> Paloalto source
+	// Serials of managed firewalls, that are not connected or failed to initialize
+	UnreachableFirewalls []string
+}
+
+func (ps *PanoramaSource) Init() error {
+	c := &pango.Panorama{Client: pango.Client{
+		Hostname:          ps.SourceConfig.Hostname,
+		Username:          ps.SourceConfig.Username,
+		Password:          ps.SourceConfig.Password,
+		Logging:           pango.LogAction | pango.LogOp,
+		VerifyCertificate: ps.SourceConfig.ValidateCert,
+		Port:              uint(ps.SourceConfig.Port),
+		Timeout:           constants.DefaultAPITimeout,
+		Protocol:          string(ps.SourceConfig.HTTPScheme),
+	}}
+
+	if err := c.Initialize(); err != nil {
+		return fmt.Errorf("panorama failed to initialize client: %s", err)
+	}
+
+	initFunctions := []func(*pango.Panorama) error{
+		ps.initManagedDevices,
+		ps.initDeviceGroups,
+		ps.initTemplates,
+		ps.initFirewalls,
+	}
+	for _, initFunc := range initFunctions {
+		startTime := time.Now()
+		if err := initFunc(c); err != nil {
+			return fmt.Errorf("panorama initialization failure: %v", err)
+		}
+		duration := time.Since(startTime)
+		ps.Logger.Infof(ps.Ctx, "Successfully initialized %s in %f seconds", utils.ExtractFunctionName(initFunc), duration.Seconds())
+	}
+	return nil
+}
+
+// Sync syncs each managed firewall using paloalto source's sync logic.
+// Reachable firewalls are synced, even if some firewalls are unreachable.
+func (ps *PanoramaSource) Sync(nbi *inventory.NetboxInventory) error {
+	serials := make([]string, 0, len(ps.Firewalls))
+	for serial := range ps.Firewalls {
+		serials = append(serials, serial)
+	}
+	slices.Sort(serials)
+	for _, serial := range serials {
+		startTime := time.Now()
+		err := ps.Firewalls[serial].Sync(nbi)
+		if err != nil {
+			return fmt.Errorf("sync firewall %s: %s", serial, err)
+		}
+		duration := time.Since(startTime)
+		ps.Logger.Infof(ps.Ctx, "Successfully synced firewall %s (%s) in %f seconds", ps.ManagedDevices[serial].Hostname, serial, duration.Seconds())
+	}
+	// Objects of unreachable firewalls are not synced, so the run must fail to keep them from orphan cleanup
+	if len(ps.UnreachableFirewalls) > 0 {
+		slices.Sort(ps.UnreachableFirewalls)
+		return fmt.Errorf("firewalls %v are unreachable, their objects were not synced", ps.UnreachableFirewalls)
+	}
+	return nil
+}
+
+// deviceAttributes returns host attributes of the managed device with the given serial,
+// which are used for matching the device to site and tenant.
+func (ps *PanoramaSource) deviceAttributes(serial string) []string {
+	attributes := make([]string, 0, len(ps.Device2DeviceGroups[serial])+len(ps.Device2Templates[serial]))
+	for _, deviceGroup := range ps.Device2DeviceGroups[serial] {
+		attributes = append(attributes, paloalto.DeviceGroupAttributePrefix+deviceGroup)
+	}
+	for _, template := range ps.Device2Templates[serial] {
+		attributes = append(attributes, paloalto.TemplateAttributePrefix+template)
+	}
+	return attributes
+}
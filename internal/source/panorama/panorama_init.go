@@ -0,0 +1,151 @@
+package panorama
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/PaloAltoNetworks/pango"
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/source/paloalto"
+)
+
+// Structs to parse xml response of connected managed devices.
+type ManagedDevicesResponse struct {
+	XMLName xml.Name             `xml:"response"`
+	Status  string               `xml:"status,attr"`
+	Result  ManagedDevicesResult `xml:"result"`
+}
+
+type ManagedDevicesResult struct {
+	Devices []ManagedDevice `xml:"devices>entry"`
+}
+
+type ManagedDevice struct {
+	Serial    string `xml:"serial"`
+	Hostname  string `xml:"hostname"`
+	IPAddress string `xml:"ip-address"`
+	Model     string `xml:"model"`
+	SWVersion string `xml:"sw-version"`
+	Connected string `xml:"connected"`
+}
+
+// parseManagedDevices parses xml response of the show devices command. It returns
+// devices connected to panorama, and serials of devices that are not connected.
+func parseManagedDevices(response []byte) (map[string]ManagedDevice, []string, error) {
+	var devicesResponse ManagedDevicesResponse
+	if err := xml.Unmarshal(response, &devicesResponse); err != nil {
+		return nil, nil, err
+	}
+	managedDevices := make(map[string]ManagedDevice)
+	var disconnectedDevices []string
+	for _, device := range devicesResponse.Result.Devices {
+		if device.Serial == "" {
+			continue
+		}
+		if device.Connected != "yes" {
+			disconnectedDevices = append(disconnectedDevices, device.Serial)
+			continue
+		}
+		managedDevices[device.Serial] = device
+	}
+	return managedDevices, disconnectedDevices, nil
+}
+
+// initManagedDevices collects all firewalls managed by panorama. Firewalls, that
+// are not connected, are marked as unreachable.
+func (ps *PanoramaSource) initManagedDevices(c *pango.Panorama) error {
+	devicesXMLString := "<show><devices><all/></devices></show>"
+	devicesXMLResponse, err := c.Op(devicesXMLString, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("init managed devices: %s", err)
+	}
+	ps.ManagedDevices, ps.UnreachableFirewalls, err = parseManagedDevices(devicesXMLResponse)
+	if err != nil {
+		return fmt.Errorf("init managed devices: %s", err)
+	}
+	for _, serial := range ps.UnreachableFirewalls {
+		ps.Logger.Warningf(ps.Ctx, "firewall %s is not connected to panorama. Skipping...", serial)
+	}
+	return nil
+}
+
+// initDeviceGroups collects device groups and maps managed devices to them.
+func (ps *PanoramaSource) initDeviceGroups(c *pango.Panorama) error {
+	deviceGroups, err := c.Panorama.DeviceGroup.GetAll()
+	if err != nil {
+		return fmt.Errorf("get all device groups: %s", err)
+	}
+	ps.Device2DeviceGroups = make(map[string][]string)
+	for _, deviceGroup := range deviceGroups {
+		for serial := range deviceGroup.Devices {
+			ps.Device2DeviceGroups[serial] = append(ps.Device2DeviceGroups[serial], deviceGroup.Name)
+		}
+	}
+	for serial := range ps.Device2DeviceGroups {
+		slices.Sort(ps.Device2DeviceGroups[serial])
+	}
+	return nil
+}
+
+// initTemplates collects templates and template stacks and maps managed devices to them.
+func (ps *PanoramaSource) initTemplates(c *pango.Panorama) error {
+	templates, err := c.Panorama.Template.GetAll()
+	if err != nil {
+		return fmt.Errorf("get all templates: %s", err)
+	}
+	templateStacks, err := c.Panorama.TemplateStack.GetAll()
+	if err != nil {
+		return fmt.Errorf("get all template stacks: %s", err)
+	}
+	ps.Device2Templates = make(map[string][]string)
+	for _, template := range templates {
+		for serial := range template.Devices {
+			ps.Device2Templates[serial] = append(ps.Device2Templates[serial], template.Name)
+		}
+	}
+	for _, templateStack := range templateStacks {
+		for _, serial := range templateStack.Devices {
+			ps.Device2Templates[serial] = append(ps.Device2Templates[serial], templateStack.Name)
+		}
+	}
+	for serial := range ps.Device2Templates {
+		slices.Sort(ps.Device2Templates[serial])
+	}
+	return nil
+}
+
+// initFirewalls creates paloalto source for each managed firewall. Requests of each
+// firewall's client are proxied through panorama to the firewall with its serial.
+// Firewalls that fail to initialize are marked as unreachable, so they don't block the others.
+func (ps *PanoramaSource) initFirewalls(c *pango.Panorama) error {
+	ps.Firewalls = make(map[string]*paloalto.PaloAltoSource)
+	for serial, device := range ps.ManagedDevices {
+		fwClient := &pango.Firewall{Client: pango.Client{
+			Hostname:          ps.SourceConfig.Hostname,
+			ApiKey:            c.ApiKey,
+			Logging:           pango.LogAction | pango.LogOp,
+			VerifyCertificate: ps.SourceConfig.ValidateCert,
+			Port:              uint(ps.SourceConfig.Port),
+			Timeout:           constants.DefaultAPITimeout,
+			Protocol:          string(ps.SourceConfig.HTTPScheme),
+			Target:            serial,
+		}}
+		if err := fwClient.Initialize(); err != nil {
+			ps.Logger.Errorf(ps.Ctx, "failed to initialize client for firewall %s (%s): %s", device.Hostname, serial, err)
+			ps.UnreachableFirewalls = append(ps.UnreachableFirewalls, serial)
+			continue
+		}
+		firewall := &paloalto.PaloAltoSource{
+			Config:         ps.Config,
+			HostAttributes: ps.deviceAttributes(serial),
+		}
+		if err := firewall.InitWithClient(fwClient); err != nil {
+			ps.Logger.Errorf(ps.Ctx, "failed to initialize firewall %s (%s): %s", device.Hostname, serial, err)
+			ps.UnreachableFirewalls = append(ps.UnreachableFirewalls, serial)
+			continue
+		}
+		ps.Firewalls[serial] = firewall
+	}
+	return nil
+}
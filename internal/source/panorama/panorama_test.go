@@ -0,0 +1,85 @@
+package panorama
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseManagedDevices(t *testing.T) {
+	response := []byte(`<response status="success"><result><devices>
+	<entry name="007051000012345">
+		<serial>007051000012345</serial>
+		<connected>yes</connected>
+		<hostname>fw-branch-1</hostname>
+		<ip-address>10.0.0.1</ip-address>
+		<model>PA-440</model>
+		<sw-version>10.2.4</sw-version>
+	</entry>
+	<entry name="007051000054321">
+		<serial>007051000054321</serial>
+		<connected>no</connected>
+		<hostname>fw-branch-2</hostname>
+	</entry>
+	</devices></result></response>`)
+	want := map[string]ManagedDevice{
+		"007051000012345": {
+			Serial:    "007051000012345",
+			Hostname:  "fw-branch-1",
+			IPAddress: "10.0.0.1",
+			Model:     "PA-440",
+			SWVersion: "10.2.4",
+			Connected: "yes",
+		},
+	}
+	wantDisconnected := []string{"007051000054321"}
+	got, gotDisconnected, err := parseManagedDevices(response)
+	if err != nil {
+		t.Fatalf("parseManagedDevices() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseManagedDevices() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(gotDisconnected, wantDisconnected) {
+		t.Errorf("parseManagedDevices() disconnected = %v, want %v", gotDisconnected, wantDisconnected)
+	}
+}
+
+func TestPanoramaSource_deviceAttributes(t *testing.T) {
+	ps := &PanoramaSource{
+		Device2DeviceGroups: map[string][]string{
+			"serial1": {"Branches", "Europe"},
+		},
+		Device2Templates: map[string][]string{
+			"serial1": {"Ljubljana"},
+			"serial2": {"DC"},
+		},
+	}
+	tests := []struct {
+		name   string
+		serial string
+		want   []string
+	}{
+		{
+			name:   "Device groups and templates",
+			serial: "serial1",
+			want:   []string{"deviceGroup:Branches", "deviceGroup:Europe", "template:Ljubljana"},
+		},
+		{
+			name:   "Only templates",
+			serial: "serial2",
+			want:   []string{"template:DC"},
+		},
+		{
+			name:   "Unknown device",
+			serial: "serial3",
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ps.deviceAttributes(tt.serial); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PanoramaSource.deviceAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,12 +19,17 @@ type ProxmoxSource struct {
 	common.Config
 
 	// Proxmox API data initialized in init functions
-	Cluster      *proxmox.Cluster
-	Nodes        []*proxmox.Node
-	NodeNetworks map[string][]*proxmox.NodeNetwork       // NodeName -> NodeNetworks (interfaces)
-	Vms          map[string][]*proxmox.VirtualMachine    // NodeName -> VirtualMachines
-	VMNetworks   map[string][]*proxmox.AgentNetworkIface // VMName -> NetworkDevices
-	Containers   map[string][]*proxmox.Container         // NodeName -> Contatiners
+	Cluster           *proxmox.Cluster
+	Nodes             []*proxmox.Node
+	OfflineNodes      []string                                // Names of nodes, whose data couldn't be collected
+	NodeNetworks      map[string][]*proxmox.NodeNetwork       // NodeName -> NodeNetworks (interfaces)
+	Vms               map[string][]*proxmox.VirtualMachine    // NodeName -> VirtualMachines
+	VMNetworks        map[string][]*proxmox.AgentNetworkIface // VMName -> NetworkDevices
+	Containers        map[string][]*proxmox.Container         // NodeName -> Contatiners
+	ContainerNetworks map[string][]*ContainerNetwork          // ContainerName -> ContainerNetworks (interfaces)
+	SDNZones          []*SDNZone
+	SDNVnets          []*SDNVnet
+	SDNSubnets        map[string][]*SDNSubnet // VnetName -> SDNSubnets
 
 	// Netbox related data for easier access. Initialized in sync functions.
 	NetboxCluster *objects.Cluster
@@ -59,30 +64,19 @@ func (ps *ProxmoxSource) Init() error {
 	ps.VlanTenantRelations = utils.ConvertStringsToRegexPairs(ps.SourceConfig.VlanTenantRelations)
 	ps.Logger.Debug(ps.Ctx, "VlanTenantRelations: ", ps.VlanTenantRelations)
 
-	// Initialize the connection
-	credentials := proxmox.Credentials{
-		Username: ps.SourceConfig.Username,
-		Password: ps.SourceConfig.Password,
-	}
-	HTTPClient := http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: !ps.SourceConfig.ValidateCert,
-			},
-		},
-	}
-	client := proxmox.NewClient(fmt.Sprintf("%s://%s:%d/api2/json",
-		ps.SourceConfig.HTTPScheme, ps.SourceConfig.Hostname, ps.SourceConfig.Port),
-		proxmox.WithCredentials(&credentials),
-		proxmox.WithHTTPClient(&HTTPClient),
-	)
-
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Initialize the connection
+	client, err := ps.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("proxmox initialization failure: %v", err)
+	}
+
 	initFuncs := []func(context.Context, *proxmox.Client) error{
 		ps.initCluster,
 		ps.initNodes,
+		ps.initSDN,
 	}
 
 	for _, initFunc := range initFuncs {
@@ -97,11 +91,49 @@ func (ps *ProxmoxSource) Init() error {
 	return nil
 }
 
+// connect returns client connected to the first reachable hostname of the source.
+// Configured hostname is tried first, and then failover hostnames in order,
+// so the source still works when the configured cluster member is down.
+func (ps *ProxmoxSource) connect(ctx context.Context) (*proxmox.Client, error) {
+	credentials := proxmox.Credentials{
+		Username: ps.SourceConfig.Username,
+		Password: ps.SourceConfig.Password,
+	}
+	HTTPClient := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !ps.SourceConfig.ValidateCert,
+			},
+		},
+	}
+	hostnames := append([]string{ps.SourceConfig.Hostname}, ps.SourceConfig.FailoverHostnames...)
+	var err error
+	for _, hostname := range hostnames {
+		client := proxmox.NewClient(fmt.Sprintf("%s://%s:%d/api2/json",
+			ps.SourceConfig.HTTPScheme, hostname, ps.SourceConfig.Port),
+			proxmox.WithCredentials(&credentials),
+			proxmox.WithHTTPClient(&HTTPClient),
+		)
+		// Version endpoint is used to check that the host is reachable and credentials are valid
+		_, err = client.Version(ctx)
+		if err != nil {
+			ps.Logger.Warningf(ps.Ctx, "proxmox host %s is not reachable: %s", hostname, err)
+			continue
+		}
+		if hostname != ps.SourceConfig.Hostname {
+			ps.Logger.Warningf(ps.Ctx, "failed over from %s to %s", ps.SourceConfig.Hostname, hostname)
+		}
+		return client, nil
+	}
+	return nil, fmt.Errorf("none of the hosts %v is reachable: %s", hostnames, err)
+}
+
 // Function that syncs all collected data to Netbox inventory.
 func (ps *ProxmoxSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		ps.syncCluster,
 		ps.syncNodes,
+		ps.syncSDN,
 		ps.syncVMs,
 		ps.syncContainers,
 	}
@@ -114,5 +146,9 @@ func (ps *ProxmoxSource) Sync(nbi *inventory.NetboxInventory) error {
 		duration := time.Since(startTime)
 		ps.Logger.Infof(ps.Ctx, "Successfully synced %s in %f seconds", utils.ExtractFunctionName(syncFunc), duration.Seconds())
 	}
+	// Objects of offline nodes are not synced, so the run must fail to keep them from orphan cleanup
+	if len(ps.OfflineNodes) > 0 {
+		return fmt.Errorf("nodes %v are offline, their objects were not synced", ps.OfflineNodes)
+	}
 	return nil
 }
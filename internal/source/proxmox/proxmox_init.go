@@ -3,6 +3,9 @@ package proxmox
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/luthermonson/go-proxmox"
 )
@@ -27,7 +30,16 @@ func (ps *ProxmoxSource) initNodes(ctx context.Context, c *proxmox.Client) error
 	ps.NodeNetworks = make(map[string][]*proxmox.NodeNetwork, len(nodes))
 	ps.Vms = make(map[string][]*proxmox.VirtualMachine, len(nodes))
 	ps.Containers = make(map[string][]*proxmox.Container, len(nodes))
+	ps.ContainerNetworks = make(map[string][]*ContainerNetwork)
+	ps.OfflineNodes = nil
 	for _, node := range nodes {
+		// Data of offline cluster members can't be collected. Online members are
+		// still synced, but the sync fails at the end (see Sync)
+		if node.Status != "online" {
+			ps.Logger.Warningf(ps.Ctx, "skipping node %s with status %s", node.Node, node.Status)
+			ps.OfflineNodes = append(ps.OfflineNodes, node.Node)
+			continue
+		}
 		node, err := c.Node(ctx, node.Node)
 		if err != nil {
 			return fmt.Errorf("init node: %s", err)
@@ -44,7 +56,7 @@ func (ps *ProxmoxSource) initNodes(ctx context.Context, c *proxmox.Client) error
 			return fmt.Errorf("init nodeVMs: %s", err)
 		}
 
-		err = ps.initContainers(ctx, node)
+		err = ps.initContainers(ctx, c, node)
 		if err != nil {
 			return fmt.Errorf("init node containers: %s", err)
 		}
@@ -86,19 +98,178 @@ func (ps *ProxmoxSource) initNodeVMs(ctx context.Context, node *proxmox.Node) er
 	return nil
 }
 
-// Helper function for initNodes. It collects all containers for given node.
-func (ps *ProxmoxSource) initContainers(ctx context.Context, node *proxmox.Node) error {
+// Helper function for initNodes. It collects all containers for given node,
+// together with their network interfaces.
+func (ps *ProxmoxSource) initContainers(ctx context.Context, c *proxmox.Client, node *proxmox.Node) error {
 	containers, err := node.Containers(ctx)
 	if err != nil {
 		return err
 	}
 	ps.Containers[node.Name] = make([]*proxmox.Container, 0, len(containers))
-	// ps.VMNetworks = make(map[string][]*proxmox.AgentNetworkIface, len(containers))
 	for _, container := range containers {
 		ps.Containers[node.Name] = append(ps.Containers[node.Name], container)
-		// ifaces, _ := container.AgentGetNetworkIFaces(ctx)
-		// ps.VMNetworks[container.Name] = make([]*proxmox.AgentNetworkIface, 0, len(ifaces))
-		// ps.VMNetworks[container.Name] = append(ps.VMNetworks[container.Name], ifaces...)
+		containerNetworks, err := ps.initContainerNetworks(ctx, c, node, container)
+		if err != nil {
+			return fmt.Errorf("init container %s networks: %s", container.Name, err)
+		}
+		ps.ContainerNetworks[container.Name] = containerNetworks
+	}
+	return nil
+}
+
+// ContainerNetwork represents network interface of the LXC container.
+type ContainerNetwork struct {
+	Name   string
+	Bridge string
+	Tag    int
+	HWAddr string
+	// IPs contains static ips from the container config and
+	// ips reported by the running container, in CIDR notation.
+	IPs []string
+}
+
+// ContainerInterface represents runtime network interface of the running container,
+// returned by /nodes/{node}/lxc/{vmid}/interfaces endpoint.
+type ContainerInterface struct {
+	Name   string `json:"name"`
+	HWAddr string `json:"hwaddr"`
+	Inet   string `json:"inet"`
+	Inet6  string `json:"inet6"`
+}
+
+// Helper function for initContainers. It collects network interfaces of the container
+// from its config (net0..netN) and merges them with runtime interfaces of the container.
+func (ps *ProxmoxSource) initContainerNetworks(ctx context.Context, c *proxmox.Client, node *proxmox.Node, container *proxmox.Container) ([]*ContainerNetwork, error) {
+	var containerConfig map[string]interface{}
+	err := c.Get(ctx, fmt.Sprintf("/nodes/%s/lxc/%d/config", node.Name, uint64(container.VMID)), &containerConfig)
+	if err != nil {
+		return nil, err
+	}
+	containerNetworks := make([]*ContainerNetwork, 0)
+	name2Network := make(map[string]*ContainerNetwork)
+	for i := 0; i < maxContainerNetworks; i++ {
+		netConfig, ok := containerConfig[fmt.Sprintf("net%d", i)].(string)
+		if !ok {
+			continue
+		}
+		containerNetwork := parseContainerNetConfig(netConfig)
+		if containerNetwork.Name == "" {
+			continue
+		}
+		containerNetworks = append(containerNetworks, containerNetwork)
+		name2Network[containerNetwork.Name] = containerNetwork
+	}
+
+	// Runtime interfaces are only available for running containers (PVE 8.1+)
+	var containerIfaces []ContainerInterface
+	err = c.Get(ctx, fmt.Sprintf("/nodes/%s/lxc/%d/interfaces", node.Name, uint64(container.VMID)), &containerIfaces)
+	if err != nil {
+		ps.Logger.Debugf(ps.Ctx, "can't collect runtime interfaces of container %s: %s", container.Name, err)
+	}
+	for _, containerIface := range containerIfaces {
+		if containerIface.Name == "" || containerIface.Name == "lo" {
+			continue
+		}
+		containerNetwork, ok := name2Network[containerIface.Name]
+		if !ok {
+			containerNetwork = &ContainerNetwork{Name: containerIface.Name}
+			containerNetworks = append(containerNetworks, containerNetwork)
+			name2Network[containerIface.Name] = containerNetwork
+		}
+		if containerNetwork.HWAddr == "" {
+			containerNetwork.HWAddr = containerIface.HWAddr
+		}
+		for _, ipAddress := range []string{containerIface.Inet, containerIface.Inet6} {
+			// Link local addresses are skipped, since they are present on every interface
+			if ipAddress == "" || strings.HasPrefix(ipAddress, "fe80:") {
+				continue
+			}
+			if !slices.Contains(containerNetwork.IPs, ipAddress) {
+				containerNetwork.IPs = append(containerNetwork.IPs, ipAddress)
+			}
+		}
+	}
+	return containerNetworks, nil
+}
+
+// maxContainerNetworks is the maximum number of network interfaces (net0..net31) of the container.
+const maxContainerNetworks = 32
+
+// parseContainerNetConfig parses network interface from the container config, e.g.
+// "name=eth0,bridge=vmbr0,hwaddr=BC:24:11:AA:BB:CC,ip=10.0.0.5/24,ip6=dhcp,tag=20,type=veth".
+// Only static ips are collected, dynamic ones (dhcp, auto, manual) are reported by the
+// running container.
+func parseContainerNetConfig(netConfig string) *ContainerNetwork {
+	containerNetwork := &ContainerNetwork{}
+	for _, option := range strings.Split(netConfig, ",") {
+		key, value, found := strings.Cut(option, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "name":
+			containerNetwork.Name = value
+		case "bridge":
+			containerNetwork.Bridge = value
+		case "hwaddr":
+			containerNetwork.HWAddr = value
+		case "tag":
+			containerNetwork.Tag, _ = strconv.Atoi(value)
+		case "ip", "ip6":
+			if strings.Contains(value, "/") {
+				containerNetwork.IPs = append(containerNetwork.IPs, value)
+			}
+		}
+	}
+	return containerNetwork
+}
+
+// SDNZone represents proxmox SDN zone, returned by /cluster/sdn/zones endpoint.
+type SDNZone struct {
+	Zone string `json:"zone"`
+	Type string `json:"type"`
+}
+
+// SDNVnet represents proxmox SDN vnet, returned by /cluster/sdn/vnets endpoint.
+// Tag is vlan id for vlan/qinq zones and vni for vxlan/evpn zones.
+type SDNVnet struct {
+	Vnet  string `json:"vnet"`
+	Zone  string `json:"zone"`
+	Alias string `json:"alias"`
+	Tag   int    `json:"tag"`
+}
+
+// SDNSubnet represents subnet of the proxmox SDN vnet, returned by
+// /cluster/sdn/vnets/{vnet}/subnets endpoint.
+type SDNSubnet struct {
+	Subnet  string `json:"subnet"`
+	CIDR    string `json:"cidr"`
+	Gateway string `json:"gateway"`
+	Vnet    string `json:"vnet"`
+}
+
+// initSDN collects SDN zones, vnets and their subnets. SDN is optional
+// (not installed or not supported on older proxmox versions), so
+// failure of collecting it is not fatal.
+func (ps *ProxmoxSource) initSDN(ctx context.Context, c *proxmox.Client) error {
+	ps.SDNSubnets = make(map[string][]*SDNSubnet)
+	err := c.Get(ctx, "/cluster/sdn/zones", &ps.SDNZones)
+	if err != nil {
+		ps.Logger.Debugf(ps.Ctx, "can't collect sdn zones: %s", err)
+		return nil
+	}
+	err = c.Get(ctx, "/cluster/sdn/vnets", &ps.SDNVnets)
+	if err != nil {
+		ps.Logger.Debugf(ps.Ctx, "can't collect sdn vnets: %s", err)
+		return nil
+	}
+	for _, vnet := range ps.SDNVnets {
+		var subnets []*SDNSubnet
+		err = c.Get(ctx, fmt.Sprintf("/cluster/sdn/vnets/%s/subnets", vnet.Vnet), &subnets)
+		if err != nil {
+			return fmt.Errorf("init subnets of vnet %s: %s", vnet.Vnet, err)
+		}
+		ps.SDNSubnets[vnet.Vnet] = subnets
 	}
 	return nil
 }
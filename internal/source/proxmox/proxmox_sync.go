@@ -2,6 +2,8 @@ package proxmox
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -13,6 +15,16 @@ import (
 )
 
 func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
+	// Check if proxmox is running standalone node (in that case cluster name is empty).
+	// Cluster is then synthesized per node, so standalone nodes from different
+	// sources don't end up in the same cluster.
+	if ps.Cluster.Name == "" {
+		ps.Cluster.Name = standaloneClusterName(ps.Nodes)
+		if err := ps.migrateStandaloneCluster(nbi); err != nil {
+			return err
+		}
+	}
+
 	clusterSite, err := common.MatchClusterToSite(ps.Ctx, nbi, ps.Cluster.Name, ps.ClusterSiteRelations)
 	if err != nil {
 		return err
@@ -35,11 +47,6 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 		return fmt.Errorf("proxmox cluster type: %s", err)
 	}
 
-	// Check if proxmox is running standalon node (in that case cluster name is empty)
-	if ps.Cluster.Name == "" {
-		ps.Cluster.Name = "ProxmoxStandalone"
-	}
-
 	nbCluster, err := nbi.AddCluster(ps.Ctx, &objects.Cluster{
 		NetboxObject: objects.NetboxObject{
 			Tags: ps.SourceTags,
@@ -59,6 +66,38 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// legacyStandaloneClusterName is the name, that was previously used for clusters of all standalone nodes.
+const legacyStandaloneClusterName = "ProxmoxStandalone"
+
+// standaloneClusterName returns name of the cluster for standalone proxmox node.
+func standaloneClusterName(nodes []*proxmox.Node) string {
+	if len(nodes) == 1 {
+		return fmt.Sprintf("%s (standalone)", nodes[0].Name)
+	}
+	return legacyStandaloneClusterName
+}
+
+// migrateStandaloneCluster renames cluster synced by this source under the legacy
+// standalone name to the per node name, so its nodes and vms are not recreated.
+func (ps *ProxmoxSource) migrateStandaloneCluster(nbi *inventory.NetboxInventory) error {
+	if ps.Cluster.Name == legacyStandaloneClusterName {
+		return nil
+	}
+	legacyCluster, ok := nbi.ClustersIndexByName[legacyStandaloneClusterName]
+	if !ok || legacyCluster.CustomFields[constants.CustomFieldSourceName] != ps.SourceConfig.Name {
+		return nil
+	}
+	if _, ok := nbi.ClustersIndexByName[ps.Cluster.Name]; ok {
+		return nil
+	}
+	ps.Logger.Infof(ps.Ctx, "renaming cluster %s to %s", legacyStandaloneClusterName, ps.Cluster.Name)
+	_, err := nbi.RenameCluster(ps.Ctx, legacyStandaloneClusterName, ps.Cluster.Name)
+	if err != nil {
+		return fmt.Errorf("migrate standalone cluster: %s", err)
+	}
+	return nil
+}
+
 func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 	ps.NetboxNodes = make(map[string]*objects.Device, len(ps.Nodes))
 	for _, node := range ps.Nodes {
@@ -125,41 +164,291 @@ func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// syncNodeNetworks syncs all network interfaces of the node. Interfaces are first created
+// with their type, then they are linked with their bonds (LAG), bridges and parent
+// interfaces (vlan raw device). Finally ips are added to interfaces and the ip on the
+// interface with the default gateway is set as primary ip of the node.
 func (ps *ProxmoxSource) syncNodeNetworks(nbi *inventory.NetboxInventory, node *proxmox.Node) error {
-	// hostIPv4Addresses := []*objects.IPAddress TODO
-	// hostIPv6Addresses := []*objects.IPAddress TODO
+	nbHost := ps.NetboxNodes[node.Name]
+	nodeNetworks := make([]*proxmox.NodeNetwork, 0, len(ps.NodeNetworks[node.Name]))
+	nbIfaces := make(map[string]*objects.Interface, len(ps.NodeNetworks[node.Name]))
 	for _, nodeNetwork := range ps.NodeNetworks[node.Name] {
-		active := false
-		if nodeNetwork.Active == 1 {
-			active = true
-		}
-		nbHost := ps.NetboxNodes[node.Name]
 		if utils.FilterInterfaceName(nodeNetwork.Iface, ps.SourceConfig.InterfaceFilter) {
 			ps.Logger.Debugf(ps.Ctx, "interface %s is filtered out with interfaceFilter %s", nodeNetwork.Iface, ps.SourceConfig.InterfaceFilter)
 			continue
 		}
-		_, err := nbi.AddInterface(ps.Ctx, &objects.Interface{
+		var ifaceMode *objects.InterfaceMode
+		if nodeNetwork.BridgeVLANAware == 1 {
+			ifaceMode = &objects.InterfaceModeTaggedAll
+		}
+		ifaceMTU, _ := strconv.Atoi(nodeNetwork.MTU)
+		nbIface, err := nbi.AddInterface(ps.Ctx, &objects.Interface{
 			NetboxObject: objects.NetboxObject{
-				Tags: ps.Config.SourceTags,
+				Tags:        ps.Config.SourceTags,
+				Description: nodeNetwork.Comments,
 				CustomFields: map[string]interface{}{
 					constants.CustomFieldSourceName: ps.SourceConfig.Name,
 				},
 			},
 			Device: nbHost,
 			Name:   nodeNetwork.Iface,
-			Status: active,
-			Type:   &objects.OtherInterfaceType, // TODO
-			// Speed: TODO
-			// Mode: TODO
-			// TaggedVlans: TODO
+			Status: nodeNetwork.Active == 1,
+			Type:   nodeNetworkType(nodeNetwork.Type),
+			MTU:    ifaceMTU,
+			Mode:   ifaceMode,
 		})
 		if err != nil {
 			return fmt.Errorf("add host interface: %s", err)
 		}
+		nodeNetworks = append(nodeNetworks, nodeNetwork)
+		nbIfaces[nodeNetwork.Iface] = nbIface
+	}
+
+	// Link interfaces with their bonds, bridges and parents
+	for _, nodeNetwork := range nodeNetworks {
+		for _, slave := range strings.Fields(nodeNetwork.Slaves) {
+			if err := ps.linkNodeInterface(nbi, nbIfaces, slave, func(iface *objects.Interface) { iface.LAG = nbIfaces[nodeNetwork.Iface] }); err != nil {
+				return fmt.Errorf("link bond slave %s: %s", slave, err)
+			}
+		}
+		for _, bridgePort := range strings.Fields(nodeNetwork.BridgePorts) {
+			if err := ps.linkNodeInterface(nbi, nbIfaces, bridgePort, func(iface *objects.Interface) { iface.BridgedInterface = nbIfaces[nodeNetwork.Iface] }); err != nil {
+				return fmt.Errorf("link bridge port %s: %s", bridgePort, err)
+			}
+		}
+		if parentName := vlanRawDevice(nodeNetwork); parentName != "" {
+			nbParent, ok := nbIfaces[parentName]
+			if !ok {
+				continue
+			}
+			if err := ps.linkNodeInterface(nbi, nbIfaces, nodeNetwork.Iface, func(iface *objects.Interface) { iface.ParentInterface = nbParent }); err != nil {
+				return fmt.Errorf("link vlan interface %s: %s", nodeNetwork.Iface, err)
+			}
+		}
 	}
+
+	// Add ips to interfaces and determine primary ips of the node
+	var hostIPv4, hostIPv6 *objects.IPAddress
+	for _, nodeNetwork := range nodeNetworks {
+		for _, ipAddress := range []string{nodeNetwork.CIDR, nodeNetwork.CIDR6} {
+			if ipAddress == "" {
+				continue
+			}
+			address := strings.Split(ipAddress, "/")[0]
+			if utils.SubnetsContainIPAddress(address, ps.SourceConfig.IgnoredSubnets) {
+				continue
+			}
+			nbIPAddress, err := nbi.AddIPAddress(ps.Ctx, &objects.IPAddress{
+				NetboxObject: objects.NetboxObject{
+					Tags: ps.Config.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName:   ps.SourceConfig.Name,
+						constants.CustomFieldArpEntryName: false,
+					},
+				},
+				Address:            ipAddress,
+				Status:             &objects.IPAddressStatusActive,
+				DNSName:            utils.ReverseLookup(address),
+				Tenant:             nbHost.Tenant,
+				AssignedObjectType: objects.AssignedObjectTypeDeviceInterface,
+				AssignedObjectID:   nbIfaces[nodeNetwork.Iface].ID,
+			})
+			if err != nil {
+				return fmt.Errorf("add ip address: %s", err)
+			}
+			// Ip on the interface with the default gateway is preferred as primary ip
+			switch utils.GetIPVersion(address) {
+			case constants.IPv4:
+				if hostIPv4 == nil || nodeNetwork.Gateway != "" {
+					hostIPv4 = nbIPAddress
+				}
+			case constants.IPv6:
+				if hostIPv6 == nil || nodeNetwork.Gateway6 != "" {
+					hostIPv6 = nbIPAddress
+				}
+			}
+			prefix, err := utils.ExtractPrefixFromIPAddress(nbIPAddress.Address)
+			if err != nil {
+				ps.Logger.Warningf(ps.Ctx, "extract prefix from ip address: %s", err)
+				continue
+			}
+			_, err = nbi.AddPrefix(ps.Ctx, &objects.Prefix{
+				Prefix: prefix,
+			})
+			if err != nil {
+				ps.Logger.Errorf(ps.Ctx, "adding prefix: %s", err)
+			}
+		}
+	}
+	if hostIPv4 != nil || hostIPv6 != nil {
+		nbHostCopy := *nbHost
+		nbHostCopy.PrimaryIPv4 = hostIPv4
+		nbHostCopy.PrimaryIPv6 = hostIPv6
+		nbHost, err := nbi.AddDevice(ps.Ctx, &nbHostCopy)
+		if err != nil {
+			return fmt.Errorf("adding primary ip of the host: %s", err)
+		}
+		ps.NetboxNodes[node.Name] = nbHost
+	}
+	return nil
+}
+
+// linkNodeInterface applies link to the already synced interface with the given name
+// (e.g. sets its LAG) and updates it in netbox. Unknown interfaces are skipped.
+func (ps *ProxmoxSource) linkNodeInterface(nbi *inventory.NetboxInventory, nbIfaces map[string]*objects.Interface, ifaceName string, link func(*objects.Interface)) error {
+	nbIface, ok := nbIfaces[ifaceName]
+	if !ok {
+		ps.Logger.Debugf(ps.Ctx, "interface %s is not synced. Skipping...", ifaceName)
+		return nil
+	}
+	nbIfaceCopy := *nbIface
+	link(&nbIfaceCopy)
+	nbIface, err := nbi.AddInterface(ps.Ctx, &nbIfaceCopy)
+	if err != nil {
+		return err
+	}
+	nbIfaces[ifaceName] = nbIface
 	return nil
 }
 
+// nodeNetworkType maps type of the proxmox node network to the netbox interface type.
+func nodeNetworkType(networkType string) *objects.InterfaceType {
+	switch networkType {
+	case "bond", "OVSBond":
+		return &objects.LAGInterfaceType
+	case "bridge", "OVSBridge":
+		return &objects.BridgeInterfaceType
+	case "vlan", "alias", "OVSIntPort":
+		return &objects.VirtualInterfaceType
+	default:
+		return &objects.OtherInterfaceType
+	}
+}
+
+// vlanRawDevice returns name of the parent interface of the vlan interface.
+// If vlan-raw-device is not set, it is determined from the interface name (e.g. eno1.10).
+func vlanRawDevice(nodeNetwork *proxmox.NodeNetwork) string {
+	if nodeNetwork.Type != "vlan" {
+		return ""
+	}
+	if nodeNetwork.VLANRawDevice != "" {
+		return nodeNetwork.VLANRawDevice
+	}
+	if parentName, _, found := strings.Cut(nodeNetwork.Iface, "."); found {
+		return parentName
+	}
+	return ""
+}
+
+// sdnVlanZoneTypes are types of proxmox SDN zones, whose vnets are vlans.
+// Vnets of other zones are either untagged (simple) or tagged with vni (vxlan, evpn).
+var sdnVlanZoneTypes = []string{"vlan", "qinq"}
+
+// syncSDN syncs proxmox SDN vnets of vlan and qinq zones as vlans, where each
+// zone gets its own vlan group. Subnets of vnets are synced as prefixes linked to vnet's vlan.
+func (ps *ProxmoxSource) syncSDN(nbi *inventory.NetboxInventory) error {
+	zoneVlanGroups := make(map[string]*objects.VlanGroup, len(ps.SDNZones))
+	for _, zone := range ps.SDNZones {
+		if !slices.Contains(sdnVlanZoneTypes, zone.Type) {
+			continue
+		}
+		vlanGroupName := fmt.Sprintf("%s (%s)", zone.Zone, ps.NetboxCluster.Name)
+		vlanGroup, err := nbi.AddVlanGroup(ps.Ctx, &objects.VlanGroup{
+			NetboxObject: objects.NetboxObject{
+				Tags:        ps.SourceTags,
+				Description: fmt.Sprintf("Proxmox SDN %s zone", zone.Type),
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName: ps.SourceConfig.Name,
+				},
+			},
+			Name:   vlanGroupName,
+			Slug:   utils.Slugify(vlanGroupName),
+			MinVid: constants.DefaultVID,
+			MaxVid: constants.MaxVID,
+		})
+		if err != nil {
+			return fmt.Errorf("add vlan group for sdn zone %s: %s", zone.Zone, err)
+		}
+		zoneVlanGroups[zone.Zone] = vlanGroup
+	}
+
+	for _, vnet := range ps.SDNVnets {
+		vlanName := vnet.Vnet
+		if vnet.Alias != "" {
+			vlanName = vnet.Alias
+		}
+		vlanTenant, err := common.MatchVlanToTenant(ps.Ctx, nbi, vlanName, ps.VlanTenantRelations)
+		if err != nil {
+			return fmt.Errorf("match vlan to tenant: %s", err)
+		}
+		var nbVlan *objects.Vlan
+		vlanGroup, ok := zoneVlanGroups[vnet.Zone]
+		if ok && vnet.Tag >= constants.DefaultVID && vnet.Tag <= constants.MaxVID {
+			nbVlan, err = nbi.AddVlan(ps.Ctx, &objects.Vlan{
+				NetboxObject: objects.NetboxObject{
+					Tags:        ps.SourceTags,
+					Description: fmt.Sprintf("Proxmox SDN vnet %s", vnet.Vnet),
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ps.SourceConfig.Name,
+					},
+				},
+				Name:   vlanName,
+				Vid:    vnet.Tag,
+				Group:  vlanGroup,
+				Status: &objects.VlanStatusActive,
+				Tenant: vlanTenant,
+			})
+			if err != nil {
+				return fmt.Errorf("add vlan for sdn vnet %s: %s", vnet.Vnet, err)
+			}
+		} else {
+			ps.Logger.Debugf(ps.Ctx, "sdn vnet %s with tag %d can't be synced as vlan", vnet.Vnet, vnet.Tag)
+		}
+
+		for _, subnet := range ps.SDNSubnets[vnet.Vnet] {
+			prefix := sdnSubnetCIDR(subnet)
+			if prefix == "" || utils.SubnetsContainIPAddress(strings.Split(prefix, "/")[0], ps.SourceConfig.IgnoredSubnets) {
+				continue
+			}
+			var comments string
+			if subnet.Gateway != "" {
+				comments = fmt.Sprintf("Gateway: %s", subnet.Gateway)
+			}
+			_, err = nbi.AddPrefix(ps.Ctx, &objects.Prefix{
+				NetboxObject: objects.NetboxObject{
+					Tags: ps.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ps.SourceConfig.Name,
+					},
+				},
+				Prefix:   prefix,
+				Status:   &objects.PrefixStatusActive,
+				Site:     ps.NetboxCluster.Site,
+				Vlan:     nbVlan,
+				Tenant:   vlanTenant,
+				Comments: comments,
+			})
+			if err != nil {
+				return fmt.Errorf("add prefix for sdn subnet %s: %s", subnet.Subnet, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sdnSubnetCIDR returns cidr of the SDN subnet. If cidr is not returned by the api,
+// it is extracted from the subnet id, which has format "zone-10.0.0.0-24".
+func sdnSubnetCIDR(subnet *SDNSubnet) string {
+	if subnet.CIDR != "" {
+		return subnet.CIDR
+	}
+	parts := strings.Split(subnet.Subnet, "-")
+	if len(parts) < 3 { //nolint:gomnd
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", parts[len(parts)-2], parts[len(parts)-1])
+}
+
 // Function that synces proxmox vms to the netbox inventory.
 func (ps *ProxmoxSource) syncVMs(nbi *inventory.NetboxInventory) error {
 	for nodeName, vms := range ps.Vms {
@@ -175,9 +464,13 @@ func (ps *ProxmoxSource) syncVMs(nbi *inventory.NetboxInventory) error {
 			if err != nil {
 				return fmt.Errorf("match vm to tenant: %s", err)
 			}
+			vmTags, err := ps.syncVMTags(nbi, vm.Tags)
+			if err != nil {
+				return fmt.Errorf("sync vm tags: %s", err)
+			}
 			nbVM, err := nbi.AddVM(ps.Ctx, &objects.VM{
 				NetboxObject: objects.NetboxObject{
-					Tags: ps.SourceTags,
+					Tags: vmTags,
 					CustomFields: map[string]interface{}{
 						constants.CustomFieldSourceName:   ps.SourceConfig.Name,
 						constants.CustomFieldSourceIDName: fmt.Sprintf("%d", vm.VMID),
@@ -290,6 +583,45 @@ func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *ob
 	return nil
 }
 
+// syncVMTags syncs proxmox tags of the vm (or container) as netbox tags. Tags are
+// prefixed with vmTagPrefix and filtered with vmTagAllowlist from the source config.
+// It returns source tags extended with synced vm tags.
+func (ps *ProxmoxSource) syncVMTags(nbi *inventory.NetboxInventory, pveTags string) ([]*objects.Tag, error) {
+	tags := make([]*objects.Tag, 0, len(ps.SourceTags))
+	tags = append(tags, ps.SourceTags...)
+	for _, pveTag := range filterVMTags(pveTags, ps.SourceConfig.VMTagAllowlist) {
+		tagName := ps.SourceConfig.VMTagPrefix + pveTag
+		nbTag, err := nbi.AddTag(ps.Ctx, &objects.Tag{
+			Name:        tagName,
+			Slug:        utils.Slugify(tagName),
+			Color:       constants.DefaultVMTagColor,
+			Description: fmt.Sprintf("Tag %s synced from proxmox", pveTag),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add tag %s: %s", tagName, err)
+		}
+		tags = append(tags, nbTag)
+	}
+	return tags, nil
+}
+
+// filterVMTags splits proxmox tags string (e.g. "prod;web") into tags, and
+// returns only tags from the allowlist. Empty allowlist allows all tags.
+func filterVMTags(pveTags string, allowlist []string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.FieldsFunc(pveTags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	}) {
+		if len(allowlist) > 0 && !slices.Contains(allowlist, tag) {
+			continue
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // Function that synces proxmox containers to the netbox inventory.
 func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 	if len(ps.Containers) > 0 {
@@ -316,9 +648,13 @@ func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 				if err != nil {
 					return fmt.Errorf("match vm to tenant: %s", err)
 				}
-				_, err = nbi.AddVM(ps.Ctx, &objects.VM{
+				containerTags, err := ps.syncVMTags(nbi, container.Tags)
+				if err != nil {
+					return fmt.Errorf("sync container tags: %s", err)
+				}
+				nbContainer, err := nbi.AddVM(ps.Ctx, &objects.VM{
 					NetboxObject: objects.NetboxObject{
-						Tags: ps.SourceTags,
+						Tags: containerTags,
 						CustomFields: map[string]interface{}{
 							constants.CustomFieldSourceName:   ps.SourceConfig.Name,
 							constants.CustomFieldSourceIDName: fmt.Sprintf("%d", container.VMID),
@@ -339,11 +675,140 @@ func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 					return fmt.Errorf("new vm: %s", err)
 				}
 
-				// err = ps.syncContainerNetworks(nbi, nbContainer)
-				// if err != nil {
-				// 	return fmt.Errorf("sync container networks: %s", err)
-				// }
+				err = ps.syncContainerNetworks(nbi, nbContainer)
+				if err != nil {
+					return fmt.Errorf("sync container networks: %s", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// syncContainerNetworks syncs network interfaces of the container as vm interfaces.
+// Interfaces with vlan tag are synced in access mode with the tagged vlan as untagged vlan.
+func (ps *ProxmoxSource) syncContainerNetworks(nbi *inventory.NetboxInventory, nbContainer *objects.VM) error {
+	containerIPv4Addresses := make([]*objects.IPAddress, 0)
+	containerIPv6Addresses := make([]*objects.IPAddress, 0)
+	for _, containerNetwork := range ps.ContainerNetworks[nbContainer.Name] {
+		if utils.FilterInterfaceName(containerNetwork.Name, ps.SourceConfig.InterfaceFilter) {
+			ps.Logger.Debugf(ps.Ctx, "interface %s is filtered out with interface filter %s", containerNetwork.Name, ps.SourceConfig.InterfaceFilter)
+			continue
+		}
+		var ifaceMode *objects.VMInterfaceMode
+		var ifaceVlan *objects.Vlan
+		if containerNetwork.Tag > 0 {
+			vlanName := fmt.Sprintf("Vlan%d", containerNetwork.Tag)
+			vlanGroup, err := common.MatchVlanToGroup(ps.Ctx, nbi, vlanName, ps.VlanGroupRelations)
+			if err != nil {
+				return fmt.Errorf("match vlan to group: %s", err)
+			}
+			vlanTenant, err := common.MatchVlanToTenant(ps.Ctx, nbi, vlanName, ps.VlanTenantRelations)
+			if err != nil {
+				return fmt.Errorf("match vlan to tenant: %s", err)
+			}
+			ifaceVlan, err = nbi.AddVlan(ps.Ctx, &objects.Vlan{
+				NetboxObject: objects.NetboxObject{
+					Tags: ps.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName: ps.SourceConfig.Name,
+					},
+				},
+				Name:   vlanName,
+				Vid:    containerNetwork.Tag,
+				Status: &objects.VlanStatusActive,
+				Tenant: vlanTenant,
+				Group:  vlanGroup,
+			})
+			if err != nil {
+				return fmt.Errorf("add vlan: %s", err)
 			}
+			ifaceMode = &objects.VMInterfaceModeAccess
+		}
+		var ifaceDescription string
+		if containerNetwork.Bridge != "" {
+			ifaceDescription = fmt.Sprintf("Bridge: %s", containerNetwork.Bridge)
+		}
+		nbContainerIface, err := nbi.AddVMInterface(ps.Ctx, &objects.VMInterface{
+			NetboxObject: objects.NetboxObject{
+				Tags:        ps.SourceTags,
+				Description: ifaceDescription,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName: ps.SourceConfig.Name,
+				},
+			},
+			Name:         containerNetwork.Name,
+			MACAddress:   strings.ToUpper(containerNetwork.HWAddr),
+			VM:           nbContainer,
+			Mode:         ifaceMode,
+			UntaggedVlan: ifaceVlan,
+		})
+		if err != nil {
+			return fmt.Errorf("add container interface: %s", err)
+		}
+
+		for _, ipAddress := range containerNetwork.IPs {
+			ipWithoutMask := strings.Split(ipAddress, "/")[0]
+			if utils.SubnetsContainIPAddress(ipWithoutMask, ps.SourceConfig.IgnoredSubnets) {
+				continue
+			}
+			nbIPAddress, err := nbi.AddIPAddress(ps.Ctx, &objects.IPAddress{
+				NetboxObject: objects.NetboxObject{
+					Tags: ps.SourceTags,
+					CustomFields: map[string]interface{}{
+						constants.CustomFieldSourceName:   ps.SourceConfig.Name,
+						constants.CustomFieldArpEntryName: false,
+					},
+				},
+				Address:            ipAddress,
+				DNSName:            utils.ReverseLookup(ipWithoutMask),
+				Tenant:             nbContainer.Tenant,
+				AssignedObjectType: objects.AssignedObjectTypeVMInterface,
+				AssignedObjectID:   nbContainerIface.ID,
+				Status:             &objects.IPAddressStatusActive,
+			})
+			if err != nil {
+				return fmt.Errorf("add ip address: %s", err)
+			}
+			switch utils.GetIPVersion(ipWithoutMask) {
+			case constants.IPv4:
+				containerIPv4Addresses = append(containerIPv4Addresses, nbIPAddress)
+			case constants.IPv6:
+				containerIPv6Addresses = append(containerIPv6Addresses, nbIPAddress)
+			default:
+				ps.Logger.Warningf(ps.Ctx, "wrong IP address: %s", ipAddress)
+			}
+			prefix, err := utils.ExtractPrefixFromIPAddress(nbIPAddress.Address)
+			if err != nil {
+				ps.Logger.Warningf(ps.Ctx, "extract prefix from ip address: %s", err)
+				continue
+			}
+			var prefixTenant *objects.Tenant
+			if ifaceVlan != nil {
+				prefixTenant = ifaceVlan.Tenant
+			}
+			_, err = nbi.AddPrefix(ps.Ctx, &objects.Prefix{
+				Prefix: prefix,
+				Tenant: prefixTenant,
+				Vlan:   ifaceVlan,
+			})
+			if err != nil {
+				ps.Logger.Errorf(ps.Ctx, "adding prefix: %s", err)
+			}
+		}
+	}
+	// From all IPv4 addresses and IPv6 addresses determine primary ips
+	if len(containerIPv4Addresses) > 0 || len(containerIPv6Addresses) > 0 {
+		nbContainerCopy := *nbContainer
+		if len(containerIPv4Addresses) > 0 {
+			nbContainerCopy.PrimaryIPv4 = containerIPv4Addresses[0]
+		}
+		if len(containerIPv6Addresses) > 0 {
+			nbContainerCopy.PrimaryIPv6 = containerIPv6Addresses[0]
+		}
+		_, err := nbi.AddVM(ps.Ctx, &nbContainerCopy)
+		if err != nil {
+			return fmt.Errorf("updating container primary ip: %s", err)
 		}
 	}
 	return nil
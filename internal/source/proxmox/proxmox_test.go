@@ -1 +1,240 @@
 package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/logger"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/luthermonson/go-proxmox"
+)
+
+func TestParseContainerNetConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		netConfig string
+		want      *ContainerNetwork
+	}{
+		{
+			name:      "Interface with vlan tag and static ips",
+			netConfig: "name=eth0,bridge=vmbr0,firewall=1,gw=10.0.0.1,hwaddr=BC:24:11:AA:BB:CC,ip=10.0.0.5/24,ip6=2001:db8::5/64,tag=20,type=veth",
+			want: &ContainerNetwork{
+				Name:   "eth0",
+				Bridge: "vmbr0",
+				Tag:    20,
+				HWAddr: "BC:24:11:AA:BB:CC",
+				IPs:    []string{"10.0.0.5/24", "2001:db8::5/64"},
+			},
+		},
+		{
+			name:      "Interface with dynamic ips",
+			netConfig: "name=eth1,bridge=vmbr1,hwaddr=BC:24:11:AA:BB:CD,ip=dhcp,ip6=auto,type=veth",
+			want: &ContainerNetwork{
+				Name:   "eth1",
+				Bridge: "vmbr1",
+				HWAddr: "BC:24:11:AA:BB:CD",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseContainerNetConfig(tt.netConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseContainerNetConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVlanRawDevice(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodeNetwork *proxmox.NodeNetwork
+		want        string
+	}{
+		{
+			name:        "Vlan with raw device",
+			nodeNetwork: &proxmox.NodeNetwork{Iface: "vlan10", Type: "vlan", VLANRawDevice: "bond0"},
+			want:        "bond0",
+		},
+		{
+			name:        "Vlan with parent in name",
+			nodeNetwork: &proxmox.NodeNetwork{Iface: "eno1.20", Type: "vlan"},
+			want:        "eno1",
+		},
+		{
+			name:        "Bridge interface",
+			nodeNetwork: &proxmox.NodeNetwork{Iface: "vmbr0", Type: "bridge"},
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vlanRawDevice(tt.nodeNetwork); got != tt.want {
+				t.Errorf("vlanRawDevice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandaloneClusterName(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []*proxmox.Node
+		want  string
+	}{
+		{
+			name:  "Standalone node",
+			nodes: []*proxmox.Node{{Name: "pve1"}},
+			want:  "pve1 (standalone)",
+		},
+		{
+			name:  "No nodes",
+			nodes: []*proxmox.Node{},
+			want:  "ProxmoxStandalone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := standaloneClusterName(tt.nodes); got != tt.want {
+				t.Errorf("standaloneClusterName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxmoxSource_connect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api2/json/version" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"version": "8.1.4"}}`)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testLogger, err := logger.New("", logger.ERROR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		hostname          string
+		failoverHostnames []string
+		wantErr           bool
+	}{
+		{
+			name:     "Configured host is reachable",
+			hostname: serverURL.Hostname(),
+		},
+		{
+			name:              "Failover to reachable host",
+			hostname:          "127.0.0.2",
+			failoverHostnames: []string{"127.0.0.3", serverURL.Hostname()},
+		},
+		{
+			name:              "No host is reachable",
+			hostname:          "127.0.0.2",
+			failoverHostnames: []string{"127.0.0.3"},
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &ProxmoxSource{
+				Config: common.Config{
+					Logger: testLogger,
+					SourceConfig: &parser.SourceConfig{
+						HTTPScheme:        parser.HTTP,
+						Hostname:          tt.hostname,
+						FailoverHostnames: tt.failoverHostnames,
+						Port:              port,
+					},
+					Ctx: context.Background(),
+				},
+			}
+			_, err := ps.connect(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProxmoxSource.connect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterVMTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		pveTags   string
+		allowlist []string
+		want      []string
+	}{
+		{
+			name:    "All tags without allowlist",
+			pveTags: "prod;web;prod",
+			want:    []string{"prod", "web"},
+		},
+		{
+			name:      "Only allowed tags",
+			pveTags:   "prod;web;owner-team1",
+			allowlist: []string{"prod", "owner-team1"},
+			want:      []string{"prod", "owner-team1"},
+		},
+		{
+			name:    "No tags",
+			pveTags: "",
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterVMTags(tt.pveTags, tt.allowlist); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterVMTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSdnSubnetCIDR(t *testing.T) {
+	tests := []struct {
+		name   string
+		subnet *SDNSubnet
+		want   string
+	}{
+		{
+			name:   "Subnet with cidr",
+			subnet: &SDNSubnet{Subnet: "zone1-10.0.0.0-24", CIDR: "10.0.0.0/24"},
+			want:   "10.0.0.0/24",
+		},
+		{
+			name:   "Cidr from subnet id",
+			subnet: &SDNSubnet{Subnet: "zone1-172.16.0.0-16"},
+			want:   "172.16.0.0/16",
+		},
+		{
+			name:   "Invalid subnet id",
+			subnet: &SDNSubnet{Subnet: "zone1"},
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sdnSubnetCIDR(tt.subnet); got != tt.want {
+				t.Errorf("sdnSubnetCIDR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/bl4ko/netbox-ssot/internal/source/dnac"
 	"github.com/bl4ko/netbox-ssot/internal/source/fmc"
 	"github.com/bl4ko/netbox-ssot/internal/source/fortigate"
+	"github.com/bl4ko/netbox-ssot/internal/source/fortimanager"
 	"github.com/bl4ko/netbox-ssot/internal/source/ovirt"
 	"github.com/bl4ko/netbox-ssot/internal/source/paloalto"
+	"github.com/bl4ko/netbox-ssot/internal/source/panorama"
 	"github.com/bl4ko/netbox-ssot/internal/source/proxmox"
 	"github.com/bl4ko/netbox-ssot/internal/source/vmware"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
@@ -64,6 +66,10 @@ func NewSource(ctx context.Context, config *parser.SourceConfig, logger *logger.
 		return &fortigate.FortigateSource{Config: commonConfig}, nil
 	case constants.FMC:
 		return &fmc.FMCSource{Config: commonConfig}, nil
+	case constants.Panorama:
+		return &panorama.PanoramaSource{Config: commonConfig}, nil
+	case constants.FortiManager:
+		return &fortimanager.FortiManagerSource{Config: commonConfig}, nil
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", config.Type)
 	}
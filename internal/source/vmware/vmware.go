@@ -13,6 +13,9 @@ import (
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -30,6 +33,8 @@ type VmwareSource struct {
 	Hosts       map[string]mo.HostSystem
 	Vms         map[string]mo.VirtualMachine
 	Networks    NetworkData
+	// Items of content libraries, collected only if syncContentLibrary is enabled
+	ContentLibraryItems []ContentLibraryItem
 
 	// Relations between objects "object_id": "object_id"
 	Cluster2Datacenter map[string]string // ClusterKey -> DatacenterKey
@@ -39,6 +44,14 @@ type VmwareSource struct {
 	// CustomField2Name is a map of custom field ids to their names
 	CustomFieldID2Name map[int32]string
 
+	// Object2Tags is a map of vsphere tags attached to clusters, hosts and vms
+	Object2Tags map[string][]VsphereTag // ObjectKey -> VsphereTags
+
+	// Folders, datacenters, compute resources and resource pools, used for
+	// determining placement of vms in vsphere inventory
+	InventoryEntities map[string]mo.ManagedEntity // EntityKey -> ManagedEntity
+	VM2Placement      map[string]VMPlacement      // VmKey -> VMPlacement
+
 	// Netbox relations
 	ClusterSiteRelations   map[string]string
 	ClusterTenantRelations map[string]string
@@ -47,11 +60,71 @@ type VmwareSource struct {
 	VMTenantRelations      map[string]string
 	VlanGroupRelations     map[string]string
 	VlanTenantRelations    map[string]string
+	TagSiteRelations       map[string]string
+	TagTenantRelations     map[string]string
+	TagRoleRelations       map[string]string
+
+	// VMPlacementTenantRelations are vmTenantRelations matching vm's placement
+	// attributes (e.g. "folder:/DC1/vm/Finance/.* = Finance")
+	VMPlacementTenantRelations map[string]string
 
 	// Mappings of custom fields to contacts
 	CustomFieldMappings map[string]string
 }
 
+// VsphereTag represents tag from vsphere tagging service, together with its category.
+type VsphereTag struct {
+	Category string
+	Name     string
+}
+
+// String returns tag in format "category/tag", which is used in tag relations.
+func (t VsphereTag) String() string {
+	return fmt.Sprintf("%s/%s", t.Category, t.Name)
+}
+
+// ContentLibraryItem represents an item (e.g. ovf or vm template) of a content library.
+type ContentLibraryItem struct {
+	Library     string
+	Name        string
+	Type        string
+	Description string
+}
+
+// Prefixes of vm's placement attributes, that can be used in vm relations.
+const (
+	placementFolderPrefix       = "folder:"
+	placementResourcePoolPrefix = "resourcePool:"
+	placementVAppPrefix         = "vapp:"
+)
+
+// VMPlacement represents location of the vm in vsphere inventory.
+type VMPlacement struct {
+	Folder       string // Inventory path of vm's folder (e.g. /DC1/vm/Finance)
+	ResourcePool string // Inventory path of vm's resource pool (e.g. /DC1/host/Cluster1/Resources/Pool1)
+	VApp         string // Name of the vApp, that the vm is part of
+}
+
+// Attributes returns vm's placement in format "attribute:value", which is matched
+// against vm relations. Folder attribute contains the full inventory path of the vm
+// (e.g. "folder:/DC1/vm/Finance/vm1").
+func (p VMPlacement) Attributes(vmName string) []string {
+	var attributes []string
+	if p.Folder != "" {
+		attributes = append(attributes, fmt.Sprintf("%s%s/%s", placementFolderPrefix, p.Folder, vmName))
+	}
+	if p.ResourcePool != "" {
+		attributes = append(attributes, placementResourcePoolPrefix+p.ResourcePool)
+	}
+	if p.VApp != "" {
+		attributes = append(attributes, placementVAppPrefix+p.VApp)
+	}
+	return attributes
+}
+
+// placementAttributePrefixes are prefixes of vm's placement attributes, that can be used in relations.
+var placementAttributePrefixes = []string{placementFolderPrefix, placementResourcePoolPrefix, placementVAppPrefix}
+
 type NetworkData struct {
 	DistributedVirtualPortgroups map[string]*DistributedPortgroupData         // Portgroup.key -> PortgroupData
 	Vid2Name                     map[int]string                               // Helper map, for quickly obtaining name of the vid
@@ -97,33 +170,30 @@ func (vc *VmwareSource) Init() error {
 	vc.HostTenantRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.HostTenantRelations)
 	vc.Logger.Debug(vc.Ctx, "HostTenantRelations: ", vc.HostTenantRelations)
 	vc.VMTenantRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.VMTenantRelations)
+	vc.VMPlacementTenantRelations = common.SplitAttributeRelations(vc.VMTenantRelations, placementAttributePrefixes)
 	vc.Logger.Debug(vc.Ctx, "VmTenantRelations: ", vc.VMTenantRelations)
+	vc.Logger.Debug(vc.Ctx, "VMPlacementTenantRelations: ", vc.VMPlacementTenantRelations)
 	vc.VlanGroupRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.VlanGroupRelations)
 	vc.Logger.Debug(vc.Ctx, "VlanGroupRelations: ", vc.VlanGroupRelations)
 	vc.VlanTenantRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.VlanTenantRelations)
 	vc.Logger.Debug(vc.Ctx, "VlanTenantRelations: ", vc.VlanTenantRelations)
+	vc.TagSiteRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.TagSiteRelations)
+	vc.Logger.Debug(vc.Ctx, "TagSiteRelations: ", vc.TagSiteRelations)
+	vc.TagTenantRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.TagTenantRelations)
+	vc.Logger.Debug(vc.Ctx, "TagTenantRelations: ", vc.TagTenantRelations)
+	vc.TagRoleRelations = utils.ConvertStringsToRegexPairs(vc.SourceConfig.TagRoleRelations)
+	vc.Logger.Debug(vc.Ctx, "TagRoleRelations: ", vc.TagRoleRelations)
 	vc.CustomFieldMappings = utils.ConvertStringsToPairs(vc.SourceConfig.CustomFieldMappings)
 	vc.Logger.Debug(vc.Ctx, "CustomFieldMappings: ", vc.CustomFieldMappings)
 
 	// Initialize the connection
-	vc.Logger.Debug(vc.Ctx, "Initializing oVirt source ", vc.SourceConfig.Name)
+	vc.Logger.Debug(vc.Ctx, "Initializing vmware source ", vc.SourceConfig.Name)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Correctly handle backslashes in username and password
-	escapedUsername := url.PathEscape(vc.SourceConfig.Username)
-	escapedPassword := url.PathEscape(vc.SourceConfig.Password)
-
-	vcURL := fmt.Sprintf("%s://%s:%s@%s:%d/sdk", vc.SourceConfig.HTTPScheme, escapedUsername, escapedPassword, vc.SourceConfig.Hostname, vc.SourceConfig.Port)
-
-	url, err := url.Parse(vcURL)
-	if err != nil {
-		return fmt.Errorf("failed parsing url for %s with error %s", vc.SourceConfig.Hostname, err)
-	}
-
-	conn, err := govmomi.NewClient(ctx, url, !vc.SourceConfig.ValidateCert)
+	conn, err := vc.connect(ctx)
 	if err != nil {
-		return fmt.Errorf("failed creating a govmomi client with an error: %s", err)
+		return err
 	}
 
 	// View manager is used to create and manage views. Views are a mechanism in vSphere
@@ -134,6 +204,7 @@ func (vc *VmwareSource) Init() error {
 	// Each string in this slice represents a different vSphere Managed Object type.
 	viewType := []string{
 		"Datastore", "Datacenter", "ClusterComputeResource", "HostSystem", "VirtualMachine", "Network",
+		"Folder", "ComputeResource", "ResourcePool",
 	}
 
 	// A container view is a subset of the vSphere inventory, focusing on the specified
@@ -179,6 +250,17 @@ func (vc *VmwareSource) Init() error {
 		vc.Logger.Infof(vc.Ctx, "Successfully initialized %s in %f seconds", utils.ExtractFunctionName(initFunc), duration.Seconds())
 	}
 
+	// Tags and content libraries are collected from vAPI, which is separate from the vim25 api.
+	// Standalone esxi hosts don't provide it, so failure there is not fatal. On vcenter it is,
+	// because syncing without tags would strip tags, custom fields and relations derived from them.
+	err = vc.initVapi(ctx, conn.Client, url.UserPassword(vc.SourceConfig.Username, vc.SourceConfig.Password))
+	if err != nil {
+		if conn.Client.IsVC() {
+			return fmt.Errorf("vmware initialization failure: collecting data from vapi: %s", err)
+		}
+		vc.Logger.Warningf(vc.Ctx, "failed collecting data from vapi: %s", err)
+	}
+
 	// Ensure the containerView is destroyed after we are done with it
 	err = containerView.Destroy(ctx)
 	if err != nil {
@@ -204,6 +286,9 @@ func (vc *VmwareSource) Sync(nbi *inventory.NetboxInventory) error {
 		vc.syncHosts,
 		vc.syncVms,
 	}
+	if vc.SourceConfig.SyncContentLibrary {
+		syncFunctions = append(syncFunctions, vc.syncContentLibrary)
+	}
 	for _, syncFunc := range syncFunctions {
 		startTime := time.Now()
 		err := syncFunc(nbi)
@@ -216,8 +301,60 @@ func (vc *VmwareSource) Sync(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// connect creates new govmomi client connected to the vsphere source.
+func (vc *VmwareSource) connect(ctx context.Context) (*govmomi.Client, error) {
+	// Correctly handle backslashes in username and password
+	escapedUsername := url.PathEscape(vc.SourceConfig.Username)
+	escapedPassword := url.PathEscape(vc.SourceConfig.Password)
+
+	vcURL := fmt.Sprintf("%s://%s:%s@%s:%d/sdk", vc.SourceConfig.HTTPScheme, escapedUsername, escapedPassword, vc.SourceConfig.Hostname, vc.SourceConfig.Port)
+
+	url, err := url.Parse(vcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing url for %s with error %s", vc.SourceConfig.Hostname, err)
+	}
+
+	conn, err := govmomi.NewClient(ctx, url, !vc.SourceConfig.ValidateCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating a govmomi client with an error: %s", err)
+	}
+	return conn, nil
+}
+
+// initVapi logs into the vAPI endpoint and collects tags attached to clusters, hosts and vms.
+// If syncContentLibrary is enabled, it also collects items of content libraries.
+func (vc *VmwareSource) initVapi(ctx context.Context, client *vim25.Client, user *url.Userinfo) error {
+	restClient := rest.NewClient(client)
+	err := restClient.Login(ctx, user)
+	if err != nil {
+		return fmt.Errorf("vapi login: %s", err)
+	}
+	defer func() {
+		if err := restClient.Logout(ctx); err != nil {
+			vc.Logger.Warningf(vc.Ctx, "vapi logout: %s", err)
+		}
+	}()
+	startTime := time.Now()
+	err = vc.InitTags(ctx, tags.NewManager(restClient))
+	if err != nil {
+		return err
+	}
+	vc.Logger.Infof(vc.Ctx, "Successfully initialized %s in %f seconds", utils.ExtractFunctionName(vc.InitTags), time.Since(startTime).Seconds())
+
+	if vc.SourceConfig.SyncContentLibrary {
+		startTime = time.Now()
+		err = vc.InitContentLibrary(ctx, library.NewManager(restClient))
+		if err != nil {
+			return err
+		}
+		vc.Logger.Infof(vc.Ctx, "Successfully initialized %s in %f seconds", utils.ExtractFunctionName(vc.InitContentLibrary), time.Since(startTime).Seconds())
+	}
+	return nil
+}
+
 // Currently we have to traverse the vsphere tree to get datacenter to cluster relation
 // For other objects relations are available in with containerView.
+// Compute resources of standalone hosts are included, because they are synced as clusters.
 func (vc *VmwareSource) CreateClusterDataCenterRelation(ctx context.Context, client *vim25.Client) error {
 	finder := find.NewFinder(client, true)
 	datacenters, err := finder.DatacenterList(ctx, "*")
@@ -227,7 +364,7 @@ func (vc *VmwareSource) CreateClusterDataCenterRelation(ctx context.Context, cli
 	vc.Cluster2Datacenter = make(map[string]string)
 	for _, dc := range datacenters {
 		finder.SetDatacenter(dc)
-		clusters, err := finder.ClusterComputeResourceList(ctx, "*")
+		clusters, err := finder.ComputeResourceList(ctx, "*")
 		if err != nil {
 			return fmt.Errorf("finder failed finding clusters for datacenter: %s", err)
 		}
@@ -0,0 +1,308 @@
+package vmware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Properties watched for changes. They are limited to properties, that are synced to
+// netbox, because others (e.g. quick stats, heartbeat and sensor readings) change constantly.
+// Changed objects are then retrieved with all properties collected in Init.
+var (
+	hostWatchProperties = []string{"name", "summary.hardware", "summary.runtime.connectionState", "summary.config.product", "vm", "config.network"}
+	vmWatchProperties   = []string{
+		"name", "summary.customValue", "runtime.host", "runtime.powerState", "guest.guestFullName", "guest.net", "guest.ipStack",
+		"config.hardware", "config.guestFullName", "config.template", "parent", "resourcePool", "parentVApp",
+	}
+)
+
+// objectChanges holds references of vsphere objects, that changed since the last update.
+type objectChanges struct {
+	Portgroups []types.ManagedObjectReference
+	Hosts      []types.ManagedObjectReference
+	Vms        []types.ManagedObjectReference
+	Removed    []types.ManagedObjectReference
+}
+
+func (c *objectChanges) empty() bool {
+	return len(c.Portgroups) == 0 && len(c.Hosts) == 0 && len(c.Vms) == 0 && len(c.Removed) == 0
+}
+
+// collectChanges groups object updates received from the property collector by object type.
+func collectChanges(updates []types.ObjectUpdate) *objectChanges {
+	changes := &objectChanges{}
+	for _, update := range updates {
+		if update.Kind == types.ObjectUpdateKindLeave {
+			changes.Removed = append(changes.Removed, update.Obj)
+			continue
+		}
+		switch update.Obj.Type {
+		case "DistributedVirtualPortgroup":
+			if !slices.Contains(changes.Portgroups, update.Obj) {
+				changes.Portgroups = append(changes.Portgroups, update.Obj)
+			}
+		case "HostSystem":
+			if !slices.Contains(changes.Hosts, update.Obj) {
+				changes.Hosts = append(changes.Hosts, update.Obj)
+			}
+		case "VirtualMachine":
+			if !slices.Contains(changes.Vms, update.Obj) {
+				changes.Vms = append(changes.Vms, update.Obj)
+			}
+		}
+	}
+	return changes
+}
+
+// SyncUpdates keeps a session to vsphere open and syncs only distributed portgroups,
+// hosts and vms, that changed since the last sync. It returns when ctx is done, or
+// when watching for updates fails.
+//
+// SyncUpdates relies on data collected by Init and synced by Sync, so it must be
+// called after the full sync.
+func (vc *VmwareSource) SyncUpdates(ctx context.Context, nbi *inventory.NetboxInventory) error {
+	conn, err := vc.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Session is closed with background context, because ctx is already done
+		err := conn.Logout(context.Background())
+		if err != nil {
+			vc.Logger.Warningf(vc.Ctx, "error occurred when ending vmware connection to host %s: %s", vc.SourceConfig.Hostname, err)
+		}
+	}()
+	vc.Logger.Info(vc.Ctx, "Watching for updates on vmware source ", vc.SourceConfig.Hostname)
+	return vc.watchUpdates(ctx, conn.Client, func(changes *objectChanges) error {
+		return vc.applyChanges(ctx, conn.Client, nbi, changes)
+	})
+}
+
+// watchUpdates creates property collector filter on distributed portgroups, hosts and
+// vms, and calls onChanges for each batch of changes until ctx is done. The first batch
+// contains current state of all objects, so only objects that changed between the full
+// sync and the start of the watch are kept from it (see unchangedSinceSync).
+func (vc *VmwareSource) watchUpdates(ctx context.Context, client *vim25.Client, onChanges func(*objectChanges) error) error {
+	viewManager := view.NewManager(client)
+	containerView, err := viewManager.CreateContainerView(ctx, client.ServiceContent.RootFolder, []string{"DistributedVirtualPortgroup", "HostSystem", "VirtualMachine"}, true)
+	if err != nil {
+		return fmt.Errorf("failed creating containerView: %s", err)
+	}
+	defer func() {
+		err := containerView.Destroy(context.Background())
+		if err != nil {
+			vc.Logger.Errorf(vc.Ctx, "failed destroying containerView: %s", err)
+		}
+	}()
+
+	// Separate property collector is used, because WaitForUpdatesEx blocks
+	// the collector until it returns.
+	propertyCollector, err := property.DefaultCollector(client).Create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating property collector: %s", err)
+	}
+	defer func() {
+		err := propertyCollector.Destroy(context.Background())
+		if err != nil {
+			vc.Logger.Errorf(vc.Ctx, "failed destroying property collector: %s", err)
+		}
+	}()
+
+	filter := new(property.WaitFilter).Add(containerView.Reference(), "DistributedVirtualPortgroup", dvpgProperties, containerView.TraversalSpec())
+	filter.Spec.PropSet = append(filter.Spec.PropSet,
+		types.PropertySpec{Type: "HostSystem", PathSet: hostWatchProperties},
+		types.PropertySpec{Type: "VirtualMachine", PathSet: vmWatchProperties},
+	)
+
+	var onChangesErr error
+	firstBatch := true
+	err = property.WaitForUpdatesEx(ctx, propertyCollector, filter, func(updates []types.ObjectUpdate) bool {
+		if firstBatch {
+			firstBatch = false
+			changedUpdates := make([]types.ObjectUpdate, 0, len(updates))
+			for _, update := range updates {
+				if !vc.unchangedSinceSync(update) {
+					changedUpdates = append(changedUpdates, update)
+				}
+			}
+			vc.Logger.Debugf(vc.Ctx, "%d of %d objects changed since the full sync", len(changedUpdates), len(updates))
+			updates = changedUpdates
+		}
+		changes := collectChanges(updates)
+		if changes.empty() {
+			return false
+		}
+		onChangesErr = onChanges(changes)
+		return onChangesErr != nil
+	})
+	if onChangesErr != nil {
+		return onChangesErr
+	}
+	// Context being done is expected way of stopping the watch
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// unchangedSinceSync returns true, if watched properties of the object in the update
+// are the same as the ones collected in Init, so the object was already synced by the
+// full sync.
+func (vc *VmwareSource) unchangedSinceSync(update types.ObjectUpdate) bool {
+	if update.Kind != types.ObjectUpdateKindEnter {
+		return false
+	}
+	switch update.Obj.Type {
+	case "DistributedVirtualPortgroup":
+		dvpg := mo.DistributedVirtualPortgroup{}
+		dvpg.Self = update.Obj
+		mo.ApplyPropertyChange(&dvpg, update.ChangeSet)
+		dvpgData, err := distributedPortgroupData(dvpg)
+		if err != nil || dvpgData == nil {
+			return false
+		}
+		syncedData, ok := vc.Networks.DistributedVirtualPortgroups[dvpg.Config.Key]
+		return ok && syncedData.Name == dvpgData.Name && syncedData.Private == dvpgData.Private &&
+			slices.Equal(syncedData.VlanIDs, dvpgData.VlanIDs) && slices.Equal(syncedData.VlanIDRanges, dvpgData.VlanIDRanges)
+	case "HostSystem":
+		syncedHost, ok := vc.Hosts[update.Obj.Value]
+		if !ok {
+			return false
+		}
+		host := mo.HostSystem{}
+		host.Self = update.Obj
+		mo.ApplyPropertyChange(&host, update.ChangeSet)
+		return reflect.DeepEqual(hostWatchedState(host), hostWatchedState(syncedHost))
+	case "VirtualMachine":
+		syncedVM, ok := vc.Vms[update.Obj.Value]
+		if !ok {
+			return false
+		}
+		vm := mo.VirtualMachine{}
+		vm.Self = update.Obj
+		mo.ApplyPropertyChange(&vm, update.ChangeSet)
+		return reflect.DeepEqual(vmWatchedState(vm), vmWatchedState(syncedVM))
+	}
+	return false
+}
+
+// hostWatchedState returns copy of the host with only hostWatchProperties set.
+func hostWatchedState(host mo.HostSystem) mo.HostSystem {
+	watched := mo.HostSystem{}
+	watched.Self = host.Self
+	watched.Name = host.Name
+	watched.Summary.Hardware = host.Summary.Hardware
+	if host.Summary.Runtime != nil {
+		watched.Summary.Runtime = &types.HostRuntimeInfo{ConnectionState: host.Summary.Runtime.ConnectionState}
+	}
+	watched.Summary.Config.Product = host.Summary.Config.Product
+	watched.Vm = host.Vm
+	if host.Config != nil {
+		watched.Config = &types.HostConfigInfo{Network: host.Config.Network}
+	}
+	return watched
+}
+
+// vmWatchedState returns copy of the vm with only vmWatchProperties set.
+func vmWatchedState(vm mo.VirtualMachine) mo.VirtualMachine {
+	watched := mo.VirtualMachine{}
+	watched.Self = vm.Self
+	watched.Name = vm.Name
+	watched.Summary.CustomValue = vm.Summary.CustomValue
+	watched.Runtime.Host = vm.Runtime.Host
+	watched.Runtime.PowerState = vm.Runtime.PowerState
+	if vm.Guest != nil {
+		watched.Guest = &types.GuestInfo{GuestFullName: vm.Guest.GuestFullName, Net: vm.Guest.Net, IpStack: vm.Guest.IpStack}
+	}
+	if vm.Config != nil {
+		watched.Config = &types.VirtualMachineConfigInfo{Hardware: vm.Config.Hardware, GuestFullName: vm.Config.GuestFullName, Template: vm.Config.Template}
+	}
+	watched.Parent = vm.Parent
+	watched.ResourcePool = vm.ResourcePool
+	watched.ParentVApp = vm.ParentVApp
+	return watched
+}
+
+// applyChanges retrieves changed objects from vsphere and syncs them to the inventory.
+// Removed objects are only removed from the source, netbox objects are left to the
+// orphan manager on the next full resync.
+func (vc *VmwareSource) applyChanges(ctx context.Context, client *vim25.Client, nbi *inventory.NetboxInventory, changes *objectChanges) error {
+	propertyCollector := property.DefaultCollector(client)
+
+	if len(changes.Portgroups) > 0 {
+		var dvpgs []mo.DistributedVirtualPortgroup
+		err := propertyCollector.Retrieve(ctx, changes.Portgroups, dvpgProperties, &dvpgs)
+		if err != nil {
+			return fmt.Errorf("failed retrieving changed DistributedVirtualPortgroups: %s", err)
+		}
+		for _, dvpg := range dvpgs {
+			err := vc.addDistributedPortgroup(dvpg)
+			if err != nil {
+				return err
+			}
+			dvpgData, ok := vc.Networks.DistributedVirtualPortgroups[dvpg.Config.Key]
+			if !ok {
+				continue
+			}
+			vc.Logger.Debugf(vc.Ctx, "Syncing changed distributed portgroup %s", dvpgData.Name)
+			err = vc.syncDistributedPortgroup(nbi, dvpgData)
+			if err != nil {
+				vc.Logger.Errorf(vc.Ctx, "sync changed distributed portgroup %s: %s", dvpgData.Name, err)
+			}
+		}
+	}
+
+	if len(changes.Hosts) > 0 {
+		var hosts []mo.HostSystem
+		err := propertyCollector.Retrieve(ctx, changes.Hosts, hostProperties, &hosts)
+		if err != nil {
+			return fmt.Errorf("failed retrieving changed hosts: %s", err)
+		}
+		for _, host := range hosts {
+			vc.addHost(host)
+			vc.Logger.Debugf(vc.Ctx, "Syncing changed host %s", host.Name)
+			err := vc.syncHost(nbi, host.Self.Value, host)
+			if err != nil {
+				vc.Logger.Errorf(vc.Ctx, "sync changed host %s: %s", host.Name, err)
+			}
+		}
+	}
+
+	if len(changes.Vms) > 0 {
+		var vms []mo.VirtualMachine
+		err := propertyCollector.Retrieve(ctx, changes.Vms, vmProperties, &vms)
+		if err != nil {
+			return fmt.Errorf("failed retrieving changed vms: %s", err)
+		}
+		for _, vm := range vms {
+			vc.addVM(vm)
+			// Vm could be migrated to another host
+			if vm.Runtime.Host != nil {
+				vc.VM2Host[vm.Self.Value] = vm.Runtime.Host.Value
+			}
+			vc.Logger.Debugf(vc.Ctx, "Syncing changed vm %s", vm.Name)
+			err := vc.syncVM(nbi, vm.Self.Value, vm)
+			if err != nil {
+				vc.Logger.Errorf(vc.Ctx, "sync changed vm %s: %s", vm.Name, err)
+			}
+		}
+	}
+
+	for _, removed := range changes.Removed {
+		vc.Logger.Infof(vc.Ctx, "%s %s was removed from vsphere, it will be removed from netbox on the next full resync", removed.Type, removed.Value)
+		delete(vc.Hosts, removed.Value)
+		delete(vc.Vms, removed.Value)
+		delete(vc.VM2Placement, removed.Value)
+		delete(vc.VM2Host, removed.Value)
+	}
+	return nil
+}
@@ -3,17 +3,28 @@ package vmware
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
+// Properties of vsphere objects, that are collected in init functions
+// and watched for changes in incremental sync.
+var (
+	dvpgProperties = []string{"config"}
+	hostProperties = []string{"name", "summary.host", "summary.hardware", "summary.runtime", "summary.config", "vm", "config.network"}
+	vmProperties   = []string{"summary", "name", "runtime", "guest", "config.hardware", "config.guestFullName", "config.template", "parent", "resourcePool", "parentVApp"}
+)
+
 // In vsphere we get vlans from DistributedVirtualPortgroups.
 func (vc *VmwareSource) InitNetworks(ctx context.Context, containerView *view.ContainerView) error {
 	var dvpgs []mo.DistributedVirtualPortgroup
-	err := containerView.Retrieve(ctx, []string{"DistributedVirtualPortgroup"}, []string{"config"}, &dvpgs)
+	err := containerView.Retrieve(ctx, []string{"DistributedVirtualPortgroup"}, dvpgProperties, &dvpgs)
 	if err != nil {
 		return fmt.Errorf("failed retrieving DistributedVirtualPortgroups: %s", err)
 	}
@@ -25,56 +36,74 @@ func (vc *VmwareSource) InitNetworks(ctx context.Context, containerView *view.Co
 		HostPortgroups:               make(map[string]map[string]*HostPortgroupData),
 	}
 	for _, dvpg := range dvpgs {
-		if dvpg.Config.Key == "" || dvpg.Config.Name == "" {
+		err := vc.addDistributedPortgroup(dvpg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDistributedPortgroup stores vlan data of the distributed portgroup.
+func (vc *VmwareSource) addDistributedPortgroup(dvpg mo.DistributedVirtualPortgroup) error {
+	dvpgData, err := distributedPortgroupData(dvpg)
+	if err != nil || dvpgData == nil {
+		return err
+	}
+	for _, vid := range dvpgData.VlanIDs {
+		if vid == constants.UntaggedVID || vid == constants.TaggedVID {
 			continue
 		}
+		vc.Networks.Vid2Name[vid] = dvpgData.Name
+	}
+	vc.Networks.DistributedVirtualPortgroups[dvpg.Config.Key] = dvpgData
+	return nil
+}
 
-		if vlanInfo, ok := dvpg.Config.DefaultPortConfig.(*types.VMwareDVSPortSetting); ok {
-			var vlanIDs []int
-			var vlanIDRanges []string
-			private := false
-			switch v := vlanInfo.Vlan.(type) {
-			case *types.VmwareDistributedVirtualSwitchTrunkVlanSpec:
-				for _, item := range v.VlanId {
-					switch {
-					case item.Start == item.End:
-						vlanIDs = append(vlanIDs, int(item.Start))
-						vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d", item.Start))
-					case item.Start == constants.UntaggedVID && item.End == constants.MaxVID:
-						vlanIDs = append(vlanIDs, constants.TaggedVID)
-						vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d-%d", item.Start, item.End))
-					default:
-						for vlan := item.Start; vlan <= item.End; vlan++ {
-							vlanIDs = append(vlanIDs, int(vlan))
-							vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d-%d", item.Start, item.End))
-						}
-					}
-				}
-			case *types.VmwareDistributedVirtualSwitchPvlanSpec:
-				vlanIDs = append(vlanIDs, int(v.PvlanId))
-				private = true
-			case *types.VmwareDistributedVirtualSwitchVlanIdSpec:
-				vlanIDs = append(vlanIDs, int(v.VlanId))
+// distributedPortgroupData returns vlan data of the distributed portgroup,
+// or nil if the portgroup has no vlan settings.
+func distributedPortgroupData(dvpg mo.DistributedVirtualPortgroup) (*DistributedPortgroupData, error) {
+	if dvpg.Config.Key == "" || dvpg.Config.Name == "" {
+		return nil, nil
+	}
+	vlanInfo, ok := dvpg.Config.DefaultPortConfig.(*types.VMwareDVSPortSetting)
+	if !ok {
+		return nil, nil
+	}
+	var vlanIDs []int
+	var vlanIDRanges []string
+	private := false
+	switch v := vlanInfo.Vlan.(type) {
+	case *types.VmwareDistributedVirtualSwitchTrunkVlanSpec:
+		for _, item := range v.VlanId {
+			switch {
+			case item.Start == item.End:
+				vlanIDs = append(vlanIDs, int(item.Start))
+				vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d", item.Start))
+			case item.Start == constants.UntaggedVID && item.End == constants.MaxVID:
+				vlanIDs = append(vlanIDs, constants.TaggedVID)
+				vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d-%d", item.Start, item.End))
 			default:
-				return fmt.Errorf("unknown vlan info spec %T", v)
-			}
-
-			for _, vid := range vlanIDs {
-				if vid == constants.UntaggedVID || vid == constants.TaggedVID {
-					continue
+				for vlan := item.Start; vlan <= item.End; vlan++ {
+					vlanIDs = append(vlanIDs, int(vlan))
+					vlanIDRanges = append(vlanIDRanges, fmt.Sprintf("%d-%d", item.Start, item.End))
 				}
-				vc.Networks.Vid2Name[vid] = dvpg.Config.Name
-			}
-
-			vc.Networks.DistributedVirtualPortgroups[dvpg.Config.Key] = &DistributedPortgroupData{
-				Name:         dvpg.Config.Name,
-				VlanIDs:      vlanIDs,
-				VlanIDRanges: vlanIDRanges,
-				Private:      private,
 			}
 		}
+	case *types.VmwareDistributedVirtualSwitchPvlanSpec:
+		vlanIDs = append(vlanIDs, int(v.PvlanId))
+		private = true
+	case *types.VmwareDistributedVirtualSwitchVlanIdSpec:
+		vlanIDs = append(vlanIDs, int(v.VlanId))
+	default:
+		return nil, fmt.Errorf("unknown vlan info spec %T", v)
 	}
-	return nil
+	return &DistributedPortgroupData{
+		Name:         dvpg.Config.Name,
+		VlanIDs:      vlanIDs,
+		VlanIDRanges: vlanIDRanges,
+		Private:      private,
+	}, nil
 }
 
 func (vc *VmwareSource) InitDisks(ctx context.Context, containerView *view.ContainerView) error {
@@ -109,6 +138,22 @@ func (vc *VmwareSource) InitClusters(ctx context.Context, containerView *view.Co
 	if err != nil {
 		return fmt.Errorf("failed retrieving clusters: %s", err)
 	}
+	// Standalone hosts are not part of a cluster, but of their own ComputeResource.
+	// They are synced as a cluster with a single host, so vms on them get a cluster.
+	var computeResources []mo.ComputeResource
+	err = containerView.Retrieve(ctx, []string{"ComputeResource"}, []string{"summary", "host", "name"}, &computeResources)
+	if err != nil {
+		return fmt.Errorf("failed retrieving compute resources: %s", err)
+	}
+	for _, computeResource := range computeResources {
+		// Retrieved compute resources also include clusters
+		if computeResource.Self.Type != "ComputeResource" {
+			continue
+		}
+		computeResource.Name = standaloneClusterName(computeResource.Name)
+		clusters = append(clusters, mo.ClusterComputeResource{ComputeResource: computeResource})
+	}
+
 	vc.Host2Cluster = make(map[string]string)
 	vc.Clusters = make(map[string]mo.ClusterComputeResource, len(clusters))
 	for _, cluster := range clusters {
@@ -120,75 +165,205 @@ func (vc *VmwareSource) InitClusters(ctx context.Context, containerView *view.Co
 	return nil
 }
 
+// standaloneClusterName returns name of the cluster for standalone host
+// (e.g. "esxi01 (standalone)").
+func standaloneClusterName(computeResourceName string) string {
+	return fmt.Sprintf("%s (standalone)", computeResourceName)
+}
+
 func (vc *VmwareSource) InitHosts(ctx context.Context, containerView *view.ContainerView) error {
 	var hosts []mo.HostSystem
-	err := containerView.Retrieve(ctx, []string{"HostSystem"}, []string{"name", "summary.host", "summary.hardware", "summary.runtime", "summary.config", "vm", "config.network"}, &hosts)
+	err := containerView.Retrieve(ctx, []string{"HostSystem"}, hostProperties, &hosts)
 	if err != nil {
 		return fmt.Errorf("failed retrieving hosts: %s", err)
 	}
 	vc.VM2Host = make(map[string]string)
 	vc.Hosts = make(map[string]mo.HostSystem, len(hosts))
 	for _, host := range hosts {
-		vc.Hosts[host.Self.Value] = host
-		for _, vm := range host.Vm {
-			vc.VM2Host[vm.Value] = host.Self.Value
-		}
+		vc.addHost(host)
+	}
+	return nil
+}
 
-		// Add network data which is received from hosts
-		// Iterate over hosts virtual switches, needed to enrich data on physical interfaces
-		vc.Networks.HostVirtualSwitches[host.Name] = make(map[string]*HostVirtualSwitchData)
-		for _, vswitch := range host.Config.Network.Vswitch {
-			if vswitch.Name != "" {
-				vc.Networks.HostVirtualSwitches[host.Name][vswitch.Name] = &HostVirtualSwitchData{
-					mtu:   int(vswitch.Mtu),
-					pnics: vswitch.Pnic,
-				}
+// addHost stores the host, together with relations to its vms and its network data.
+func (vc *VmwareSource) addHost(host mo.HostSystem) {
+	vc.Hosts[host.Self.Value] = host
+	for _, vm := range host.Vm {
+		vc.VM2Host[vm.Value] = host.Self.Value
+	}
+
+	// Add network data which is received from hosts
+	// Iterate over hosts virtual switches, needed to enrich data on physical interfaces
+	vc.Networks.HostVirtualSwitches[host.Name] = make(map[string]*HostVirtualSwitchData)
+	for _, vswitch := range host.Config.Network.Vswitch {
+		if vswitch.Name != "" {
+			vc.Networks.HostVirtualSwitches[host.Name][vswitch.Name] = &HostVirtualSwitchData{
+				mtu:   int(vswitch.Mtu),
+				pnics: vswitch.Pnic,
 			}
 		}
-		// Iterate over hosts proxy switches, needed to enrich data on physical interfaces
-		// Also stores mtu data which is used for VM interfaces
-		vc.Networks.HostProxySwitches[host.Name] = make(map[string]*HostProxySwitchData)
-		for _, pswitch := range host.Config.Network.ProxySwitch {
-			if pswitch.DvsUuid != "" {
-				vc.Networks.HostProxySwitches[host.Name][pswitch.DvsUuid] = &HostProxySwitchData{
-					mtu:   int(pswitch.Mtu),
-					pnics: pswitch.Pnic,
-					name:  pswitch.DvsName,
-				}
+	}
+	// Iterate over hosts proxy switches, needed to enrich data on physical interfaces
+	// Also stores mtu data which is used for VM interfaces
+	vc.Networks.HostProxySwitches[host.Name] = make(map[string]*HostProxySwitchData)
+	for _, pswitch := range host.Config.Network.ProxySwitch {
+		if pswitch.DvsUuid != "" {
+			vc.Networks.HostProxySwitches[host.Name][pswitch.DvsUuid] = &HostProxySwitchData{
+				mtu:   int(pswitch.Mtu),
+				pnics: pswitch.Pnic,
+				name:  pswitch.DvsName,
 			}
 		}
-		// Iterate over hosts port groups, needed to enrich data on physical interfaces
-		vc.Networks.HostPortgroups[host.Name] = make(map[string]*HostPortgroupData)
-		for _, pgroup := range host.Config.Network.Portgroup {
-			if pgroup.Spec.Name != "" {
-				nicOrder := pgroup.ComputedPolicy.NicTeaming.NicOrder
-				pgroupNics := []string{}
-				if len(nicOrder.ActiveNic) > 0 {
-					pgroupNics = append(pgroupNics, nicOrder.ActiveNic...)
-				}
-				if len(nicOrder.StandbyNic) > 0 {
-					pgroupNics = append(pgroupNics, nicOrder.StandbyNic...)
-				}
-				vc.Networks.HostPortgroups[host.Name][pgroup.Spec.Name] = &HostPortgroupData{
-					vlanID:  int(pgroup.Spec.VlanId),
-					vswitch: pgroup.Spec.VswitchName,
-					nics:    pgroupNics,
-				}
+	}
+	// Iterate over hosts port groups, needed to enrich data on physical interfaces
+	vc.Networks.HostPortgroups[host.Name] = make(map[string]*HostPortgroupData)
+	for _, pgroup := range host.Config.Network.Portgroup {
+		if pgroup.Spec.Name != "" {
+			nicOrder := pgroup.ComputedPolicy.NicTeaming.NicOrder
+			pgroupNics := []string{}
+			if len(nicOrder.ActiveNic) > 0 {
+				pgroupNics = append(pgroupNics, nicOrder.ActiveNic...)
+			}
+			if len(nicOrder.StandbyNic) > 0 {
+				pgroupNics = append(pgroupNics, nicOrder.StandbyNic...)
+			}
+			vc.Networks.HostPortgroups[host.Name][pgroup.Spec.Name] = &HostPortgroupData{
+				vlanID:  int(pgroup.Spec.VlanId),
+				vswitch: pgroup.Spec.VswitchName,
+				nics:    pgroupNics,
 			}
 		}
 	}
-	return nil
 }
 
 func (vc *VmwareSource) InitVms(ctx context.Context, containerView *view.ContainerView) error {
 	var vms []mo.VirtualMachine
-	err := containerView.Retrieve(ctx, []string{"VirtualMachine"}, []string{"summary", "name", "runtime", "guest", "config.hardware", "config.guestFullName"}, &vms)
+	err := containerView.Retrieve(ctx, []string{"VirtualMachine"}, vmProperties, &vms)
 	if err != nil {
 		return fmt.Errorf("failed retrieving vms: %s", err)
 	}
+	// Folders, compute resources and resource pools are needed to determine
+	// inventory paths of vms
+	var entities []mo.ManagedEntity
+	err = containerView.Retrieve(ctx, []string{"Folder", "Datacenter", "ComputeResource", "ResourcePool"}, []string{"name", "parent"}, &entities)
+	if err != nil {
+		return fmt.Errorf("failed retrieving inventory entities: %s", err)
+	}
+	vc.InventoryEntities = make(map[string]mo.ManagedEntity, len(entities))
+	for _, entity := range entities {
+		vc.InventoryEntities[entity.Self.Value] = entity
+	}
 	vc.Vms = make(map[string]mo.VirtualMachine, len(vms))
+	vc.VM2Placement = make(map[string]VMPlacement, len(vms))
 	for _, vm := range vms {
-		vc.Vms[vm.Self.Value] = vm
+		vc.addVM(vm)
+	}
+	return nil
+}
+
+// addVM stores the vm together with its placement in vsphere inventory.
+func (vc *VmwareSource) addVM(vm mo.VirtualMachine) {
+	vc.Vms[vm.Self.Value] = vm
+	placement := VMPlacement{
+		Folder:       vc.inventoryPath(vm.Parent),
+		ResourcePool: vc.inventoryPath(vm.ResourcePool),
+	}
+	if vm.ParentVApp != nil {
+		placement.VApp = vc.InventoryEntities[vm.ParentVApp.Value].Name
+	} else if vm.ResourcePool != nil && vm.ResourcePool.Type == "VirtualApp" {
+		placement.VApp = vc.InventoryEntities[vm.ResourcePool.Value].Name
+	}
+	vc.VM2Placement[vm.Self.Value] = placement
+}
+
+// inventoryPath returns path of the entity in vsphere inventory (e.g. /DC1/vm/Finance).
+// Root folder is not part of the container view, so the path ends at the first
+// entity, that is not in InventoryEntities.
+func (vc *VmwareSource) inventoryPath(ref *types.ManagedObjectReference) string {
+	var path []string
+	// Visited entities guard against cycles in inconsistent data
+	visited := make(map[string]bool)
+	for ref != nil && !visited[ref.Value] {
+		visited[ref.Value] = true
+		entity, ok := vc.InventoryEntities[ref.Value]
+		if !ok || entity.Parent == nil {
+			break
+		}
+		path = append([]string{entity.Name}, path...)
+		ref = entity.Parent
+	}
+	if len(path) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(path, "/")
+}
+
+// InitContentLibrary collects items of all content libraries.
+func (vc *VmwareSource) InitContentLibrary(ctx context.Context, libraryManager *library.Manager) error {
+	libraries, err := libraryManager.GetLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving content libraries: %s", err)
+	}
+	vc.ContentLibraryItems = make([]ContentLibraryItem, 0)
+	for _, contentLibrary := range libraries {
+		items, err := libraryManager.GetLibraryItems(ctx, contentLibrary.ID)
+		if err != nil {
+			return fmt.Errorf("failed retrieving items of content library %s: %s", contentLibrary.Name, err)
+		}
+		for _, item := range items {
+			var description string
+			if item.Description != nil {
+				description = *item.Description
+			}
+			vc.ContentLibraryItems = append(vc.ContentLibraryItems, ContentLibraryItem{
+				Library:     contentLibrary.Name,
+				Name:        item.Name,
+				Type:        item.Type,
+				Description: description,
+			})
+		}
+	}
+	return nil
+}
+
+// InitTags collects vsphere tags attached to clusters, hosts and vms,
+// together with names of their categories.
+func (vc *VmwareSource) InitTags(ctx context.Context, tagManager *tags.Manager) error {
+	categories, err := tagManager.GetCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving tag categories: %s", err)
+	}
+	categoryID2Name := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryID2Name[category.ID] = category.Name
+	}
+
+	objectRefs := make([]mo.Reference, 0, len(vc.Clusters)+len(vc.Hosts)+len(vc.Vms))
+	for _, cluster := range vc.Clusters {
+		objectRefs = append(objectRefs, cluster.Self)
+	}
+	for _, host := range vc.Hosts {
+		objectRefs = append(objectRefs, host.Self)
+	}
+	for _, vm := range vc.Vms {
+		objectRefs = append(objectRefs, vm.Self)
+	}
+	vc.Object2Tags = make(map[string][]VsphereTag)
+	if len(objectRefs) == 0 {
+		return nil
+	}
+	attachedTags, err := tagManager.GetAttachedTagsOnObjects(ctx, objectRefs)
+	if err != nil {
+		return fmt.Errorf("failed retrieving attached tags: %s", err)
+	}
+	for _, objectTags := range attachedTags {
+		objectKey := objectTags.ObjectID.Reference().Value
+		for _, tag := range objectTags.Tags {
+			vc.Object2Tags[objectKey] = append(vc.Object2Tags[objectKey], VsphereTag{
+				Category: categoryID2Name[tag.CategoryID],
+				Name:     tag.Name,
+			})
+		}
 	}
 	return nil
 }
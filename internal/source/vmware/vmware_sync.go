@@ -17,34 +17,43 @@ import (
 
 func (vc *VmwareSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 	for _, dvpg := range vc.Networks.DistributedVirtualPortgroups {
-		// TODO: currently we are syncing only vlans
-		// Get vlanGroup from relations
-		vlanGroup, err := common.MatchVlanToGroup(vc.Ctx, nbi, dvpg.Name, vc.VlanGroupRelations)
+		err := vc.syncDistributedPortgroup(nbi, dvpg)
 		if err != nil {
-			return fmt.Errorf("vlanGroup: %s", err)
-		}
-		// Get tenant from relations
-		vlanTenant, err := common.MatchVlanToTenant(vc.Ctx, nbi, dvpg.Name, vc.VlanTenantRelations)
-		if err != nil {
-			return fmt.Errorf("vlanTenant: %s", err)
+			return err
 		}
-		if len(dvpg.VlanIDs) == 1 && len(dvpg.VlanIDRanges) == 0 {
-			_, err := nbi.AddVlan(vc.Ctx, &objects.Vlan{
-				NetboxObject: objects.NetboxObject{
-					Tags: vc.Config.SourceTags,
-					CustomFields: map[string]interface{}{
-						constants.CustomFieldSourceName: vc.SourceConfig.Name,
-					},
+	}
+	return nil
+}
+
+// syncDistributedPortgroup syncs vlan of the distributed portgroup.
+func (vc *VmwareSource) syncDistributedPortgroup(nbi *inventory.NetboxInventory, dvpg *DistributedPortgroupData) error {
+	// TODO: currently we are syncing only vlans
+	// Get vlanGroup from relations
+	vlanGroup, err := common.MatchVlanToGroup(vc.Ctx, nbi, dvpg.Name, vc.VlanGroupRelations)
+	if err != nil {
+		return fmt.Errorf("vlanGroup: %s", err)
+	}
+	// Get tenant from relations
+	vlanTenant, err := common.MatchVlanToTenant(vc.Ctx, nbi, dvpg.Name, vc.VlanTenantRelations)
+	if err != nil {
+		return fmt.Errorf("vlanTenant: %s", err)
+	}
+	if len(dvpg.VlanIDs) == 1 && len(dvpg.VlanIDRanges) == 0 {
+		_, err := nbi.AddVlan(vc.Ctx, &objects.Vlan{
+			NetboxObject: objects.NetboxObject{
+				Tags: vc.Config.SourceTags,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName: vc.SourceConfig.Name,
 				},
-				Name:   dvpg.Name,
-				Group:  vlanGroup,
-				Vid:    dvpg.VlanIDs[0],
-				Status: &objects.VlanStatusActive,
-				Tenant: vlanTenant,
-			})
-			if err != nil {
-				return err
-			}
+			},
+			Name:   dvpg.Name,
+			Group:  vlanGroup,
+			Vid:    dvpg.VlanIDs[0],
+			Status: &objects.VlanStatusActive,
+			Tenant: vlanTenant,
+		})
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -104,12 +113,30 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 			return fmt.Errorf("match cluster to tenant: %s", err)
 		}
 
+		clusterTags, clusterCustomFields, err := vc.syncObjectTags(nbi, clusterID)
+		if err != nil {
+			return fmt.Errorf("sync cluster tags: %s", err)
+		}
+		clusterCustomFields[constants.CustomFieldSourceName] = vc.SourceConfig.Name
+		tagSite, err := common.MatchTagsToSite(vc.Ctx, nbi, vc.objectTagNames(clusterID), vc.TagSiteRelations)
+		if err != nil {
+			return fmt.Errorf("match cluster tags to site: %s", err)
+		}
+		if tagSite != nil {
+			clusterSite = tagSite
+		}
+		tagTenant, err := common.MatchTagsToTenant(vc.Ctx, nbi, vc.objectTagNames(clusterID), vc.TagTenantRelations)
+		if err != nil {
+			return fmt.Errorf("match cluster tags to tenant: %s", err)
+		}
+		if tagTenant != nil {
+			clusterTenant = tagTenant
+		}
+
 		nbCluster := &objects.Cluster{
 			NetboxObject: objects.NetboxObject{
-				Tags: vc.Config.SourceTags,
-				CustomFields: map[string]interface{}{
-					constants.CustomFieldSourceName: vc.SourceConfig.Name,
-				},
+				Tags:         clusterTags,
+				CustomFields: clusterCustomFields,
 			},
 			Name:   clusterName,
 			Type:   clusterType,
@@ -130,116 +157,138 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 // custom role Server.
 func (vc *VmwareSource) syncHosts(nbi *inventory.NetboxInventory) error {
 	for hostID, host := range vc.Hosts {
-		var err error
-		hostName := host.Name
-		hostCluster := nbi.ClustersIndexByName[vc.Clusters[vc.Host2Cluster[hostID]].Name]
-
-		hostSite, err := common.MatchHostToSite(vc.Ctx, nbi, hostName, vc.HostSiteRelations)
+		err := vc.syncHost(nbi, hostID, host)
 		if err != nil {
-			return fmt.Errorf("hostSite: %s", err)
+			return err
 		}
-		hostTenant, err := common.MatchHostToTenant(vc.Ctx, nbi, hostName, vc.HostTenantRelations)
-		if err != nil {
-			return fmt.Errorf("hostTenant: %s", err)
-		}
-		hostAssetTag := host.Summary.Hardware.Uuid
-		hostModel := host.Summary.Hardware.Model
-
-		var hostSerialNumber string
-		// find serial number from  host summary.hardware.OtherIdentifyingInfo (vmware specific logic)
-		serialInfoTypes := map[string]bool{
-			"EnclosureSerialNumberTag": true,
-			"ServiceTag":               true,
-			"SerialNumberTag":          true,
-		}
-		for _, info := range host.Summary.Hardware.OtherIdentifyingInfo {
-			infoType := info.IdentifierType.GetElementDescription().Key
-			if serialInfoTypes[infoType] {
-				if info.IdentifierValue != "" {
-					hostSerialNumber = info.IdentifierValue
-					break
-				}
+	}
+	return nil
+}
+
+// syncHost syncs the host as netbox device, together with its nics.
+func (vc *VmwareSource) syncHost(nbi *inventory.NetboxInventory, hostID string, host mo.HostSystem) error {
+	var err error
+	hostName := host.Name
+	hostCluster := nbi.ClustersIndexByName[vc.Clusters[vc.Host2Cluster[hostID]].Name]
+
+	hostSite, err := vc.matchHostToSite(nbi, hostID)
+	if err != nil {
+		return fmt.Errorf("hostSite: %s", err)
+	}
+	hostTenant, err := common.MatchHostToTenant(vc.Ctx, nbi, hostName, vc.HostTenantRelations)
+	if err != nil {
+		return fmt.Errorf("hostTenant: %s", err)
+	}
+	tagTenant, err := common.MatchTagsToTenant(vc.Ctx, nbi, vc.objectTagNames(hostID), vc.TagTenantRelations)
+	if err != nil {
+		return fmt.Errorf("match host tags to tenant: %s", err)
+	}
+	if tagTenant != nil {
+		hostTenant = tagTenant
+	}
+	hostTags, hostCustomFields, err := vc.syncObjectTags(nbi, hostID)
+	if err != nil {
+		return fmt.Errorf("sync host tags: %s", err)
+	}
+	hostAssetTag := host.Summary.Hardware.Uuid
+	hostModel := host.Summary.Hardware.Model
+
+	var hostSerialNumber string
+	// find serial number from  host summary.hardware.OtherIdentifyingInfo (vmware specific logic)
+	serialInfoTypes := map[string]bool{
+		"EnclosureSerialNumberTag": true,
+		"ServiceTag":               true,
+		"SerialNumberTag":          true,
+	}
+	for _, info := range host.Summary.Hardware.OtherIdentifyingInfo {
+		infoType := info.IdentifierType.GetElementDescription().Key
+		if serialInfoTypes[infoType] {
+			if info.IdentifierValue != "" {
+				hostSerialNumber = info.IdentifierValue
+				break
 			}
 		}
+	}
 
-		manufacturerName := host.Summary.Hardware.Vendor
-		var hostManufacturer *objects.Manufacturer
-		if manufacturerName == "" {
-			manufacturerName = constants.DefaultManufacturer
-		}
-		hostManufacturer, err = nbi.AddManufacturer(vc.Ctx, &objects.Manufacturer{
-			Name: manufacturerName,
-			Slug: utils.Slugify(manufacturerName),
-		})
-		if err != nil {
-			return fmt.Errorf("failed adding vmware Manufacturer %v with error: %s", hostManufacturer, err)
-		}
+	manufacturerName := host.Summary.Hardware.Vendor
+	var hostManufacturer *objects.Manufacturer
+	if manufacturerName == "" {
+		manufacturerName = constants.DefaultManufacturer
+	}
+	hostManufacturer, err = nbi.AddManufacturer(vc.Ctx, &objects.Manufacturer{
+		Name: manufacturerName,
+		Slug: utils.Slugify(manufacturerName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed adding vmware Manufacturer %v with error: %s", hostManufacturer, err)
+	}
 
-		var hostDeviceType *objects.DeviceType
-		hostDeviceType, err = nbi.AddDeviceType(vc.Ctx, &objects.DeviceType{
-			Manufacturer: hostManufacturer,
-			Model:        hostModel,
-			Slug:         utils.Slugify(hostModel),
-		})
-		if err != nil {
-			return fmt.Errorf("failed adding vmware DeviceType %v with error: %s", hostDeviceType, err)
-		}
+	var hostDeviceType *objects.DeviceType
+	hostDeviceType, err = nbi.AddDeviceType(vc.Ctx, &objects.DeviceType{
+		Manufacturer: hostManufacturer,
+		Model:        hostModel,
+		Slug:         utils.Slugify(hostModel),
+	})
+	if err != nil {
+		return fmt.Errorf("failed adding vmware DeviceType %v with error: %s", hostDeviceType, err)
+	}
 
-		var hostStatus *objects.DeviceStatus
-		switch host.Summary.Runtime.ConnectionState {
-		case "connected":
-			hostStatus = &objects.DeviceStatusActive
-		default:
-			hostStatus = &objects.DeviceStatusOffline
-		}
+	var hostStatus *objects.DeviceStatus
+	switch host.Summary.Runtime.ConnectionState {
+	case "connected":
+		hostStatus = &objects.DeviceStatusActive
+	default:
+		hostStatus = &objects.DeviceStatusOffline
+	}
 
-		var hostPlatform *objects.Platform
-		osType := host.Summary.Config.Product.Name
-		osVersion := host.Summary.Config.Product.Version
-		platformName := utils.GeneratePlatformName(osType, osVersion)
-		hostPlatform, err = nbi.AddPlatform(vc.Ctx, &objects.Platform{
-			Name: platformName,
-			Slug: utils.Slugify(platformName),
-		})
-		if err != nil {
-			return fmt.Errorf("failed adding vmware Platform %v with error: %s", hostPlatform, err)
-		}
+	var hostPlatform *objects.Platform
+	osType := host.Summary.Config.Product.Name
+	osVersion := host.Summary.Config.Product.Version
+	platformName := utils.GeneratePlatformName(osType, osVersion)
+	hostPlatform, err = nbi.AddPlatform(vc.Ctx, &objects.Platform{
+		Name: platformName,
+		Slug: utils.Slugify(platformName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed adding vmware Platform %v with error: %s", hostPlatform, err)
+	}
 
-		hostCPUCores := host.Summary.Hardware.NumCpuCores
-		hostMemGB := host.Summary.Hardware.MemorySize / constants.KiB / constants.KiB / constants.KiB
+	hostCPUCores := host.Summary.Hardware.NumCpuCores
+	hostMemGB := host.Summary.Hardware.MemorySize / constants.KiB / constants.KiB / constants.KiB
 
-		hostDeviceRole, err := nbi.AddDeviceRole(vc.Ctx, &objects.DeviceRole{Name: constants.DeviceRoleServer, Slug: utils.Slugify(constants.DeviceRoleServer), Color: constants.DeviceRoleServerColor, VMRole: false})
-		if err != nil {
-			return err
-		}
+	hostDeviceRole, err := nbi.AddDeviceRole(vc.Ctx, &objects.DeviceRole{Name: constants.DeviceRoleServer, Slug: utils.Slugify(constants.DeviceRoleServer), Color: constants.DeviceRoleServerColor, VMRole: false})
+	if err != nil {
+		return err
+	}
 
-		nbHost := &objects.Device{
-			NetboxObject: objects.NetboxObject{Tags: vc.Config.SourceTags, CustomFields: map[string]interface{}{
-				constants.CustomFieldSourceName:       vc.SourceConfig.Name,
-				constants.CustomFieldHostCPUCoresName: fmt.Sprintf("%d", hostCPUCores),
-				constants.CustomFieldHostMemoryName:   fmt.Sprintf("%d GB", hostMemGB),
-			}},
-			Name:         hostName,
-			Status:       hostStatus,
-			Platform:     hostPlatform,
-			DeviceRole:   hostDeviceRole,
-			Site:         hostSite,
-			Tenant:       hostTenant,
-			Cluster:      hostCluster,
-			SerialNumber: hostSerialNumber,
-			AssetTag:     hostAssetTag,
-			DeviceType:   hostDeviceType,
-		}
-		nbHost, err = nbi.AddDevice(vc.Ctx, nbHost)
-		if err != nil {
-			return fmt.Errorf("failed to add vmware host %s with error: %v", host.Name, err)
-		}
+	hostCustomFields[constants.CustomFieldSourceName] = vc.SourceConfig.Name
+	hostCustomFields[constants.CustomFieldHostCPUCoresName] = fmt.Sprintf("%d", hostCPUCores)
+	hostCustomFields[constants.CustomFieldHostMemoryName] = fmt.Sprintf("%d GB", hostMemGB)
+	nbHost := &objects.Device{
+		NetboxObject: objects.NetboxObject{
+			Tags:         hostTags,
+			CustomFields: hostCustomFields,
+		},
+		Name:         hostName,
+		Status:       hostStatus,
+		Platform:     hostPlatform,
+		DeviceRole:   hostDeviceRole,
+		Site:         hostSite,
+		Tenant:       hostTenant,
+		Cluster:      hostCluster,
+		SerialNumber: hostSerialNumber,
+		AssetTag:     hostAssetTag,
+		DeviceType:   hostDeviceType,
+	}
+	nbHost, err = nbi.AddDevice(vc.Ctx, nbHost)
+	if err != nil {
+		return fmt.Errorf("failed to add vmware host %s with error: %v", host.Name, err)
+	}
 
-		// We also need to sync nics separately, because nic is a separate object in netbox
-		err = vc.syncHostNics(nbi, host, nbHost)
-		if err != nil {
-			return fmt.Errorf("failed to sync vmware host %s nics with error: %v", host.Name, err)
-		}
+	// We also need to sync nics separately, because nic is a separate object in netbox
+	err = vc.syncHostNics(nbi, host, nbHost)
+	if err != nil {
+		return fmt.Errorf("failed to sync vmware host %s nics with error: %v", host.Name, err)
 	}
 	return nil
 }
@@ -644,152 +693,359 @@ func (vc *VmwareSource) collectHostVirtualNicData(nbi *inventory.NetboxInventory
 
 func (vc *VmwareSource) syncVms(nbi *inventory.NetboxInventory) error {
 	for vmKey, vm := range vc.Vms {
-		// Check if vm is a template, we don't add templates into netbox.
-		if vm.Config != nil {
-			if vm.Config.Template {
-				continue
-			}
+		err := vc.syncVM(nbi, vmKey, vm)
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		vmName := vm.Name
-		vmHostName := vc.Hosts[vc.VM2Host[vmKey]].Name
-
-		// Tenant is received from VmTenantRelations
-		vmTenant, err := common.MatchVMToTenant(vc.Ctx, nbi, vmName, vc.VMTenantRelations)
+// syncContentLibrary syncs items of content libraries as tags, so they don't mix
+// with platforms, which represent operating systems of devices and vms.
+func (vc *VmwareSource) syncContentLibrary(nbi *inventory.NetboxInventory) error {
+	for _, item := range vc.ContentLibraryItems {
+		description := item.Description
+		if description == "" || len(description) > objects.MaxDescriptionLength {
+			description = fmt.Sprintf("Item of type %s from content library %s", item.Type, item.Library)
+		}
+		tagName := fmt.Sprintf("%s/%s", item.Library, item.Name)
+		_, err := nbi.AddTag(vc.Ctx, &objects.Tag{
+			Name:        tagName,
+			Slug:        constants.ContentLibraryTagSlugPrefix + utils.Slugify(tagName),
+			Color:       constants.DefaultContentLibraryTagColor,
+			Description: description,
+		})
 		if err != nil {
-			return fmt.Errorf("vm's Tenant: %s", err)
+			return fmt.Errorf("failed adding content library item %s as tag: %s", item.Name, err)
 		}
+	}
+	return nil
+}
+
+// syncVM syncs the vm, together with its contacts and interfaces.
+func (vc *VmwareSource) syncVM(nbi *inventory.NetboxInventory, vmKey string, vm mo.VirtualMachine) error {
+	// Templates are added into netbox only if syncTemplates is enabled.
+	vmIsTemplate := vm.Config != nil && vm.Config.Template
+	if vmIsTemplate && !vc.SourceConfig.SyncTemplates {
+		return nil
+	}
+
+	vmName := vm.Name
+	vmHostName := vc.Hosts[vc.VM2Host[vmKey]].Name
+
+	// Placement attributes (e.g. "folder:/DC1/vm/Finance/vm1") are matched
+	// together with vm's tags in tag relations
+	vmPlacement := vc.VM2Placement[vmKey]
+	vmPlacementAttributes := vmPlacement.Attributes(vmName)
+	vmAttributes := append(vc.objectTagNames(vmKey), vmPlacementAttributes...)
+
+	// Tenant is received from VmTenantRelations. Relations on vm's placement
+	// have priority over relations on vm's name
+	vmTenant, err := common.MatchVMToTenant(vc.Ctx, nbi, vmName, vc.VMTenantRelations)
+	if err != nil {
+		return fmt.Errorf("vm's Tenant: %s", err)
+	}
 
-		// Site is the same as the Host
-		vmSite, err := common.MatchHostToSite(vc.Ctx, nbi, vmHostName, vc.HostSiteRelations)
+	placementTenant, err := common.MatchTagsToTenant(vc.Ctx, nbi, vmPlacementAttributes, vc.VMPlacementTenantRelations)
+	if err != nil {
+		return fmt.Errorf("match vm placement to tenant: %s", err)
+	}
+	if placementTenant != nil {
+		vmTenant = placementTenant
+	}
+
+	tagTenant, err := common.MatchTagsToTenant(vc.Ctx, nbi, vmAttributes, vc.TagTenantRelations)
+	if err != nil {
+		return fmt.Errorf("match vm tags to tenant: %s", err)
+	}
+	if tagTenant != nil {
+		vmTenant = tagTenant
+	}
+
+	// Role is received from TagRoleRelations
+	vmRole, err := common.MatchTagsToVMRole(vc.Ctx, nbi, vmAttributes, vc.TagRoleRelations)
+	if err != nil {
+		return fmt.Errorf("vm's Role: %s", err)
+	}
+	if vmIsTemplate {
+		vmRole, err = nbi.AddDeviceRole(vc.Ctx, &objects.DeviceRole{
+			Name:   constants.DeviceRoleVMTemplate,
+			Slug:   utils.Slugify(constants.DeviceRoleVMTemplate),
+			Color:  constants.DeviceRoleVMTemplateColor,
+			VMRole: true,
+		})
 		if err != nil {
-			return fmt.Errorf("vm's Site: %s", err)
+			return fmt.Errorf("vm template's Role: %s", err)
 		}
-		vmHost := nbi.DevicesIndexByNameAndSiteID[vmHostName][vmSite.ID]
+	}
 
-		// Cluster of the vm is same as the host
-		vmCluster := vmHost.Cluster
+	// Site is the same as the Host
+	hostSite, err := vc.matchHostToSite(nbi, vc.VM2Host[vmKey])
+	if err != nil {
+		return fmt.Errorf("vm's Site: %s", err)
+	}
+	vmHost := nbi.DevicesIndexByNameAndSiteID[vmHostName][hostSite.ID]
+
+	// Cluster of the vm is same as the host
+	var vmCluster *objects.Cluster
+	if vmHost != nil {
+		vmCluster = vmHost.Cluster
+	}
 
-		// VM status
-		vmStatus := &objects.VMStatusOffline
-		vmPowerState := vm.Runtime.PowerState
-		if vmPowerState == types.VirtualMachinePowerStatePoweredOn {
-			vmStatus = &objects.VMStatusActive
+	// Site can be overridden with TagSiteRelations, as long as it
+	// doesn't conflict with the site of vm's cluster
+	vmSite := hostSite
+	tagSite, err := common.MatchTagsToSite(vc.Ctx, nbi, vmAttributes, vc.TagSiteRelations)
+	if err != nil {
+		return fmt.Errorf("match vm tags to site: %s", err)
+	}
+	if tagSite != nil {
+		if vmCluster != nil && vmCluster.Site != nil && vmCluster.Site.ID != tagSite.ID {
+			vc.Logger.Warningf(vc.Ctx, "matched site %s for vm %s conflicts with site %s of its cluster, using cluster's site", tagSite.Name, vmName, vmCluster.Site.Name)
+		} else {
+			vmSite = tagSite
 		}
+	}
 
-		// vmVCPUs
-		vmVCPUs := vm.Config.Hardware.NumCPU
+	// VM status
+	vmStatus := &objects.VMStatusOffline
+	vmPowerState := vm.Runtime.PowerState
+	if vmPowerState == types.VirtualMachinePowerStatePoweredOn {
+		vmStatus = &objects.VMStatusActive
+	}
 
-		// vmMemory
-		vmMemory := vm.Config.Hardware.MemoryMB
+	// vmVCPUs
+	vmVCPUs := vm.Config.Hardware.NumCPU
 
-		// DisksSize
-		vmDiskSizeB := int64(0)
-		for _, hwDevice := range vm.Config.Hardware.Device {
-			if disk, ok := hwDevice.(*types.VirtualDisk); ok {
-				vmDiskSizeB += disk.CapacityInBytes
-			}
-		}
+	// vmMemory
+	vmMemory := vm.Config.Hardware.MemoryMB
 
-		// vmPlatform
-		vmPlatformName := vm.Config.GuestFullName
-		if vmPlatformName == "" {
-			vmPlatformName = vm.Guest.GuestFullName
+	// DisksSize
+	vmDiskSizeB := int64(0)
+	for _, hwDevice := range vm.Config.Hardware.Device {
+		if disk, ok := hwDevice.(*types.VirtualDisk); ok {
+			vmDiskSizeB += disk.CapacityInBytes
 		}
-		if vmPlatformName == "" {
-			vmPlatformName = utils.GeneratePlatformName(constants.DefaultOSName, constants.DefaultOSVersion)
-		}
-		vmPlatform, err := nbi.AddPlatform(vc.Ctx, &objects.Platform{
-			Name: vmPlatformName,
-			Slug: utils.Slugify(vmPlatformName),
-		})
-		if err != nil {
-			return fmt.Errorf("failed adding vmware vm's Platform %v with error: %s", vmPlatform, err)
-		}
-
-		// Extract additional info from CustomFields
-		var vmOwners []string
-		var vmOwnerEmails []string
-		var vmDescription string
-		vmCustomFields := map[string]interface{}{}
-		if len(vm.Summary.CustomValue) > 0 {
-			for _, field := range vm.Summary.CustomValue {
-				if field, ok := field.(*types.CustomFieldStringValue); ok {
-					fieldName := vc.CustomFieldID2Name[field.Key]
-
-					if mappedField, ok := vc.CustomFieldMappings[fieldName]; ok {
-						switch mappedField {
-						case "owner":
-							vmOwners = strings.Split(field.Value, ",")
-						case "email":
-							vmOwnerEmails = strings.Split(field.Value, ",")
-						case "description":
-							vmDescription = strings.TrimSpace(field.Value)
-						}
-					} else {
-						fieldName = utils.Alphanumeric(fieldName)
-						if _, ok := nbi.CustomFieldsIndexByName[fieldName]; !ok {
-							_, err := nbi.AddCustomField(vc.Ctx, &objects.CustomField{
-								Name:                  fieldName,
-								Type:                  objects.CustomFieldTypeText,
-								CustomFieldUIVisible:  &objects.CustomFieldUIVisibleIfSet,
-								CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
-								ContentTypes:          []string{constants.ContentTypeVirtualizationVirtualMachine},
-							})
-							if err != nil {
-								return fmt.Errorf("vm's custom field %s: %s", fieldName, err)
-							}
+	}
+
+	// vmPlatform
+	vmPlatformName := vm.Config.GuestFullName
+	if vmPlatformName == "" {
+		vmPlatformName = vm.Guest.GuestFullName
+	}
+	if vmPlatformName == "" {
+		vmPlatformName = utils.GeneratePlatformName(constants.DefaultOSName, constants.DefaultOSVersion)
+	}
+	vmPlatform, err := nbi.AddPlatform(vc.Ctx, &objects.Platform{
+		Name: vmPlatformName,
+		Slug: utils.Slugify(vmPlatformName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed adding vmware vm's Platform %v with error: %s", vmPlatform, err)
+	}
+
+	vmTags, vmCustomFields, err := vc.syncObjectTags(nbi, vmKey)
+	if err != nil {
+		return fmt.Errorf("vm's tags: %s", err)
+	}
+
+	// Extract additional info from CustomFields
+	var vmOwners []string
+	var vmOwnerEmails []string
+	var vmDescription string
+	if len(vm.Summary.CustomValue) > 0 {
+		for _, field := range vm.Summary.CustomValue {
+			if field, ok := field.(*types.CustomFieldStringValue); ok {
+				fieldName := vc.CustomFieldID2Name[field.Key]
+
+				if mappedField, ok := vc.CustomFieldMappings[fieldName]; ok {
+					switch mappedField {
+					case "owner":
+						vmOwners = strings.Split(field.Value, ",")
+					case "email":
+						vmOwnerEmails = strings.Split(field.Value, ",")
+					case "description":
+						vmDescription = strings.TrimSpace(field.Value)
+					}
+				} else {
+					fieldName = utils.Alphanumeric(fieldName)
+					if _, ok := nbi.CustomFieldsIndexByName[fieldName]; !ok {
+						_, err := nbi.AddCustomField(vc.Ctx, &objects.CustomField{
+							Name:                  fieldName,
+							Type:                  objects.CustomFieldTypeText,
+							CustomFieldUIVisible:  &objects.CustomFieldUIVisibleIfSet,
+							CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
+							ContentTypes:          []string{constants.ContentTypeVirtualizationVirtualMachine},
+						})
+						if err != nil {
+							return fmt.Errorf("vm's custom field %s: %s", fieldName, err)
 						}
-						vmCustomFields[fieldName] = field.Value
 					}
+					vmCustomFields[fieldName] = field.Value
 				}
 			}
 		}
-		vmCustomFields[constants.CustomFieldSourceName] = vc.SourceConfig.Name
+	}
+	vmCustomFields[constants.CustomFieldSourceName] = vc.SourceConfig.Name
 
-		// netbox description has constraint <= len(200 characters)
-		// In this case we make a comment
-		var vmComments string
-		if len(vmDescription) >= objects.MaxDescriptionLength {
-			vmDescription = "See comments."
-			vmComments = vmDescription
+	err = vc.addPlacementCustomFields(nbi)
+	if err != nil {
+		return fmt.Errorf("vm's placement custom fields: %s", err)
+	}
+	// Placement custom fields are cleared, when the vm leaves the folder, resource pool or vapp
+	placementCustomFields := map[string]string{
+		constants.CustomFieldVMFolderName:       vmPlacement.Folder,
+		constants.CustomFieldVMResourcePoolName: vmPlacement.ResourcePool,
+		constants.CustomFieldVMVAppName:         vmPlacement.VApp,
+	}
+	for fieldName, value := range placementCustomFields {
+		if value == "" {
+			vmCustomFields[fieldName] = nil
+		} else {
+			vmCustomFields[fieldName] = value
 		}
+	}
 
-		newVM, err := nbi.AddVM(vc.Ctx, &objects.VM{
-			NetboxObject: objects.NetboxObject{
-				Tags:         vc.Config.SourceTags,
-				Description:  vmDescription,
-				CustomFields: vmCustomFields,
-			},
-			Name:     vmName,
-			Cluster:  vmCluster,
-			Site:     vmSite,
-			Tenant:   vmTenant,
-			Status:   vmStatus,
-			Host:     vmHost,
-			Platform: vmPlatform,
-			VCPUs:    float32(vmVCPUs),
-			Memory:   int(vmMemory),                                                    // MBs
-			Disk:     int(vmDiskSizeB / constants.KiB / constants.KiB / constants.KiB), // GBs
-			Comments: vmComments,
-		})
+	// netbox description has constraint <= len(200 characters)
+	// In this case we make a comment
+	var vmComments string
+	if len(vmDescription) >= objects.MaxDescriptionLength {
+		vmDescription = "See comments."
+		vmComments = vmDescription
+	}
 
-		if err != nil {
-			return fmt.Errorf("failed to sync vmware VM %s: %v", vmName, err)
-		}
+	newVM, err := nbi.AddVM(vc.Ctx, &objects.VM{
+		NetboxObject: objects.NetboxObject{
+			Tags:         vmTags,
+			Description:  vmDescription,
+			CustomFields: vmCustomFields,
+		},
+		Name:     vmName,
+		Role:     vmRole,
+		Cluster:  vmCluster,
+		Site:     vmSite,
+		Tenant:   vmTenant,
+		Status:   vmStatus,
+		Host:     vmHost,
+		Platform: vmPlatform,
+		VCPUs:    float32(vmVCPUs),
+		Memory:   int(vmMemory),                                                    // MBs
+		Disk:     int(vmDiskSizeB / constants.KiB / constants.KiB / constants.KiB), // GBs
+		Comments: vmComments,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to sync vmware VM %s: %v", vmName, err)
+	}
+
+	err = vc.addVMContact(nbi, newVM, vmOwners, vmOwnerEmails)
+	if err != nil {
+		return fmt.Errorf("adding %s's contact: %s", newVM, err)
+	}
+
+	// Sync vm interfaces
+	err = vc.syncVMInterfaces(nbi, vm, newVM)
+	if err != nil {
+		return fmt.Errorf("failed to sync vmware %s's interfaces: %v", newVM, err)
+	}
+	return nil
+}
 
-		err = vc.addVMContact(nbi, newVM, vmOwners, vmOwnerEmails)
+// matchHostToSite returns site of the host. Site matched by host's tags
+// has priority over site matched by host's name.
+func (vc *VmwareSource) matchHostToSite(nbi *inventory.NetboxInventory, hostKey string) (*objects.Site, error) {
+	tagSite, err := common.MatchTagsToSite(vc.Ctx, nbi, vc.objectTagNames(hostKey), vc.TagSiteRelations)
+	if err != nil {
+		return nil, err
+	}
+	if tagSite != nil {
+		return tagSite, nil
+	}
+	return common.MatchHostToSite(vc.Ctx, nbi, vc.Hosts[hostKey].Name, vc.HostSiteRelations)
+}
+
+// addPlacementCustomFields adds custom fields for storing vm's placement in vsphere,
+// if they don't exist yet.
+func (vc *VmwareSource) addPlacementCustomFields(nbi *inventory.NetboxInventory) error {
+	return common.AddVMCustomFields(vc.Ctx, nbi, []common.VMCustomField{
+		{Name: constants.CustomFieldVMFolderName, Label: constants.CustomFieldVMFolderLabel, Description: constants.CustomFieldVMFolderDescription},
+		{Name: constants.CustomFieldVMResourcePoolName, Label: constants.CustomFieldVMResourcePoolLabel, Description: constants.CustomFieldVMResourcePoolDescription},
+		{Name: constants.CustomFieldVMVAppName, Label: constants.CustomFieldVMVAppLabel, Description: constants.CustomFieldVMVAppDescription},
+	})
+}
+
+// objectTagNames returns vsphere tags attached to the object in format "category/tag".
+func (vc *VmwareSource) objectTagNames(objectKey string) []string {
+	tagNames := make([]string, 0, len(vc.Object2Tags[objectKey]))
+	for _, tag := range vc.Object2Tags[objectKey] {
+		tagNames = append(tagNames, tag.String())
+	}
+	return tagNames
+}
+
+// syncObjectTags syncs vsphere tags attached to the object. Tags from categories listed
+// in tagCategoryCustomFields are returned as custom fields (category -> tag names),
+// which are cleared if the object has no tags from the category, and the rest are
+// synced as netbox tags, filtered with vmTagAllowlist.
+// Returned netbox tags also include source tags.
+func (vc *VmwareSource) syncObjectTags(nbi *inventory.NetboxInventory, objectKey string) ([]*objects.Tag, map[string]interface{}, error) {
+	nbTags := make([]*objects.Tag, 0, len(vc.SourceTags))
+	nbTags = append(nbTags, vc.SourceTags...)
+	category2Values := make(map[string][]string)
+	for _, tag := range vc.Object2Tags[objectKey] {
+		if slices.Contains(vc.SourceConfig.TagCategoryCustomFields, tag.Category) {
+			category2Values[tag.Category] = append(category2Values[tag.Category], tag.Name)
+			continue
+		}
+		if len(vc.SourceConfig.VMTagAllowlist) > 0 && !slices.Contains(vc.SourceConfig.VMTagAllowlist, tag.Name) && !slices.Contains(vc.SourceConfig.VMTagAllowlist, tag.Category) {
+			continue
+		}
+		tagName := vc.SourceConfig.VMTagPrefix + tag.Name
+		nbTag, err := nbi.AddTag(vc.Ctx, &objects.Tag{
+			Name:        tagName,
+			Slug:        utils.Slugify(tagName),
+			Color:       constants.DefaultVMTagColor,
+			Description: fmt.Sprintf("Tag %s from vsphere category %s", tag.Name, tag.Category),
+		})
 		if err != nil {
-			return fmt.Errorf("adding %s's contact: %s", newVM, err)
+			return nil, nil, fmt.Errorf("add tag %s: %s", tagName, err)
 		}
+		nbTags = append(nbTags, nbTag)
+	}
 
-		// Sync vm interfaces
-		err = vc.syncVMInterfaces(nbi, vm, newVM)
-		if err != nil {
-			return fmt.Errorf("failed to sync vmware %s's interfaces: %v", newVM, err)
+	customFields := make(map[string]interface{}, len(vc.SourceConfig.TagCategoryCustomFields))
+	for _, category := range vc.SourceConfig.TagCategoryCustomFields {
+		fieldName := utils.Alphanumeric(category)
+		values, ok := category2Values[category]
+		if !ok {
+			// Clear the custom field, when all tags of the category are removed from the object
+			if _, ok := nbi.CustomFieldsIndexByName[fieldName]; ok {
+				customFields[fieldName] = nil
+			}
+			continue
 		}
+		if _, ok := nbi.CustomFieldsIndexByName[fieldName]; !ok {
+			_, err := nbi.AddCustomField(vc.Ctx, &objects.CustomField{
+				Name:                  fieldName,
+				Label:                 category,
+				Type:                  objects.CustomFieldTypeText,
+				CustomFieldUIVisible:  &objects.CustomFieldUIVisibleIfSet,
+				CustomFieldUIEditable: &objects.CustomFieldUIEditableYes,
+				Description:           fmt.Sprintf("Tags from vsphere category %s", category),
+				ContentTypes: []string{
+					constants.ContentTypeVirtualizationCluster,
+					constants.ContentTypeDcimDevice,
+					constants.ContentTypeVirtualizationVirtualMachine,
+				},
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("add custom field %s: %s", fieldName, err)
+			}
+		}
+		customFields[fieldName] = strings.Join(values, ", ")
 	}
-	return nil
+	return nbTags, customFields, nil
 }
 
 // Syncs VM's interfaces to Netbox.
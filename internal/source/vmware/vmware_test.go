@@ -1 +1,388 @@
 package vmware
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/logger"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/rest"
+	_ "github.com/vmware/govmomi/vapi/simulator" // Registers vapi endpoints in the simulator
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestVmwareSource_InitTags(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		restClient := rest.NewClient(c)
+		err := restClient.Login(ctx, simulator.DefaultLogin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tagManager := tags.NewManager(restClient)
+		categoryID, err := tagManager.CreateCategory(ctx, &tags.Category{
+			Name:        "Owner",
+			Cardinality: "SINGLE",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tagID, err := tagManager.CreateTag(ctx, &tags.Tag{
+			Name:       "team1",
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vmRef := simulator.Map.Any("VirtualMachine").Reference()
+		hostRef := simulator.Map.Any("HostSystem").Reference()
+		err = tagManager.AttachTag(ctx, tagID, vmRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vc := &VmwareSource{
+			Hosts: map[string]mo.HostSystem{hostRef.Value: {ManagedEntity: mo.ManagedEntity{ExtensibleManagedObject: mo.ExtensibleManagedObject{Self: hostRef}}}},
+			Vms:   map[string]mo.VirtualMachine{vmRef.Value: {ManagedEntity: mo.ManagedEntity{ExtensibleManagedObject: mo.ExtensibleManagedObject{Self: vmRef}}}},
+		}
+		err = vc.InitTags(ctx, tagManager)
+		if err != nil {
+			t.Fatalf("VmwareSource.InitTags() error = %v", err)
+		}
+		want := map[string][]VsphereTag{
+			vmRef.Value: {{Category: "Owner", Name: "team1"}},
+		}
+		if !reflect.DeepEqual(vc.Object2Tags, want) {
+			t.Errorf("VmwareSource.Object2Tags = %v, want %v", vc.Object2Tags, want)
+		}
+		if got := vc.objectTagNames(vmRef.Value); !reflect.DeepEqual(got, []string{"Owner/team1"}) {
+			t.Errorf("VmwareSource.objectTagNames() = %v, want %v", got, []string{"Owner/team1"})
+		}
+	})
+}
+
+func TestCollectChanges(t *testing.T) {
+	vmRef := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}
+	hostRef := types.ManagedObjectReference{Type: "HostSystem", Value: "host-1"}
+	dvpgRef := types.ManagedObjectReference{Type: "DistributedVirtualPortgroup", Value: "dvportgroup-1"}
+	removedRef := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-2"}
+	tests := []struct {
+		name    string
+		updates []types.ObjectUpdate
+		want    *objectChanges
+	}{
+		{
+			name:    "No updates",
+			updates: []types.ObjectUpdate{},
+			want:    &objectChanges{},
+		},
+		{
+			name: "Updates grouped by type",
+			updates: []types.ObjectUpdate{
+				{Kind: types.ObjectUpdateKindModify, Obj: vmRef},
+				{Kind: types.ObjectUpdateKindModify, Obj: vmRef},
+				{Kind: types.ObjectUpdateKindEnter, Obj: hostRef},
+				{Kind: types.ObjectUpdateKindModify, Obj: dvpgRef},
+				{Kind: types.ObjectUpdateKindLeave, Obj: removedRef},
+			},
+			want: &objectChanges{
+				Portgroups: []types.ManagedObjectReference{dvpgRef},
+				Hosts:      []types.ManagedObjectReference{hostRef},
+				Vms:        []types.ManagedObjectReference{vmRef},
+				Removed:    []types.ManagedObjectReference{removedRef},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectChanges(tt.updates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVmwareSource_watchUpdates(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		testLogger, err := logger.New("", logger.DEBUG)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vc := &VmwareSource{Config: common.Config{Logger: testLogger, Ctx: ctx}}
+		vmRef := simulator.Map.Any("VirtualMachine").Reference()
+		vm := object.NewVirtualMachine(c, vmRef)
+
+		watchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		// Keep changing power state of the vm, until the change is received
+		go func() {
+			powerOn := false
+			for watchCtx.Err() == nil {
+				var task *object.Task
+				var err error
+				if powerOn {
+					task, err = vm.PowerOn(watchCtx)
+				} else {
+					task, err = vm.PowerOff(watchCtx)
+				}
+				if err == nil {
+					_ = task.Wait(watchCtx)
+				}
+				powerOn = !powerOn
+				time.Sleep(100 * time.Millisecond)
+			}
+		}()
+
+		// Nothing was synced yet, so the first batch contains current state of all objects
+		var initial, received *objectChanges
+		err = vc.watchUpdates(watchCtx, c, func(changes *objectChanges) error {
+			if initial == nil {
+				initial = changes
+				return nil
+			}
+			for _, changedVM := range changes.Vms {
+				if changedVM == vmRef {
+					received = changes
+					cancel()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("VmwareSource.watchUpdates() error = %v", err)
+		}
+		if initial == nil || !slices.Contains(initial.Vms, vmRef) {
+			t.Errorf("VmwareSource.watchUpdates() did not receive initial state of vm %s", vmRef.Value)
+		}
+		if received == nil {
+			t.Errorf("VmwareSource.watchUpdates() did not receive change of vm %s", vmRef.Value)
+		}
+	})
+}
+
+func TestVmwareSource_watchUpdatesSkipsSyncedObjects(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		testLogger, err := logger.New("", logger.DEBUG)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// State of objects as collected by the full sync
+		containerView, err := view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"DistributedVirtualPortgroup", "HostSystem", "VirtualMachine"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var dvpgs []mo.DistributedVirtualPortgroup
+		var hosts []mo.HostSystem
+		var vms []mo.VirtualMachine
+		if err := containerView.Retrieve(ctx, []string{"DistributedVirtualPortgroup"}, dvpgProperties, &dvpgs); err != nil {
+			t.Fatal(err)
+		}
+		if err := containerView.Retrieve(ctx, []string{"HostSystem"}, hostProperties, &hosts); err != nil {
+			t.Fatal(err)
+		}
+		if err := containerView.Retrieve(ctx, []string{"VirtualMachine"}, vmProperties, &vms); err != nil {
+			t.Fatal(err)
+		}
+		vc := &VmwareSource{
+			Config:   common.Config{Logger: testLogger, Ctx: ctx},
+			Hosts:    make(map[string]mo.HostSystem),
+			Vms:      make(map[string]mo.VirtualMachine),
+			Networks: NetworkData{DistributedVirtualPortgroups: make(map[string]*DistributedPortgroupData), Vid2Name: make(map[int]string)},
+		}
+		for _, dvpg := range dvpgs {
+			if err := vc.addDistributedPortgroup(dvpg); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, host := range hosts {
+			vc.Hosts[host.Self.Value] = host
+		}
+		for _, vm := range vms {
+			vc.Vms[vm.Self.Value] = vm
+		}
+		// Vm changed after the full sync
+		changedVM := vms[0]
+		changedVM.Runtime.PowerState = types.VirtualMachinePowerStateSuspended
+		vc.Vms[changedVM.Self.Value] = changedVM
+
+		watchCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+		var received []*objectChanges
+		err = vc.watchUpdates(watchCtx, c, func(changes *objectChanges) error {
+			received = append(received, changes)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("VmwareSource.watchUpdates() error = %v", err)
+		}
+		want := []*objectChanges{{Vms: []types.ManagedObjectReference{changedVM.Self}}}
+		if !reflect.DeepEqual(received, want) {
+			t.Errorf("VmwareSource.watchUpdates() received %v, want %v", received, want)
+		}
+	})
+}
+
+func TestVMPlacement_Attributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement VMPlacement
+		vmName    string
+		want      []string
+	}{
+		{
+			name:      "Empty placement",
+			placement: VMPlacement{},
+			vmName:    "vm1",
+			want:      nil,
+		},
+		{
+			name: "Full placement",
+			placement: VMPlacement{
+				Folder:       "/DC1/vm/Finance",
+				ResourcePool: "/DC1/host/Cluster1/Resources/Pool1",
+				VApp:         "app1",
+			},
+			vmName: "vm1",
+			want: []string{
+				"folder:/DC1/vm/Finance/vm1",
+				"resourcePool:/DC1/host/Cluster1/Resources/Pool1",
+				"vapp:app1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.placement.Attributes(tt.vmName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VMPlacement.Attributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPlacementRelation(t *testing.T) {
+	tests := []struct {
+		regex string
+		want  bool
+	}{
+		{regex: "folder:/DC1/vm/Finance/.*", want: true},
+		{regex: "^resourcePool:.*/Pool1$", want: true},
+		{regex: "vapp:app1", want: true},
+		{regex: ".*Health", want: false},
+		{regex: ".*", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.regex, func(t *testing.T) {
+			if got := common.IsAttributeRelation(tt.regex, placementAttributePrefixes); got != tt.want {
+				t.Errorf("common.IsAttributeRelation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVmwareSource_InitVmsPlacement(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		containerView, err := view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"Datacenter", "VirtualMachine", "Folder", "ComputeResource", "ResourcePool"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vc := &VmwareSource{}
+		err = vc.InitVms(ctx, containerView)
+		if err != nil {
+			t.Fatalf("VmwareSource.InitVms() error = %v", err)
+		}
+		if len(vc.Vms) == 0 {
+			t.Fatal("VmwareSource.InitVms() collected no vms")
+		}
+		for vmKey, vm := range vc.Vms {
+			placement := vc.VM2Placement[vmKey]
+			if placement.Folder != "/DC0/vm" {
+				t.Errorf("VmwareSource.VM2Placement[%s].Folder = %s, want %s", vm.Name, placement.Folder, "/DC0/vm")
+			}
+			if !strings.HasPrefix(placement.ResourcePool, "/DC0/host/") || !strings.HasSuffix(placement.ResourcePool, "/Resources") {
+				t.Errorf("VmwareSource.VM2Placement[%s].ResourcePool = %s, want /DC0/host/*/Resources", vm.Name, placement.ResourcePool)
+			}
+		}
+	})
+}
+
+func TestVmwareSource_InitClustersStandalone(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		containerView, err := view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"ComputeResource"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vc := &VmwareSource{}
+		err = vc.InitClusters(ctx, containerView)
+		if err != nil {
+			t.Fatalf("VmwareSource.InitClusters() error = %v", err)
+		}
+		// Default vcsim inventory contains standalone host DC0_H0 and cluster DC0_C0
+		clusterNames := make([]string, 0, len(vc.Clusters))
+		for _, cluster := range vc.Clusters {
+			clusterNames = append(clusterNames, cluster.Name)
+		}
+		slices.Sort(clusterNames)
+		want := []string{"DC0_C0", "DC0_H0 (standalone)"}
+		if !reflect.DeepEqual(clusterNames, want) {
+			t.Errorf("VmwareSource.Clusters names = %v, want %v", clusterNames, want)
+		}
+		for _, cluster := range vc.Clusters {
+			for _, host := range cluster.Host {
+				if vc.Host2Cluster[host.Value] != cluster.Self.Value {
+					t.Errorf("VmwareSource.Host2Cluster[%s] = %s, want %s", host.Value, vc.Host2Cluster[host.Value], cluster.Self.Value)
+				}
+			}
+		}
+	})
+}
+
+func TestVmwareSource_InitContentLibrary(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		restClient := rest.NewClient(c)
+		err := restClient.Login(ctx, simulator.DefaultLogin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		libraryManager := library.NewManager(restClient)
+		datastoreRef := simulator.Map.Any("Datastore").Reference()
+		libraryID, err := libraryManager.CreateLibrary(ctx, library.Library{
+			Name: "images",
+			Type: "LOCAL",
+			Storage: []library.StorageBackings{
+				{DatastoreID: datastoreRef.Value, Type: "DATASTORE"},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = libraryManager.CreateLibraryItem(ctx, library.Item{
+			Name:      "ubuntu-22.04",
+			Type:      "ovf",
+			LibraryID: libraryID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vc := &VmwareSource{}
+		err = vc.InitContentLibrary(ctx, libraryManager)
+		if err != nil {
+			t.Fatalf("VmwareSource.InitContentLibrary() error = %v", err)
+		}
+		want := []ContentLibraryItem{{Library: "images", Name: "ubuntu-22.04", Type: "ovf"}}
+		if !reflect.DeepEqual(vc.ContentLibraryItems, want) {
+			t.Errorf("VmwareSource.ContentLibraryItems = %v, want %v", vc.ContentLibraryItems, want)
+		}
+	})
+}
@@ -14,7 +14,11 @@ import (
 // We assume that Choice attribute is always the first attribute of an object.
 func isChoiceEmbedded(v reflect.Value) bool {
 	vType := v.Type()
-	return vType.Field(0).Type == reflect.TypeOf(objects.Choice{})
+	if vType.NumField() == 0 {
+		return false
+	}
+	fieldType := vType.Field(0).Type
+	return fieldType == reflect.TypeOf(objects.Choice{}) || fieldType == reflect.TypeOf(objects.IntChoice{})
 	// for i := 0; i < v.NumField(); i++ {
 	// 	if vType.Field(i).Type == reflect.TypeOf(objects.Choice{}) {
 	// 		return true
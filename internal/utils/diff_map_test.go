@@ -536,7 +536,21 @@ func Test_isChoiceEmbedded(t *testing.T) {
 		args args
 		want bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "String choice",
+			args: args{v: reflect.ValueOf(objects.DeviceStatusActive)},
+			want: true,
+		},
+		{
+			name: "Integer choice",
+			args: args{v: reflect.ValueOf(objects.IKEVersion2)},
+			want: true,
+		},
+		{
+			name: "Struct without choice",
+			args: args{v: reflect.ValueOf(objects.Tag{Name: "test"})},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
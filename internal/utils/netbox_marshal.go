@@ -31,6 +31,7 @@ func StructToNetboxJSONMap(obj interface{}) map[string]interface{} {
 		fieldValue := v.Field(i)
 		fieldType := v.Type().Field(i)
 		jsonTag := fieldType.Tag.Get("json")
+		omitEmpty := strings.Contains(jsonTag, ",omitempty")
 		jsonTag = strings.Split(jsonTag, ",")[0]
 
 		if fieldType.Name == "ID" {
@@ -51,8 +52,9 @@ func StructToNetboxJSONMap(obj interface{}) map[string]interface{} {
 			fieldValue = fieldValue.Elem()
 		}
 
-		// If a field is empty we skip it
-		if !fieldValue.IsValid() || fieldValue.IsZero() {
+		// If a field is empty we skip it, unless its json tag
+		// is without omitempty (e.g. group_id, where 0 is a valid value)
+		if !fieldValue.IsValid() || (fieldValue.IsZero() && omitEmpty) {
 			continue
 		}
 
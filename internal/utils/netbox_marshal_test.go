@@ -279,6 +279,16 @@ func TestStructToNetboxJSONMap(t *testing.T) {
 			},
 			want: map[string]interface{}{"tags": []interface{}{int64(1), int64(2), int64(3)}},
 		},
+		{
+			name: "Keep zero values of attributes without omitempty",
+			args: args{
+				obj: interface{}(objects.FHRPGroup{
+					Name:     "HSRP 0",
+					Protocol: &objects.FHRPGroupProtocolHSRP,
+				}),
+			},
+			want: map[string]interface{}{"name": "HSRP 0", "protocol": "hsrp", "group_id": 0},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {